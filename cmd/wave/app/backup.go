@@ -0,0 +1,144 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"cthul.io/cthul/pkg/backup"
+	"github.com/spf13/cobra"
+)
+
+// NewBackupCmd creates the backup subcommand, it writes all cluster resources to an archive.
+func NewBackupCmd(flags *cliFlags) *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:          "backup",
+		SilenceUsage: true,
+		Short:        "export all cluster resources to an archive",
+		Long: `backup exports all cluster resources (domains, devices, disks and interfaces) to a portable json archive.
+Resource configs are stored in their json representation, runtime state (e.g. node registrations) is not exported.`,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := LoadConfig(flags.configPath)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer cancel()
+
+			dbClient, err := createDatabase(&config.Database)
+			if err != nil {
+				return err
+			}
+			defer dbClient.Terminate(ctx)
+
+			archive, err := backup.Snapshot(ctx, dbClient)
+			if err != nil {
+				return err
+			}
+
+			if output == "-" {
+				return backup.Write(os.Stdout, archive)
+			}
+			file, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			if err := backup.Write(file, archive); err != nil {
+				return err
+			}
+			return file.Sync()
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "-", "path of the archive file ('-' for stdout)")
+
+	return cmd
+}
+
+// NewRestoreCmd creates the restore subcommand, it imports cluster resources from an archive.
+func NewRestoreCmd(flags *cliFlags) *cobra.Command {
+	var (
+		input  string
+		dryRun bool
+		filter backup.Filter
+	)
+	cmd := &cobra.Command{
+		Use:          "restore",
+		SilenceUsage: true,
+		Short:        "import cluster resources from an archive",
+		Long: fmt.Sprintf(`restore imports cluster resources from an archive created by the backup command.
+Resources can be selected by kind (%v) and id, by default the full archive is restored.
+Resources that exist in the cluster but not in the archive are not removed.`, backup.Kinds()),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := LoadConfig(flags.configPath)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer cancel()
+
+			file, err := os.Open(input)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			archive, err := backup.Read(file)
+			if err != nil {
+				return err
+			}
+
+			dbClient, err := createDatabase(&config.Database)
+			if err != nil {
+				return err
+			}
+			defer dbClient.Terminate(ctx)
+
+			changes, err := backup.Restore(ctx, dbClient, archive, filter, dryRun)
+			for _, change := range changes {
+				fmt.Fprintln(cmd.OutOrStdout(), change.String())
+			}
+			if err != nil {
+				return err
+			}
+			if dryRun {
+				fmt.Fprintf(cmd.OutOrStdout(), "%d change(s) would be applied (dry-run)\n", len(changes))
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "%d change(s) applied\n", len(changes))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&input, "input", "i", "", "path of the archive file")
+	cmd.MarkFlagRequired("input")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only print the changes without applying them")
+	cmd.Flags().StringSliceVar(&filter.Kinds, "kind", []string{}, "restore only resources of this kind (repeatable)")
+	cmd.Flags().StringSliceVar(&filter.Ids, "id", []string{}, "restore only resources with this id (repeatable)")
+
+	return cmd
+}
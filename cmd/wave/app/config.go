@@ -20,51 +20,99 @@
 package app
 
 import (
-	"github.com/BurntSushi/toml"
+	"errors"
+	"fmt"
+	"net"
 	"os"
+	"slices"
+
+	"github.com/BurntSushi/toml"
 )
 
 // BaseConfig represents the configuration model
 type BaseConfig struct {
-	NodeId    string          `toml:"nodeid" validate:"required"`
-	Lifecycle LifecycleConfig `toml:"lifecycle"`
-	Logging   LoggingConfig   `toml:"logging"`
-	Database  DatabaseConfig  `toml:"db"`
-	Scheduler SchedulerConfig `toml:"scheduler"`
-	Api       ApiConfig       `toml:"api"`
+	NodeId     string           `toml:"nodeid"`
+	Lifecycle  LifecycleConfig  `toml:"lifecycle"`
+	Logging    LoggingConfig    `toml:"logging"`
+	Database   DatabaseConfig   `toml:"db"`
+	Election   ElectionConfig   `toml:"election"`
+	Scheduler  SchedulerConfig  `toml:"scheduler"`
+	Rebalancer RebalancerConfig `toml:"rebalancer"`
+	Node       NodeConfig       `toml:"node"`
+	Api        ApiConfig        `toml:"api"`
 }
 
 type LifecycleConfig struct {
-	TerminationTTL int64 `toml:"termination_ttl" validate:"required"`
+	TerminationTTL int64 `toml:"termination_ttl"`
+	PhaseTTL       int64 `toml:"phase_ttl"`
 }
 
 type LoggingConfig struct {
-	Level  string `toml:"level" validate:"required,oneof=debug info warning error critical"`
+	Level  string `toml:"level"`
 	Trace  bool   `toml:"trace"`
-	Buffer int64  `toml:"buffer" validate:"gte=0,lte=4096"`
+	Buffer int64  `toml:"buffer"`
 }
 
 type DatabaseConfig struct {
-	Addr        string `toml:"addr" validate:"required,tcp_addr|unix_addr"`
-	Username    string `toml:"username" validate:"required"`
-	Password    string `toml:"password" validate:"required"`
-	TimeoutTTL  int64  `toml:"timeout_ttl" validate:"required"`
+	Type        string `toml:"type"`
+	Path        string `toml:"path"`
+	Addr        string `toml:"addr"`
+	Username    string `toml:"username"`
+	Password    string `toml:"password"`
+	TimeoutTTL  int64  `toml:"timeout_ttl"`
 	Healthcheck bool   `toml:"healthcheck"`
+	HealthTTL   int64  `toml:"health_ttl"`
 	SkipVerify  bool   `toml:"skipverify"`
 }
 
+type ElectionConfig struct {
+	Backend    string `toml:"backend"`
+	Contest    bool   `toml:"contest"`
+	ContestTTL int64  `toml:"contest_ttl"`
+}
+
 type SchedulerConfig struct {
-	CycleTTL int64 `toml:"cycle_ttl" validate:"required"`
-  RescheduleCycles int64 `toml:"reschedule_cycles" validate:"required"`
-	CpuThreshold int64 `toml:"cpu_threshold" validate:"gte=0,lte=1000"`
-	MemThreshold int64 `toml:"mem_threshold" validate:"gte=0,lte=1000"`
+	CycleTTL int64 `toml:"cycle_ttl"`
+  RescheduleCycles int64 `toml:"reschedule_cycles"`
+	CpuThreshold int64 `toml:"cpu_threshold"`
+	MemThreshold int64 `toml:"mem_threshold"`
+	CpuUsageFactor float64 `toml:"cpu_usage_factor"`
+	MemUsageFactor float64 `toml:"mem_usage_factor"`
+	SpreadWeight   float64 `toml:"spread_weight"`
+	BinpackWeight  float64 `toml:"binpack_weight"`
+	BalancedWeight float64 `toml:"balanced_weight"`
+	AffinityWeight float64 `toml:"affinity_weight"`
+}
+
+type RebalancerConfig struct {
+	Enabled       bool  `toml:"enabled"`
+	CycleTTL      int64 `toml:"cycle_ttl"`
+	HotThreshold  int64 `toml:"hot_threshold"`
+	SkewThreshold int64 `toml:"skew_threshold"`
+	MaxMoves      int64 `toml:"max_moves"`
+	Cooldown      int64 `toml:"cooldown"`
+	MoveRunning   bool  `toml:"move_running"`
+	DryRun        bool  `toml:"dry_run"`
+}
+
+type NodeConfig struct {
+	CycleTTL       int64             `toml:"cycle_ttl"`
+	Affinity       []string          `toml:"affinity"`
+	Labels         map[string]string `toml:"labels"`
+	Maintenance    bool              `toml:"maintenance"`
+	CpuFactor      float64           `toml:"cpu_factor"`
+	MemFactor      float64           `toml:"mem_factor"`
+	CpuOvercommit  float64           `toml:"cpu_overcommit"`
+	MemOvercommit  float64           `toml:"mem_overcommit"`
+	ReservedCpu    float64           `toml:"reserved_cpu"`
+	ReservedMemory int64             `toml:"reserved_memory"`
 }
 
 type ApiConfig struct {
-	Addr     string `toml:"addr" validate:"required,tcp_addr"`
+	Addr     string `toml:"addr"`
   Origins []string `toml:"origins"`
-	CertFile string `toml:"cert_file" validate:"required"`
-	KeyFile  string `toml:"key_file" validate:"required"`
+	CertFile string `toml:"cert_file"`
+	KeyFile  string `toml:"key_file"`
 }
 
 // LoadConfig reads the configuration file, decodes it (toml) and validates it.
@@ -81,5 +129,91 @@ func LoadConfig(path string) (*BaseConfig, error) {
 		return nil, err
 	}
 
+	if config.NodeId == "" {
+		config.NodeId, err = os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to use hostname as node id: %w", err)
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	return config, nil
 }
+
+// Validate checks the configuration against the constraints of the configuration model.
+// It is the single source of truth for the constraints, the config structs only define the toml mapping.
+// All violations are returned as joined error.
+func (c *BaseConfig) Validate() error {
+	errs := []error{}
+	check := func(ok bool, key, constraint string) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("'%s' must %s", key, constraint))
+		}
+	}
+
+	check(c.NodeId != "", "nodeid", "be set")
+	check(c.Lifecycle.TerminationTTL > 0, "lifecycle.termination_ttl", "be greater than 0")
+	check(c.Lifecycle.PhaseTTL >= 0, "lifecycle.phase_ttl", "not be negative")
+
+	check(slices.Contains([]string{"debug", "info", "warning", "error", "critical"}, c.Logging.Level),
+		"logging.level", "be one of 'debug', 'info', 'warning', 'error', 'critical'")
+	check(c.Logging.Buffer >= 0 && c.Logging.Buffer <= 4096, "logging.buffer", "be between 0 and 4096")
+
+	check(slices.Contains([]string{"", "etcd", "bolt"}, c.Database.Type), "db.type", "be one of 'etcd', 'bolt'")
+	if c.Database.Type == "bolt" {
+		check(c.Database.Path != "", "db.path", "be set")
+	} else {
+		check(c.Database.Addr != "", "db.addr", "be set")
+		check(c.Database.Username != "", "db.username", "be set")
+		check(c.Database.Password != "", "db.password", "be set")
+	}
+	check(c.Database.TimeoutTTL > 0, "db.timeout_ttl", "be greater than 0")
+	check(c.Database.HealthTTL >= 0, "db.health_ttl", "not be negative")
+
+	check(slices.Contains([]string{"", "cash", "lease"}, c.Election.Backend),
+		"election.backend", "be one of 'cash', 'lease'")
+	check(c.Election.ContestTTL > 0, "election.contest_ttl", "be greater than 0")
+
+	check(c.Scheduler.CycleTTL > 0, "scheduler.cycle_ttl", "be greater than 0")
+	check(c.Scheduler.RescheduleCycles > 0, "scheduler.reschedule_cycles", "be greater than 0")
+	check(c.Scheduler.CpuThreshold > 0 && c.Scheduler.CpuThreshold <= 1000,
+		"scheduler.cpu_threshold", "be between 1 and 1000")
+	check(c.Scheduler.MemThreshold > 0 && c.Scheduler.MemThreshold <= 1000,
+		"scheduler.mem_threshold", "be between 1 and 1000")
+	check(c.Scheduler.CpuUsageFactor > 0 && c.Scheduler.CpuUsageFactor <= 1,
+		"scheduler.cpu_usage_factor", "be between 0 (exclusive) and 1")
+	check(c.Scheduler.MemUsageFactor > 0 && c.Scheduler.MemUsageFactor <= 1,
+		"scheduler.mem_usage_factor", "be between 0 (exclusive) and 1")
+	check(c.Scheduler.SpreadWeight >= 0, "scheduler.spread_weight", "not be negative")
+	check(c.Scheduler.BinpackWeight >= 0, "scheduler.binpack_weight", "not be negative")
+	check(c.Scheduler.BalancedWeight >= 0, "scheduler.balanced_weight", "not be negative")
+	check(c.Scheduler.AffinityWeight >= 0, "scheduler.affinity_weight", "not be negative")
+
+	if c.Rebalancer.Enabled {
+		check(c.Rebalancer.CycleTTL > 0, "rebalancer.cycle_ttl", "be greater than 0")
+		check(c.Rebalancer.HotThreshold > 0 && c.Rebalancer.HotThreshold <= 100,
+			"rebalancer.hot_threshold", "be between 1 and 100")
+		check(c.Rebalancer.SkewThreshold > 0 && c.Rebalancer.SkewThreshold <= 100,
+			"rebalancer.skew_threshold", "be between 1 and 100")
+		check(c.Rebalancer.MaxMoves > 0, "rebalancer.max_moves", "be greater than 0")
+		check(c.Rebalancer.Cooldown >= 0, "rebalancer.cooldown", "not be negative")
+	}
+
+	check(c.Node.CycleTTL > 0, "node.cycle_ttl", "be greater than 0")
+	check(c.Node.CpuFactor > 0 && c.Node.CpuFactor <= 1, "node.cpu_factor", "be between 0 (exclusive) and 1")
+	check(c.Node.MemFactor > 0 && c.Node.MemFactor <= 1, "node.mem_factor", "be between 0 (exclusive) and 1")
+	check(c.Node.CpuOvercommit > 0, "node.cpu_overcommit", "be greater than 0")
+	check(c.Node.MemOvercommit > 0, "node.mem_overcommit", "be greater than 0")
+	check(c.Node.ReservedCpu >= 0, "node.reserved_cpu", "not be negative")
+	check(c.Node.ReservedMemory >= 0, "node.reserved_memory", "not be negative")
+
+	_, _, err := net.SplitHostPort(c.Api.Addr)
+	check(err == nil, "api.addr", "be a valid tcp address (host:port)")
+	check(c.Api.CertFile != "", "api.cert_file", "be set")
+	check(c.Api.KeyFile != "", "api.key_file", "be set")
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,152 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// reloadHook applies changed settings to a running component.
+type reloadHook struct {
+	// keys holds the settings (toml keys, e.g. "api.origins") applied by the hook.
+	keys []string
+	// files specifies that the settings reference files, which can change without changing the configuration.
+	files bool
+	apply func(*BaseConfig) error
+}
+
+// reloader re-reads the configuration file and applies changed settings to the running components through
+// reload hooks. Changed settings without reload hook cannot be applied live, they are reported as requiring
+// a restart until the service is restarted.
+type reloader struct {
+	path   string
+	logger *slog.Logger
+
+	lock sync.Mutex
+	// config holds the configuration of the running service (settings that require a restart are not updated).
+	config *BaseConfig
+	hooks  []reloadHook
+}
+
+func newReloader(logger *slog.Logger, path string, config *BaseConfig) *reloader {
+	return &reloader{
+		path:   path,
+		logger: logger.WithGroup("config-reloader"),
+		lock:   sync.Mutex{},
+		config: config,
+		hooks:  []reloadHook{},
+	}
+}
+
+// AddHook adds a reload hook that is executed if one of the specified settings changed.
+func (r *reloader) AddHook(apply func(*BaseConfig) error, keys ...string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.hooks = append(r.hooks, reloadHook{keys: keys, files: false, apply: apply})
+}
+
+// AddFileHook adds a reload hook for settings that reference files (e.g. certificates).
+// The hook is executed on every reload, as the referenced files may have changed in place.
+func (r *reloader) AddFileHook(apply func(*BaseConfig) error, keys ...string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.hooks = append(r.hooks, reloadHook{keys: keys, files: true, apply: apply})
+}
+
+// Reload reads and validates the configuration file and applies the changed settings.
+// Returns the applied settings and the changed settings that require a restart. If the configuration is
+// invalid, nothing is applied. Failing hooks are reported as error, the other hooks are still applied.
+// The settings of a failing hook are not applied, they are reported as requiring a restart together with the error.
+func (r *reloader) Reload() ([]string, []string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	config, err := LoadConfig(r.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	changed := map[string][]int{}
+	diffConfig(reflect.ValueOf(r.config).Elem(), reflect.ValueOf(config).Elem(), "", nil, changed)
+
+	applied, errs := []string{}, []error{}
+	for _, hook := range r.hooks {
+		hookChanged := []string{}
+		for _, key := range hook.keys {
+			if _, ok := changed[key]; ok {
+				hookChanged = append(hookChanged, key)
+			}
+		}
+		if len(hookChanged) == 0 && !hook.files {
+			continue
+		}
+
+		if err := hook.apply(config); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply %s: %w", strings.Join(hook.keys, ", "), err))
+			continue
+		}
+		for _, key := range hookChanged {
+			reflect.ValueOf(r.config).Elem().FieldByIndex(changed[key]).Set(
+				reflect.ValueOf(config).Elem().FieldByIndex(changed[key]),
+			)
+			delete(changed, key)
+		}
+		applied = append(applied, hookChanged...)
+	}
+
+	restart := []string{}
+	for key := range changed {
+		restart = append(restart, key)
+	}
+	sort.Strings(applied)
+	sort.Strings(restart)
+
+	if len(applied) > 0 {
+		r.logger.Info(fmt.Sprintf("applied settings: %s", strings.Join(applied, ", ")))
+	}
+	if len(restart) > 0 {
+		r.logger.Warn(fmt.Sprintf("settings require a restart: %s", strings.Join(restart, ", ")))
+	}
+	return applied, restart, errors.Join(errs...)
+}
+
+// diffConfig compares two configuration structs and adds every differing setting to the changed map
+// (toml key -> field index). Nested structs (toml tables) are compared by their individual settings.
+func diffConfig(old, new reflect.Value, prefix string, index []int, changed map[string][]int) {
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		key := prefix + strings.Split(field.Tag.Get("toml"), ",")[0]
+		fieldIndex := append(append([]int{}, index...), i)
+		if field.Type.Kind() == reflect.Struct {
+			diffConfig(old.Field(i), new.Field(i), key+".", fieldIndex, changed)
+			continue
+		}
+		if !reflect.DeepEqual(old.Field(i).Interface(), new.Field(i).Interface()) {
+			changed[key] = fieldIndex
+		}
+	}
+}
+
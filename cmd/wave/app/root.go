@@ -41,12 +41,14 @@ The service is installed on every cthul node that hosts virtual machines and dir
 				return err
 			}
 
-			return Run(config)
+			return Run(flags.configPath, config)
 		},
 	}
 
 	cmd.PersistentFlags().StringVarP(&flags.configPath,
 		"config", "c", "/etc/cthul/wave/config.toml", "path of the configuration file")
 
+	cmd.AddCommand(NewBackupCmd(flags), NewRestoreCmd(flags))
+
 	return cmd
 }
@@ -29,15 +29,22 @@ import (
 	"syscall"
 	"time"
 
+	"cthul.io/cthul/internal/elect"
 	"cthul.io/cthul/internal/wave/api"
 	"cthul.io/cthul/internal/wave/scheduler"
 	"cthul.io/cthul/pkg/adapter/domain/libvirt"
 	"cthul.io/cthul/pkg/adapter/domain/libvirt/generator"
 	"cthul.io/cthul/pkg/adapter/domain/libvirt/hotplug"
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/db/boltdb"
 	"cthul.io/cthul/pkg/db/etcdv3"
 	"cthul.io/cthul/pkg/granit/disk"
+	"cthul.io/cthul/pkg/informer"
+	"cthul.io/cthul/pkg/keyspace"
 	"cthul.io/cthul/pkg/lifecycle"
+	"cthul.io/cthul/pkg/migration"
 	"cthul.io/cthul/pkg/proton/inter"
+	"cthul.io/cthul/pkg/resource"
 	"cthul.io/cthul/pkg/wave/domain"
 	"cthul.io/cthul/pkg/wave/node"
 	"cthul.io/cthul/pkg/wave/serial"
@@ -52,23 +59,42 @@ import (
 // Run is the root entrypoint of the service.
 // This function does only fail if a critical error occurs while setting up the system,
 // otherwise it will run until an os level signal (SIGINT/TERM) is received.
-func Run(config *BaseConfig) error {
+// On SIGHUP the configuration is reloaded from the configPath (see reloader).
+func Run(configPath string, config *BaseConfig) error {
+	logLevel := &slog.LevelVar{}
+	logLevel.Set(parseLogLevel(config.Logging.Level))
   logger := slog.New(tint.NewHandler(os.Stdout, &tint.Options{
-    AddSource: true,
+    AddSource: config.Logging.Trace,
+    Level: logLevel,
     TimeFormat: time.Kitchen,
   }))
 
-	lifecycleManager := lifecycle.NewManager(logger.With("comp", "lifecycle-manager"))
-	defer lifecycleManager.TerminateParallel(
+	// the reloader applies configuration changes to the running components (SIGHUP or api call).
+	configReloader := newReloader(logger.With("comp", "config-reloader"), configPath, config)
+	configReloader.AddHook(func(c *BaseConfig) error {
+		logLevel.Set(parseLogLevel(c.Logging.Level))
+		return nil
+	}, "logging.level")
+
+	// components are terminated in phases ordered by their dependencies (api -> operators -> scheduler -> db),
+	// which ensures components can still reach the database while they release leader keys or registrations.
+	lifecycleManager := lifecycle.NewManager(logger.With("comp", "lifecycle-manager"),
+		lifecycle.WithPhaseTimeout(time.Second * time.Duration(config.Lifecycle.PhaseTTL)),
+	)
+	defer lifecycleManager.Terminate(
 		time.Second * time.Duration(config.Lifecycle.TerminationTTL),
 	)
 
-	dbClient := etcdv3.New([]string{config.Database.Addr},
-		etcdv3.WithAuth(config.Database.Username, config.Database.Password),
-		etcdv3.WithDialTimeout(time.Second * time.Duration(config.Database.TimeoutTTL)),
-		etcdv3.WithSkipVerify(config.Database.SkipVerify),
-	)
-	lifecycleManager.AddHook(dbClient.Terminate)
+	// components register their health probes, the aggregated state is reported to the cluster (node operator)
+	// and exposed on the api (/healthz, /readyz & grpc health service).
+	healthRegistry := lifecycle.NewHealth()
+
+	dbClient, err := createDatabase(&config.Database)
+	if err!=nil {
+		return err
+	}
+	lifecycleManager.AddNamedHook("db", dbClient.Terminate)
+	healthRegistry.AddProbe("db", lifecycle.PROBE_READINESS, dbClient.Health)
 
 	if config.Database.Healthcheck {
 		ctx, cancel := context.WithTimeout(
@@ -81,10 +107,36 @@ func Run(config *BaseConfig) error {
 		cancel()
 	}
 
-  nodeController := node.New(config.NodeId, dbClient)
+	// the database layout is migrated before any component accesses the database.
+	// Older binaries refuse to start on a newer layout, which prevents them from corrupting it.
+	migrationCtx, migrationCtxCancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	err = migration.New(logger.With("comp", "migrator"), dbClient, migration.Steps).Run(migrationCtx)
+	migrationCtxCancel()
+	if err!=nil {
+		return fmt.Errorf("database migration failed: %s", err.Error())
+	}
+
+	// informers cache the cluster state, controllers and the scheduler read from them instead of the database.
+	nodeInformer := informer.New(logger.With("comp", "node-informer"), dbClient, keyspace.WaveNode.Prefix())
+	domainInformer := resource.NewInformer(logger.With("comp", "domain-informer"), dbClient, keyspace.WaveDomain)
+	videoInformer := resource.NewInformer(logger.With("comp", "video-informer"), dbClient, keyspace.WaveVideo)
+	serialInformer := resource.NewInformer(logger.With("comp", "serial-informer"), dbClient, keyspace.WaveSerial)
+	informers := map[string]*informer.Informer{
+		"node-informer": nodeInformer,
+		"domain-informer": domainInformer,
+		"video-informer": videoInformer,
+		"serial-informer": serialInformer,
+	}
+	for name, inf := range informers {
+		inf.ServeAndDetach()
+		lifecycleManager.AddNamedHook(name, inf.Terminate, "db")
+		healthRegistry.AddProbe(name, lifecycle.PROBE_READINESS, inf.Health)
+	}
 
-  videoController := video.New(config.NodeId, dbClient)
-  serialController := serial.New(config.NodeId, dbClient)
+  nodeController := node.New(config.NodeId, dbClient, node.WithInformer(nodeInformer))
+
+  videoController := video.New(config.NodeId, dbClient, video.WithInformer(videoInformer))
+  serialController := serial.New(config.NodeId, dbClient, serial.WithInformer(serialInformer))
   diskController := disk.New(config.NodeId, dbClient)
   interController := inter.New(config.NodeId, dbClient)
   domainAdapter := libvirt.New(
@@ -96,70 +148,262 @@ func Run(config *BaseConfig) error {
     ),
     hotplug.New(),
   )
-  domainController := domain.New(config.NodeId, dbClient, domainAdapter, domain.WithRunRoot(
-    "/run/cthul/wave/",
-  ))
-	scheduler := scheduler.New(logger.With("comp", "scheduler"), dbClient, 
-    domainController, nodeController,
-    scheduler.WithCycleTTL(config.Scheduler.CycleTTL),
-    scheduler.WithRescheduleCycles(config.Scheduler.RescheduleCycles),
-	)
-	scheduler.ServeAndDetach()
-	scheduler.SetLeaderState("", true) // TODO use this in elect controller or not at all
-	lifecycleManager.AddHook(scheduler.Terminate)
-
+	healthRegistry.AddProbe("libvirt", lifecycle.PROBE_READINESS, domainAdapter.Health)
+  domainController := domain.New(config.NodeId, dbClient, domainAdapter,
+    domain.WithRunRoot("/run/cthul/wave/"),
+    domain.WithInformer(domainInformer),
+  )
 	domainOperator := domainop.New(logger.With("comp", "domain-operator"), dbClient, domainAdapter,
 		domainop.WithNodeId(config.NodeId),
+		domainop.WithInformer(domainInformer),
 		// TODO
 	)
 	domainOperator.ServeAndDetach()
-	lifecycleManager.AddHook(domainOperator.Terminate)
-
-	nodeOperator := nodeop.New(logger.With("comp", "node-operator"), dbClient, 
-		nodeop.WithNodeId(config.NodeId),
-		nodeop.WithAffinity("todo", "todo2"),
-		// TODO
+	healthRegistry.AddProbe("domain-operator", lifecycle.PROBE_READINESS, domainOperator.Health)
+	lifecycleManager.AddNamedHook("domain-operator", domainOperator.Terminate,
+		"db", "domain-informer", "video-informer", "serial-informer",
 	)
-	nodeOperator.ServeAndDetach()
-	lifecycleManager.AddHook(nodeOperator.Terminate)
 
 	serialOperator := serialop.New(logger.With("comp", "serial-operator"), dbClient, 
 		serialop.WithNodeId(config.NodeId),
 		// TODO
 	)
 	serialOperator.ServeAndDetach()
-	lifecycleManager.AddHook(serialOperator.Terminate)
+	healthRegistry.AddProbe("serial-operator", lifecycle.PROBE_READINESS, serialOperator.Health)
+	lifecycleManager.AddNamedHook("serial-operator", serialOperator.Terminate, "db")
 	
 	videoOperator := videoop.New(logger.With("comp", "video-operator"), dbClient, 
 		videoop.WithNodeId(config.NodeId),
 		// TODO
 	)
 	videoOperator.ServeAndDetach()
-	lifecycleManager.AddHook(videoOperator.Terminate)
+	healthRegistry.AddProbe("video-operator", lifecycle.PROBE_READINESS, videoOperator.Health)
+	lifecycleManager.AddNamedHook("video-operator", videoOperator.Terminate, "db")
+
+	nodeOperator := nodeop.New(logger.With("comp", "node-operator"), dbClient, 
+		nodeop.WithNodeId(config.NodeId),
+		nodeop.WithCycleTTL(config.Node.CycleTTL),
+		nodeop.WithAffinity(config.Node.Affinity...),
+		nodeop.WithLabels(config.Node.Labels),
+		nodeop.WithMaintenance(config.Node.Maintenance),
+		nodeop.WithResourceFactor(config.Node.CpuFactor, config.Node.MemFactor),
+		nodeop.WithOvercommit(config.Node.CpuOvercommit, config.Node.MemOvercommit),
+		nodeop.WithReservedCapacity(config.Node.ReservedCpu, config.Node.ReservedMemory),
+		nodeop.WithHealthChecks(healthRegistry.Ready),
+		// TODO
+	)
+	nodeOperator.ServeAndDetach()
+	configReloader.AddHook(func(c *BaseConfig) error {
+		nodeOperator.SetAffinity(c.Node.Affinity...)
+		nodeOperator.SetLabels(c.Node.Labels)
+		nodeOperator.SetMaintenance(c.Node.Maintenance)
+		nodeOperator.SetResourceFactor(c.Node.CpuFactor, c.Node.MemFactor)
+		nodeOperator.SetOvercommit(c.Node.CpuOvercommit, c.Node.MemOvercommit)
+		nodeOperator.SetReservedCapacity(c.Node.ReservedCpu, c.Node.ReservedMemory)
+		return nil
+	}, "node.affinity", "node.labels", "node.maintenance", "node.cpu_factor", "node.mem_factor",
+		"node.cpu_overcommit", "node.mem_overcommit", "node.reserved_cpu", "node.reserved_memory")
+	// the node operator reports the health of the other operators, therefore it is terminated before them.
+	lifecycleManager.AddNamedHook("node-operator", nodeOperator.Terminate,
+		"db", "domain-operator", "serial-operator", "video-operator",
+	)
+
+	// leader-only components are registered on the leader registry, they only run while this node is leader.
+	leaderRegistry := elect.NewRegistry(logger.With("comp", "leader-registry"))
+	lifecycleManager.AddNamedHook("leader-registry", leaderRegistry.Terminate, "scheduler")
+
+	// the election weight of the local node is computed from the node state measured by the node operator.
+	election, fence := createElection(
+		logger.With("comp", "election"), dbClient, config, leaderRegistry, nodeOperator.Cash,
+	)
+
+	schedulerOpts := []scheduler.Option{
+		scheduler.WithCycleTTL(config.Scheduler.CycleTTL),
+		scheduler.WithRescheduleCycles(config.Scheduler.RescheduleCycles),
+		scheduler.WithUsageFactors(config.Scheduler.CpuUsageFactor, config.Scheduler.MemUsageFactor),
+		scheduler.WithCommitThresholds(config.Scheduler.CpuThreshold, config.Scheduler.MemThreshold),
+		scheduler.WithScoreWeights(scoreWeights(config)),
+		scheduler.WithRebalanceCycleTTL(config.Rebalancer.CycleTTL),
+		scheduler.WithRebalanceThresholds(config.Rebalancer.HotThreshold, config.Rebalancer.SkewThreshold),
+		scheduler.WithRebalanceLimits(config.Rebalancer.MaxMoves, config.Rebalancer.Cooldown),
+		scheduler.WithRebalanceRunning(config.Rebalancer.MoveRunning),
+		scheduler.WithRebalanceDryRun(config.Rebalancer.DryRun),
+	}
+	if fence != nil {
+		schedulerOpts = append(schedulerOpts, scheduler.WithFence(fence))
+	}
+	scheduler := scheduler.New(logger.With("comp", "scheduler"), dbClient,
+		domainController, nodeController, schedulerOpts...,
+	)
+	leaderRegistry.Add("scheduler", scheduler.Lead)
+	if config.Rebalancer.Enabled {
+		leaderRegistry.Add("rebalancer", scheduler.Rebalance)
+	}
+	configReloader.AddHook(func(c *BaseConfig) error {
+		scheduler.SetCycleTTL(c.Scheduler.CycleTTL)
+		scheduler.SetRescheduleCycles(c.Scheduler.RescheduleCycles)
+		scheduler.SetUsageFactors(c.Scheduler.CpuUsageFactor, c.Scheduler.MemUsageFactor)
+		scheduler.SetCommitThresholds(c.Scheduler.CpuThreshold, c.Scheduler.MemThreshold)
+		scheduler.SetScoreWeights(scoreWeights(c))
+		return nil
+	}, "scheduler.cycle_ttl", "scheduler.reschedule_cycles", "scheduler.cpu_usage_factor", "scheduler.mem_usage_factor",
+		"scheduler.cpu_threshold", "scheduler.mem_threshold",
+		"scheduler.spread_weight", "scheduler.binpack_weight", "scheduler.balanced_weight", "scheduler.affinity_weight")
+	configReloader.AddHook(func(c *BaseConfig) error {
+		scheduler.SetRebalanceCycleTTL(c.Rebalancer.CycleTTL)
+		scheduler.SetRebalanceThresholds(c.Rebalancer.HotThreshold, c.Rebalancer.SkewThreshold)
+		scheduler.SetRebalanceLimits(c.Rebalancer.MaxMoves, c.Rebalancer.Cooldown)
+		scheduler.SetRebalanceRunning(c.Rebalancer.MoveRunning)
+		scheduler.SetRebalanceDryRun(c.Rebalancer.DryRun)
+		return nil
+	}, "rebalancer.cycle_ttl", "rebalancer.hot_threshold", "rebalancer.skew_threshold", "rebalancer.max_moves",
+		"rebalancer.cooldown", "rebalancer.move_running", "rebalancer.dry_run")
+	lifecycleManager.AddNamedHook("scheduler", scheduler.Terminate, "db", "domain-informer", "node-informer")
+
+	election.ServeAndDetach()
+	// the election hands over the leadership before the leader-only components are stopped.
+	lifecycleManager.AddNamedHook("election", election.Terminate, "db", "leader-registry", "node-operator")
 
 	apiCertificate, err := tls.LoadX509KeyPair(config.Api.CertFile, config.Api.KeyFile)
 	if err!=nil {
 		return err
 	}
-  _ = apiCertificate
 	apiEndpoint := api.New(config.Api.Addr,
     api.WithLogger(logger.With("comp", "api-endpoint")),
+    api.WithTLS(apiCertificate),
     api.WithOrigins(config.Api.Origins),
     api.WithDomain(domainController),
     api.WithVideo(videoController),
     api.WithSerial(serialController),
-    api.WithNode(nodeController),
+    api.WithNode(nodeController, election.Leader, election.StepDown, configReloader.Reload),
+    api.WithHealth(healthRegistry),
 	)
 	if err := apiEndpoint.ServeAndDetach(); err!=nil {
 		return err
 	}
-	lifecycleManager.AddHook(apiEndpoint.Terminate)
+	healthRegistry.AddProbe("api", lifecycle.PROBE_LIVENESS, apiEndpoint.Health)
+	configReloader.AddHook(func(c *BaseConfig) error {
+		apiEndpoint.SetOrigins(c.Api.Origins)
+		return nil
+	}, "api.origins")
+	configReloader.AddFileHook(func(c *BaseConfig) error {
+		cert, err := tls.LoadX509KeyPair(c.Api.CertFile, c.Api.KeyFile)
+		if err!=nil {
+			return err
+		}
+		apiEndpoint.SetCertificate(cert)
+		return nil
+	}, "api.cert_file", "api.key_file")
+	lifecycleManager.AddNamedHook("api", apiEndpoint.Terminate,
+		"db", "election", "node-informer", "domain-informer", "video-informer", "serial-informer",
+	)
 
 	signalChan := make(chan os.Signal, 1)
-	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 
-	exitSignal := <-signalChan
-  logger.Info(fmt.Sprintf("received %s; service is being shutdown...", exitSignal.String()))
-	
-	return nil
+	for {
+		exitSignal := <-signalChan
+		if exitSignal == syscall.SIGHUP {
+			logger.Info("received SIGHUP; reloading configuration...")
+			if _, _, err := configReloader.Reload(); err!=nil {
+				logger.Error(fmt.Sprintf("failed to reload configuration: %s", err.Error()))
+			}
+			continue
+		}
+		logger.Info(fmt.Sprintf("received %s; service is being shutdown...", exitSignal.String()))
+		return nil
+	}
+}
+
+// parseLogLevel converts the configured log level into a slog level ("critical" is above the error level).
+func parseLogLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	case "critical":
+		return slog.LevelError + 4
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// scoreWeights converts the configured plugin weights into the score weights of the scheduler.
+func scoreWeights(config *BaseConfig) map[scheduler.SCORE_PLUGIN]float64 {
+	return map[scheduler.SCORE_PLUGIN]float64{
+		scheduler.SCORE_SPREAD:   config.Scheduler.SpreadWeight,
+		scheduler.SCORE_BINPACK:  config.Scheduler.BinpackWeight,
+		scheduler.SCORE_BALANCED: config.Scheduler.BalancedWeight,
+		scheduler.SCORE_AFFINITY: config.Scheduler.AffinityWeight,
+	}
+}
+
+// leaderElection is implemented by all election backends.
+type leaderElection interface {
+	ServeAndDetach()
+	Leader() (string, bool)
+	StepDown(context.Context) error
+	Terminate(context.Context) error
+}
+
+// createElection creates the election of the configured backend, leader changes are reported to the registry.
+// The "lease" backend additionally returns the fence of the current term, which is attached to leader-only writes.
+// The "cash" backend evaluates the cash function every contest cycle to determine the weight of the local node.
+func createElection(
+	logger *slog.Logger, client db.Client, config *BaseConfig, registry *elect.Registry, cash func() int64,
+) (leaderElection, func() (db.Condition, bool)) {
+	switch config.Election.Backend {
+	case "lease":
+		opts := []elect.CampaignOption{
+			elect.WithCampaignTTL(config.Election.ContestTTL),
+			elect.WithTermHooks(func(term elect.Term) {
+				registry.Hook(term.Leader, term.Local)
+			}),
+		}
+		if config.Election.Contest {
+			opts = append(opts, elect.WithCampaignNode(config.NodeId))
+		}
+		campaign := elect.NewCampaign(logger, client, keyspace.WaveLeader, opts...)
+		return campaign, campaign.Fence
+	default:
+		return elect.New(logger, client, keyspace.WaveLeader,
+			elect.WithLocalLeader(config.Election.Contest, config.NodeId, 0),
+			elect.WithCashFunc(cash),
+			elect.WithCandidates(keyspace.WaveElectionCandidate.Prefix()),
+			elect.WithContestTTL(config.Election.ContestTTL),
+			elect.WithContestHooks(registry.Hook),
+		), nil
+	}
+}
+
+// databaseClient extends the database abstraction with the lifecycle functions used by the service.
+type databaseClient interface {
+	db.Client
+	CheckEndpointHealth(context.Context) error
+	Health() error
+	Terminate(context.Context) error
+}
+
+// createDatabase creates the database client of the configured type.
+// The "bolt" type uses an embedded single node database, which allows running wave completely standalone.
+// If no type is specified, the external etcd database is used.
+func createDatabase(config *DatabaseConfig) (databaseClient, error) {
+	switch config.Type {
+	case "", "etcd":
+		return etcdv3.New([]string{config.Addr},
+			etcdv3.WithAuth(config.Username, config.Password),
+			etcdv3.WithDialTimeout(time.Second * time.Duration(config.TimeoutTTL)),
+			etcdv3.WithSkipVerify(config.SkipVerify),
+			etcdv3.WithHealthInterval(time.Second * time.Duration(config.HealthTTL)),
+		), nil
+	case "bolt":
+		return boltdb.New(config.Path,
+			boltdb.WithOpenTimeout(time.Second * time.Duration(config.TimeoutTTL)),
+		), nil
+	default:
+		return nil, fmt.Errorf("unknown database type '%s'", config.Type)
+	}
 }
@@ -0,0 +1,333 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package elect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+)
+
+// Term describes a leadership term. The token is the revision the contestKey was written at when the leader
+// won the campaign, it increases monotonically with every new term.
+type Term struct {
+	// Leader holds the id of the current leader ("" = no leader).
+	Leader string
+	// Local states whether the local node is the leader.
+	Local bool
+	// Token holds the fencing token of the term (0 = no leader).
+	Token int64
+}
+
+// Campaign is an election backend built on database leases. In contrast to the cash based Operator it does not
+// rely on timing: the contestKey is only created if it does not exist (compare-and-set on revision 0) and is
+// attached to the lease of the leader, so the leader holds the key until it resigns or its lease expires.
+//
+// Every term exposes a fencing token (the revision of the contestKey). Leader-only actions attach the token
+// to their writes as transaction condition (see Fence()), which rejects late writes of a deposed leader,
+// as the contestKey revision changes as soon as another node takes over.
+type Campaign struct {
+	// rootCtx is active for the full lifetime of the campaign.
+	// closing it leads to the forceful / immediate shutdown of the campaign.
+	rootCtx       context.Context
+	rootCtxCancel context.CancelFunc
+
+	// workCtx is active for the lifetime of the background operations
+	// closing it leads to the graceful shutdown of the campaign.
+	workCtx       context.Context
+	workCtxCancel context.CancelFunc
+
+	// finChan is used to send the absolute exist signal
+	// if the channel emits, this indicates that the campaign is fully cleaned up.
+	finChan chan struct{}
+
+	client db.Client
+	logger *slog.Logger
+
+	// contestKey marks the database key which is used to contest the leader.
+	contestKey string
+	// contestTTL specifies the ttl of the leader lease, a crashed leader is replaced after this ttl.
+	contestTTL int64
+	// nodeId holds the id the local node campaigns with ("" = the local node only observes the leader).
+	nodeId string
+	// termHook holds a callback executed on every term change.
+	termHook func(Term)
+
+	// leaderLease holds the lease the contestKey is attached to while the local node is leader.
+	leaderLease *db.Lease
+
+	termLock sync.RWMutex
+	term     Term
+	// stepDownUntil holds the time until the local node refrains from campaigning after it stepped down.
+	stepDownUntil time.Time
+}
+
+type CampaignOption func(*Campaign)
+
+// errUncontested is returned by a campaign round if no node contests the leader.
+var errUncontested = errors.New("leader is uncontested")
+
+// NewCampaign creates a new lease based election. The contestKey is the database key used for election.
+func NewCampaign(logger *slog.Logger, client db.Client, contestKey string, opts ...CampaignOption) *Campaign {
+	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
+	workCtx, workCtxCancel := context.WithCancel(rootCtx)
+	campaign := &Campaign{
+		rootCtx:       rootCtx,
+		rootCtxCancel: rootCtxCancel,
+		workCtx:       workCtx,
+		workCtxCancel: workCtxCancel,
+		finChan:       make(chan struct{}),
+		client:        client,
+		logger:        logger.WithGroup("elect-campaign"),
+		contestKey:    contestKey,
+		contestTTL:    10,
+		nodeId:        "",
+		termHook:      func(Term) {},
+		leaderLease:   nil,
+		termLock:      sync.RWMutex{},
+		term:          Term{Leader: "", Local: false, Token: 0},
+		stepDownUntil: time.Time{},
+	}
+
+	for _, opt := range opts {
+		opt(campaign)
+	}
+	campaign.leaderLease = db.NewLease(client, campaign.contestTTL)
+
+	return campaign
+}
+
+// WithCampaignNode enables the local node to campaign for the leader with the specified nodeId.
+// Without this option the campaign only observes the current leader.
+func WithCampaignNode(nodeId string) CampaignOption {
+	return func(c *Campaign) {
+		c.nodeId = nodeId
+	}
+}
+
+// WithCampaignTTL specifies a custom ttl for the leader lease. If the leader crashes, another node takes over
+// after this ttl.
+func WithCampaignTTL(ttl int64) CampaignOption {
+	return func(c *Campaign) {
+		c.contestTTL = ttl
+	}
+}
+
+// WithTermHooks adds one or more callback hooks that are executed on every term change.
+// The callback functions must NOT block, they block the campaign. Callbacks should be idempotent.
+func WithTermHooks(callbacks ...func(Term)) CampaignOption {
+	return func(c *Campaign) {
+		c.termHook = func(term Term) {
+			for _, callback := range callbacks {
+				callback(term)
+			}
+		}
+	}
+}
+
+// Term returns the current leadership term.
+func (c *Campaign) Term() Term {
+	c.termLock.RLock()
+	defer c.termLock.RUnlock()
+	return c.term
+}
+
+// Leader returns the id of the current leader and whether the local node is the leader.
+func (c *Campaign) Leader() (string, bool) {
+	term := c.Term()
+	return term.Leader, term.Local
+}
+
+// Fence returns the transaction condition that guards leader-only writes of the current term.
+// Returns false if the local node is not the leader.
+func (c *Campaign) Fence() (db.Condition, bool) {
+	term := c.Term()
+	if !term.Local {
+		return db.Condition{}, false
+	}
+	return db.Condition{Key: c.contestKey, Compare: db.COMPARE_EQUAL, Revision: term.Token}, true
+}
+
+// ServeAndDetach launches the campaign in a detached goroutine.
+func (c *Campaign) ServeAndDetach() {
+	go func() {
+		c.campaign()
+		c.finChan <- struct{}{}
+	}()
+}
+
+// campaign contests the leader in rounds until the campaign is terminated.
+// On termination the lease is revoked, which removes the contestKey so that other nodes can immediately take over.
+func (c *Campaign) campaign() {
+	for {
+		err := c.round(c.leaderLease)
+		if errors.Is(err, errUncontested) {
+			c.logger.Debug("no leader available; waiting for next campaign round...")
+		} else if err != nil {
+			c.logger.Error(fmt.Sprintf("campaign round failed: %s", err.Error()))
+		}
+
+		// without error, the next round is started immediately as the leader just resigned.
+		delay := time.Duration(0)
+		if err != nil {
+			delay = time.Second * time.Duration(c.contestTTL) / 2
+		}
+		select {
+		case <-time.After(delay):
+		case <-c.workCtx.Done():
+			c.setTerm(Term{Leader: "", Local: false, Token: 0})
+			err := c.leaderLease.Release(c.rootCtx)
+			if err != nil {
+				c.logger.Error("failed to resign leader before termination")
+			}
+			return
+		}
+	}
+}
+
+// round executes a single campaign round. The local node tries to create the contestKey, afterwards the
+// contestKey is observed until it is removed (the leader resigned or its lease expired).
+func (c *Campaign) round(leaderLease *db.Lease) error {
+	ctx, cancel := context.WithCancel(c.workCtx)
+	defer cancel()
+
+	c.termLock.RLock()
+	steppingDown := time.Now().Before(c.stepDownUntil)
+	c.termLock.RUnlock()
+
+	if c.nodeId != "" && !steppingDown {
+		// the lease is only acquired if the contestKey is free, otherwise non leaders would keep an idle lease
+		// alive for the whole term of the current leader.
+		_, token, err := c.client.GetRevision(ctx, c.contestKey)
+		if err != nil {
+			return fmt.Errorf("failed to fetch leader: %w", err)
+		}
+		if token == 0 {
+			lease, err := leaderLease.Acquire(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to acquire leader lease: %w", err)
+			}
+			won, err := c.client.Txn(ctx, []db.Condition{{
+				Key: c.contestKey, Compare: db.COMPARE_EQUAL, Revision: 0,
+			}}, []db.Operation{{
+				Type: db.OPERATION_SET, Key: c.contestKey, Value: c.nodeId, Lease: lease,
+			}})
+			if err != nil {
+				return fmt.Errorf("failed to contest leader: %w", err)
+			}
+			if !won {
+				// another node took the contestKey in the meantime, the lease is not attached to it.
+				if err := leaderLease.Release(ctx); err != nil {
+					c.logger.Error("failed to release leader lease")
+				}
+			}
+		}
+	}
+
+	leader, token, err := c.client.GetRevision(ctx, c.contestKey)
+	if err != nil {
+		return fmt.Errorf("failed to fetch leader: %w", err)
+	}
+	if token == 0 {
+		c.setTerm(Term{Leader: "", Local: false, Token: 0})
+		return errUncontested
+	}
+	c.setTerm(Term{Leader: leader, Local: c.nodeId != "" && leader == c.nodeId, Token: token})
+
+	resigned := false
+	err = c.client.Watch(ctx, c.contestKey, token+1, func(event db.Event, err error) {
+		if err != nil {
+			c.logger.Error(err.Error())
+			return
+		}
+		if event.Type == db.EVENT_DELETE {
+			resigned = true
+			c.setTerm(Term{Leader: "", Local: false, Token: 0})
+			cancel()
+			return
+		}
+		// the key is not rewritten by the leader, a put therefore always starts a new term.
+		c.setTerm(Term{Leader: event.Value, Local: c.nodeId != "" && event.Value == c.nodeId, Token: event.Revision})
+	})
+	if resigned || c.workCtx.Err() != nil {
+		return nil
+	}
+	c.setTerm(Term{Leader: "", Local: false, Token: 0})
+	if err != nil {
+		return fmt.Errorf("failed to watch leader: %w", err)
+	}
+	return fmt.Errorf("leader watch closed unexpectedly")
+}
+
+// StepDown resigns the leadership of the local node. The lease is revoked, which removes the contestKey and
+// lets the other nodes immediately start a new campaign round. The local node does not campaign for a few
+// rounds, so that another node takes over (if no other node campaigns, the local node takes the leader back).
+func (c *Campaign) StepDown(ctx context.Context) error {
+	if _, local := c.Leader(); !local {
+		return ErrNotLeader
+	}
+	c.termLock.Lock()
+	c.stepDownUntil = time.Now().Add(time.Second * time.Duration(c.contestTTL*2))
+	c.termLock.Unlock()
+
+	err := c.leaderLease.Release(ctx)
+	if err != nil {
+		c.termLock.Lock()
+		c.stepDownUntil = time.Time{}
+		c.termLock.Unlock()
+		return fmt.Errorf("failed to resign leader: %w", err)
+	}
+	c.logger.Info("resigned leader")
+	return nil
+}
+
+// setTerm updates the current term and executes the term hooks if it changed.
+func (c *Campaign) setTerm(term Term) {
+	c.termLock.Lock()
+	changed := c.term != term
+	c.term = term
+	c.termLock.Unlock()
+
+	if changed {
+		c.logger.Debug(fmt.Sprintf("leader term changed to '%s' (token %d)", term.Leader, term.Token))
+		c.termHook(term)
+	}
+}
+
+// Terminate stops the campaign gracefully. If the local node is the leader, the lease is revoked in order to
+// make other nodes immediately take over. If this does not succeed in the provided context window,
+// it terminates forcefully.
+func (c *Campaign) Terminate(ctx context.Context) error {
+	c.workCtxCancel()
+	defer c.rootCtxCancel()
+	select {
+	case <-c.finChan:
+		return nil
+	case <-ctx.Done():
+		c.rootCtxCancel()
+		<-c.finChan
+		return nil
+	}
+}
@@ -0,0 +1,200 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package elect
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/db/memdb"
+)
+
+const testContestKey = "/test/leader"
+
+// leaseCounter tracks the leases a campaign currently holds.
+type leaseCounter struct {
+	db.Client
+	lock   sync.Mutex
+	active map[int64]struct{}
+}
+
+func (c *leaseCounter) GrantLease(ctx context.Context, ttl int64) (int64, error) {
+	lease, err := c.Client.GrantLease(ctx, ttl)
+	if err == nil {
+		c.lock.Lock()
+		c.active[lease] = struct{}{}
+		c.lock.Unlock()
+	}
+	return lease, err
+}
+
+func (c *leaseCounter) RevokeLease(ctx context.Context, lease int64) error {
+	c.lock.Lock()
+	delete(c.active, lease)
+	c.lock.Unlock()
+	return c.Client.RevokeLease(ctx, lease)
+}
+
+func (c *leaseCounter) count() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.active)
+}
+
+func newTestCampaign(t *testing.T, client db.Client, nodeId string) *Campaign {
+	t.Helper()
+	opts := []CampaignOption{WithCampaignTTL(1)}
+	if nodeId != "" {
+		opts = append(opts, WithCampaignNode(nodeId))
+	}
+	campaign := NewCampaign(slog.New(slog.NewTextHandler(io.Discard, nil)), client, testContestKey, opts...)
+	campaign.ServeAndDetach()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		campaign.Terminate(ctx)
+	})
+	return campaign
+}
+
+// waitFor polls the condition until it holds or the timeout exceeds.
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 10)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not reached in time")
+		}
+		time.Sleep(time.Millisecond * 20)
+	}
+}
+
+// leaderOf returns the campaign that leads and the remaining campaigns.
+func leaderOf(campaigns map[string]*Campaign) (string, []string) {
+	leader, followers := "", []string{}
+	for id, campaign := range campaigns {
+		if _, local := campaign.Leader(); local {
+			leader = id
+		} else {
+			followers = append(followers, id)
+		}
+	}
+	return leader, followers
+}
+
+func TestCampaign(t *testing.T) {
+	ctx := context.Background()
+	client := memdb.New()
+	defer client.Terminate(ctx)
+
+	counters := map[string]*leaseCounter{}
+	campaigns := map[string]*Campaign{}
+	for _, id := range []string{"node-a", "node-b"} {
+		counters[id] = &leaseCounter{Client: client, active: map[int64]struct{}{}}
+		campaigns[id] = newTestCampaign(t, counters[id], id)
+	}
+	observer := newTestCampaign(t, client, "")
+
+	waitFor(t, func() bool {
+		leader, followers := leaderOf(campaigns)
+		return leader != "" && len(followers) == 1 && observer.Term().Leader == leader &&
+			campaigns[followers[0]].Term().Leader == leader
+	})
+	leader, followers := leaderOf(campaigns)
+	follower := followers[0]
+	term := campaigns[leader].Term()
+
+	tests := []struct {
+		name string
+		got  func() bool
+	}{
+		{name: "all nodes agree on the token", got: func() bool {
+			return observer.Term().Token == term.Token && campaigns[follower].Term().Token == term.Token
+		}},
+		{name: "observer is never local", got: func() bool { return !observer.Term().Local }},
+		{name: "follower has no fence", got: func() bool { _, ok := campaigns[follower].Fence(); return !ok }},
+		{name: "follower holds no lease", got: func() bool { return counters[follower].count() == 0 }},
+		{name: "leader holds one lease", got: func() bool { return counters[leader].count() == 1 }},
+		{name: "follower cannot step down", got: func() bool {
+			return campaigns[follower].StepDown(ctx) == ErrNotLeader
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got() {
+				t.Error("condition does not hold")
+			}
+		})
+	}
+
+	fence, ok := campaigns[leader].Fence()
+	if !ok {
+		t.Fatal("expected leader to provide a fence")
+	}
+	if ok, err := client.Txn(ctx, []db.Condition{fence}, []db.Operation{
+		{Type: db.OPERATION_SET, Key: "/test/fenced", Value: leader},
+	}); err != nil || !ok {
+		t.Fatalf("expected fenced write of the leader to succeed: %v", err)
+	}
+
+	if err := campaigns[leader].StepDown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		_, local := campaigns[follower].Leader()
+		return local && observer.Term().Leader == follower
+	})
+	if newTerm := campaigns[follower].Term(); newTerm.Token <= term.Token {
+		t.Errorf("expected token of the new term to increase, got %d after %d", newTerm.Token, term.Token)
+	}
+
+	// late writes of the deposed leader must be rejected by the fence of its old term.
+	if ok, err := client.Txn(ctx, []db.Condition{fence}, []db.Operation{
+		{Type: db.OPERATION_SET, Key: "/test/fenced", Value: leader},
+	}); err != nil || ok {
+		t.Errorf("expected fenced write of the deposed leader to be rejected (err: %v)", err)
+	}
+}
+
+func TestCampaignTakeover(t *testing.T) {
+	ctx := context.Background()
+	client := memdb.New()
+	defer client.Terminate(ctx)
+
+	first := NewCampaign(slog.New(slog.NewTextHandler(io.Discard, nil)), client, testContestKey,
+		WithCampaignTTL(1), WithCampaignNode("node-a"),
+	)
+	first.ServeAndDetach()
+	waitFor(t, func() bool { _, local := first.Leader(); return local })
+
+	second := newTestCampaign(t, client, "node-b")
+	waitFor(t, func() bool { return second.Term().Leader == "node-a" })
+
+	// terminating the leader revokes its lease, the other node takes over immediately.
+	terminateCtx, terminateCancel := context.WithTimeout(ctx, time.Second*5)
+	defer terminateCancel()
+	first.Terminate(terminateCtx)
+	waitFor(t, func() bool { _, local := second.Leader(); return local })
+}
@@ -65,7 +65,26 @@ type Operator struct {
 	contestHook func(string, bool)
 
 	// localNode holds leader information about the local node.
+	// The cash of the local node is reevaluated every contest cycle if a cashFunc is specified.
 	localNode clusterLeader
+	localNodeLock sync.RWMutex
+	// cashFunc computes the cash of the local node from live node properties (nil = static cash).
+	cashFunc func() int64
+	// stepDownUntil holds the time until the local node refrains from contesting after it stepped down.
+	stepDownUntil time.Time
+	// handoverUntil holds the time until the leader lease is kept for a pending handover (see StepDown()).
+	handoverUntil time.Time
+
+	// leaderLease holds the lease the contestKey is attached to while the local node is leader.
+	leaderLease *db.Lease
+
+	// candidatePrefix marks the database prefix where contesting nodes register their candidacy
+	// ("" = candidacies are not registered, which disables leader handover).
+	candidatePrefix string
+	// contestLock ensures that leader handovers do not interleave with the leader writes of the contest cycle.
+	contestLock sync.Mutex
+	// contestTrigger triggers an immediate contest cycle (e.g. after leadership was handed over to this node).
+	contestTrigger chan struct{}
 	
 	// leaderNode holds the leader information of the current leader.
 	leaderNode clusterLeader
@@ -90,6 +109,13 @@ func New(logger *slog.Logger, client db.Client, contestKey string, opts ...Optio
 		contestTTL: 5,
 		contestHook: func(_ string, _ bool) {},
 		localNode: clusterLeader{ Id: "", Cash: -1 },
+		localNodeLock: sync.RWMutex{},
+		cashFunc: nil,
+		stepDownUntil: time.Time{},
+		handoverUntil: time.Time{},
+		candidatePrefix: "",
+		contestLock: sync.Mutex{},
+		contestTrigger: make(chan struct{}, 1),
 		leaderNode: clusterLeader{ Id: "", Cash: -1 },
 		leaderNodeLock: sync.RWMutex{},
 	}
@@ -97,6 +123,7 @@ func New(logger *slog.Logger, client db.Client, contestKey string, opts ...Optio
 	for _, opt := range opts {
 		opt(controller)
 	}
+	controller.leaderLease = db.NewLease(client, controller.contestTTL * 2)
 
 	return controller
 }
@@ -113,6 +140,22 @@ func WithLocalLeader(contest bool, nodeId string, nodeCash int64) Option {
 	}
 }
 
+// WithCashFunc enables dynamic cash. The function is evaluated every contest cycle and computes the cash of the
+// local node from live node properties (e.g. health, maintenance state and load).
+func WithCashFunc(cashFunc func() int64) Option {
+	return func (o *Operator) {
+		o.cashFunc = cashFunc
+	}
+}
+
+// WithCandidates enables leader handover. Contesting nodes register their candidacy (id & cash) under the
+// specified prefix, which allows the leader to hand the leadership to the next best candidate (see StepDown()).
+func WithCandidates(prefix string) Option {
+	return func (o *Operator) {
+		o.candidatePrefix = prefix
+	}
+}
+
 // WithContestTTL specifies a custom ttl for the leader contest cycle. If the leader is contested by this node
 // it does this in cycles based on this ttl.
 func WithContestTTL(ttl int64) Option {
@@ -135,6 +178,14 @@ func WithContestHooks(callbacks ...func(string, bool)) Option {
 	}
 }
 
+// Leader returns the id of the current leader and whether the local node is the leader.
+func (o *Operator) Leader() (string, bool) {
+	o.leaderNodeLock.RLock()
+	defer o.leaderNodeLock.RUnlock()
+	return o.leaderNode.Id, o.leaderNode.Id != "" && o.leaderNode.Id == o.localNode.Id
+}
+
+
 // ServeAndDetach launches two routines to check the current leader and contest it under given conditions.
 func (o *Operator) ServeAndDetach() {
 	wg := sync.WaitGroup{}
@@ -166,21 +217,22 @@ func (o *Operator) checkLeader() {
 	}
 	leaderNode := o.electLeader(leaderStr)
 	if leaderNode!=nil {
-		o.leaderNodeLock.Lock()
-		o.leaderNode = *leaderNode
-		o.leaderNodeLock.Unlock()
+		o.setLeader(leaderNode)
 	}
 	
-	err = o.client.Watch(ctx, o.contestKey, func(_, value string, err error) {
+	err = o.client.Watch(ctx, o.contestKey, 0, func(event db.Event, err error) {
 		if err!=nil {
 			o.logger.Error(err.Error())
 			return
 		}
-		leaderNode := o.electLeader(value)
+		leaderStr := event.Value
+		if event.Type == db.EVENT_DELETE {
+			// a deleted contestKey means the leader resigned or expired, therefore the leader is uncontested.
+			leaderStr = ""
+		}
+		leaderNode := o.electLeader(leaderStr)
 		if leaderNode!=nil {
-			o.leaderNodeLock.Lock()
-			o.leaderNode = *leaderNode
-			o.leaderNodeLock.Unlock()
+			o.setLeader(leaderNode)
 		}
 	})
 	if err!=nil {
@@ -190,14 +242,19 @@ func (o *Operator) checkLeader() {
 
 // electLeader analyzes the leaderStr and returns the new leader or nil if it should not be changed.
 func (o *Operator) electLeader(leaderStr string) *clusterLeader {
+	local := o.local()
+	if leaderStr=="" && local.Cash < 0 && local.Id != "" {
+		o.logger.Debug("skipping leader; reason: local node is stepping down")
+		return &clusterLeader{ Id: "", Cash: -1 }
+	}
 	if leaderStr=="" {
 		o.logger.Debug("contesting leader; reason: leader is uncontested")
-		return &clusterLeader{ Id: o.localNode.Id, Cash: o.localNode.Cash }
+		return &clusterLeader{ Id: local.Id, Cash: local.Cash }
 	}
 	newLeaderNode, err := parseClusterLeader(leaderStr)
 	if err!=nil {
 		o.logger.Debug("contesting leader; reason: " + err.Error())
-		return &clusterLeader{ Id: o.localNode.Id, Cash: o.localNode.Cash }
+		return &clusterLeader{ Id: local.Id, Cash: local.Cash }
 	}
 
 	// Important: If the local node == new leader the leader node should NOT be changed.
@@ -215,65 +272,128 @@ func (o *Operator) electLeader(leaderStr string) *clusterLeader {
 	// Because usually both controllers have the same schedule this will repeat itself.
 	// To avoid this, elections that have the local node as candidate are skipped instead of overwritting the
 	// current leader. With this, the leader is only overwritten if he actually has more cash.
-	if o.localNode.Id == newLeaderNode.Id {
+	// A handover is the only case where the local node accepts itself as leader from the contestKey.
+	// The handover is written by the previous leader (see StepDown()), therefore it cannot originate
+	// from a delayed write of the local node.
+	if newLeaderNode.Handover && local.Id != "" && local.Id == newLeaderNode.Id {
+		o.logger.Info("accepting leader; reason: leadership was handed over to local node")
+		return &clusterLeader{ Id: local.Id, Cash: local.Cash, Handover: true }
+	}
+	if local.Id == newLeaderNode.Id {
 		o.logger.Debug("skipping leader; reason: local node is already leader")
 		return nil
 	}
-	if o.localNode.Cash <= newLeaderNode.Cash {
+	if local.Cash <= newLeaderNode.Cash {
 		o.logger.Debug("skipping leader; reason: local node has not enough cash")
 		return &clusterLeader{ Id: newLeaderNode.Id, Cash: newLeaderNode.Cash }
 	}
 	
 	o.logger.Debug("contesting leader; reason: local node has more cash")
-	return &clusterLeader{ Id: o.localNode.Id, Cash: o.localNode.Cash }
+	return &clusterLeader{ Id: local.Id, Cash: local.Cash }
 }
 
 
 // contestLeader checks if the current leader (reported by checkLeader) matches the local node.
 // If the local node is the reported leader, it will set the local node as leader
 // and repeat this step in the provided contestTTL interval.
+// While the local node is leader, the contestKey is attached to a lease that is kept alive in the background,
+// if the node crashes, the contestKey disappears as soon as the lease expires.
+// Every cycle also reevaluates the local cash and registers the candidacy of the local node (if enabled).
 func (o *Operator) contestLeader() {
 	if o.localNode.Id == "" {
 		o.logger.Info("local node will not serve as leader")
 		return
 	}
+
+	candidateLease := db.NewLease(o.client, o.contestTTL * 2)
 	
 	for {
-		o.leaderNodeLock.RLock()
-		if o.leaderNode.Id != "" && o.leaderNode.Id == o.localNode.Id {
-			ctx, cancel := context.WithTimeout(o.workCtx, time.Second * time.Duration(o.contestTTL))
-			defer cancel()
-			_, err := o.client.Set(ctx, o.contestKey,
-				serializeClusterLeader(&o.leaderNode), (o.contestTTL * 2),
-			)
-			if err!=nil {
-				o.logger.Error("failed to contest leader")
-			}
-			o.contestHook(o.leaderNode.Id, true)
-		} else {
-			o.contestHook(o.leaderNode.Id, false)
-		}
-		o.leaderNodeLock.RUnlock()
+		o.contestCycle(candidateLease)
 		
 		select {
 		case <-time.After(time.Second * time.Duration(o.contestTTL)):
 			break
+		case <-o.contestTrigger:
+			break
 		case <-o.workCtx.Done():
-			o.leaderNodeLock.RLock()
-			defer o.leaderNodeLock.RUnlock()
-			if o.leaderNode.Id != "" && o.leaderNode.Id == o.localNode.Id {
-				// If the node is currently contesting leader, it sets leader explicitly to "" before termination
-				// so that other nodes can immediately contest the leader.
-				err := o.client.Delete(o.rootCtx, o.contestKey)
-				if err!=nil {
-					o.logger.Error("failed to reset leader before termination")
+			// If the node is currently the leader, the leadership is handed over to the next best candidate.
+			// If no candidate is available, the lease is revoked before termination which removes the contestKey,
+			// so that other nodes can immediately contest the leader.
+			// The handover is attached to the leader lease, therefore the lease is only released after the
+			// candidate took over the contestKey.
+			if _, isLeader := o.Leader(); isLeader && o.candidatePrefix != "" {
+				if err := o.StepDown(o.rootCtx); err!=nil {
+					o.logger.Warn(fmt.Sprintf("failed to hand over leader before termination: %s", err.Error()))
+				} else {
+					o.awaitHandover(o.rootCtx)
 				}
 			}
+			err := o.leaderLease.Release(o.rootCtx)
+			if err!=nil {
+				o.logger.Error("failed to reset leader before termination")
+			}
+			err = candidateLease.Release(o.rootCtx)
+			if err!=nil {
+				o.logger.Error("failed to remove leader candidacy before termination")
+			}
 			return
 		}
 	}
 }
 
+// contestCycle runs one contest cycle. It registers the candidacy of the local node and contests or releases
+// the leader depending on the current leader. The context of the cycle is released as soon as it returns.
+func (o *Operator) contestCycle(candidateLease *db.Lease) {
+	ctx, cancel := context.WithTimeout(o.workCtx, time.Second * time.Duration(o.contestTTL))
+	defer cancel()
+
+	local := o.refreshCash()
+	if o.candidatePrefix != "" {
+		lease, err := candidateLease.Acquire(ctx)
+		if err!=nil {
+			o.logger.Error("failed to acquire candidate lease")
+		} else {
+			_, err = o.client.SetLease(ctx, o.candidatePrefix + local.Id, serializeClusterLeader(&local), lease)
+			if err!=nil {
+				o.logger.Error("failed to register leader candidacy")
+			}
+		}
+	}
+
+	o.contestLock.Lock()
+	defer o.contestLock.Unlock()
+	o.leaderNodeLock.Lock()
+	leaderNode := o.leaderNode
+	if leaderNode.Id != "" && leaderNode.Id == local.Id {
+		// the leader publishes its current cash, so that other nodes can contest it if it decreases.
+		o.leaderNode.Cash = local.Cash
+		leaderNode.Cash = local.Cash
+	}
+	o.leaderNodeLock.Unlock()
+
+	if leaderNode.Id != "" && leaderNode.Id == local.Id {
+		lease, err := o.leaderLease.Acquire(ctx)
+		if err!=nil {
+			o.logger.Error("failed to acquire leader lease")
+		} else {
+			_, err = o.client.SetLease(ctx, o.contestKey, serializeClusterLeader(&leaderNode), lease)
+			if err!=nil {
+				o.logger.Error("failed to contest leader")
+			}
+		}
+		o.contestHook(leaderNode.Id, true)
+	} else if o.handoverPending() {
+		// the lease still holds the handover of the local node, it is released once the handover window ends.
+		o.contestHook(leaderNode.Id, false)
+	} else {
+		// releasing the lease does not affect the contestKey of another leader (it uses its own lease).
+		err := o.leaderLease.Release(ctx)
+		if err!=nil {
+			o.logger.Error("failed to release leader lease")
+		}
+		o.contestHook(leaderNode.Id, false)
+	}
+}
 
 // Terminate stops the election controller gracefully. If this node currently contested the leader
 // it tries to reset the contestKey in order to make other nodes immediately contest the leader.
@@ -0,0 +1,184 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package elect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+)
+
+// ErrNotLeader is returned by StepDown() if the local node is not the current leader.
+var ErrNotLeader = errors.New("local node is not the leader")
+
+// local returns a snapshot of the local node leader information.
+// While the local node steps down, its cash is reported as -1 so that it does not contest the leader.
+func (o *Operator) local() clusterLeader {
+	o.localNodeLock.RLock()
+	defer o.localNodeLock.RUnlock()
+	local := o.localNode
+	if time.Now().Before(o.stepDownUntil) {
+		local.Cash = -1
+	}
+	return local
+}
+
+// refreshCash reevaluates the cash of the local node (if a cashFunc is specified) and returns a snapshot
+// of the local node leader information.
+func (o *Operator) refreshCash() clusterLeader {
+	if o.cashFunc != nil {
+		cash := o.cashFunc()
+		o.localNodeLock.Lock()
+		o.localNode.Cash = cash
+		o.localNodeLock.Unlock()
+	}
+	return o.local()
+}
+
+// setLeader updates the leader reported by checkLeader. If the leadership was handed over to the local node,
+// an immediate contest cycle is triggered so that the local node takes over the contestKey without a gap.
+func (o *Operator) setLeader(leaderNode *clusterLeader) {
+	handover := leaderNode.Handover
+	o.leaderNodeLock.Lock()
+	o.leaderNode = clusterLeader{ Id: leaderNode.Id, Cash: leaderNode.Cash }
+	o.leaderNodeLock.Unlock()
+	if handover {
+		select {
+		case o.contestTrigger <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// StepDown hands the leadership over to the next best candidate (the registered candidate with the most cash).
+// The contestKey is directly overwritten with the candidate (marked as handover), which is accepted by the
+// candidate even though it was not written by itself. This avoids the election gap that occurs if the leader
+// simply resigns (e.g. during rolling upgrades). After stepping down, the local node refrains from contesting
+// the leader for a few contest cycles.
+// The handover stays attached to the leader lease of the local node, which is kept for two contest cycles so
+// that the candidate can take over the contestKey. If the local node crashes meanwhile, the handover expires
+// together with its lease.
+// Requires the local node to be the current leader and candidacies to be enabled (see WithCandidates()).
+func (o *Operator) StepDown(ctx context.Context) error {
+	if o.candidatePrefix == "" {
+		return fmt.Errorf("leader handover is not enabled")
+	}
+
+	o.contestLock.Lock()
+	defer o.contestLock.Unlock()
+
+	if _, isLeader := o.Leader(); !isLeader {
+		return ErrNotLeader
+	}
+
+	candidates, err := o.client.GetRange(ctx, o.candidatePrefix)
+	if err!=nil {
+		return fmt.Errorf("failed to fetch leader candidates: %w", err)
+	}
+	var best *clusterLeader
+	for _, candidateStr := range candidates {
+		candidate, err := parseClusterLeader(candidateStr)
+		if err!=nil {
+			continue
+		}
+		// candidates without cash are either stepping down themselves or in maintenance.
+		if candidate.Id == "" || candidate.Id == o.localNode.Id || candidate.Cash <= 0 {
+			continue
+		}
+		if best == nil || candidate.Cash > best.Cash || (candidate.Cash == best.Cash && candidate.Id < best.Id) {
+			best = candidate
+		}
+	}
+	if best == nil {
+		return fmt.Errorf("no leader candidate available")
+	}
+
+	lease, err := o.leaderLease.Acquire(ctx)
+	if err!=nil {
+		return fmt.Errorf("failed to acquire leader lease: %w", err)
+	}
+
+	o.localNodeLock.Lock()
+	o.stepDownUntil = time.Now().Add(time.Second * time.Duration(o.contestTTL * 4))
+	o.handoverUntil = time.Now().Add(time.Second * time.Duration(o.contestTTL * 2))
+	o.localNodeLock.Unlock()
+
+	_, err = o.client.SetLease(ctx, o.contestKey, serializeClusterLeader(&clusterLeader{
+		Id: best.Id, Cash: best.Cash, Handover: true,
+	}), lease)
+	if err!=nil {
+		o.localNodeLock.Lock()
+		o.stepDownUntil = time.Time{}
+		o.handoverUntil = time.Time{}
+		o.localNodeLock.Unlock()
+		return fmt.Errorf("failed to hand over leader: %w", err)
+	}
+
+	o.leaderNodeLock.Lock()
+	o.leaderNode = clusterLeader{ Id: best.Id, Cash: best.Cash }
+	o.leaderNodeLock.Unlock()
+	o.contestHook(best.Id, false)
+
+	o.logger.Info(fmt.Sprintf("handed over leader to '%s'", best.Id))
+	return nil
+}
+
+// handoverPending reports whether the leader lease must be kept for a handover of the local node.
+func (o *Operator) handoverPending() bool {
+	o.localNodeLock.RLock()
+	defer o.localNodeLock.RUnlock()
+	return time.Now().Before(o.handoverUntil)
+}
+
+// awaitHandover blocks until the candidate took over the contestKey, the handover disappeared or the
+// handover window ended. This keeps the leader lease (and with it the handover) alive during termination.
+func (o *Operator) awaitHandover(ctx context.Context) {
+	o.localNodeLock.RLock()
+	handoverUntil := o.handoverUntil
+	o.localNodeLock.RUnlock()
+
+	ctx, cancel := context.WithDeadline(ctx, handoverUntil)
+	defer cancel()
+
+	leaderStr, revision, err := o.client.GetRevision(ctx, o.contestKey)
+	if err!=nil {
+		o.logger.Warn(fmt.Sprintf("failed to check leader handover: %s", err.Error()))
+		return
+	}
+	if leaderNode, err := parseClusterLeader(leaderStr); err!=nil || !leaderNode.Handover {
+		return
+	}
+
+	err = o.client.Watch(ctx, o.contestKey, revision + 1, func(event db.Event, err error) {
+		if err!=nil {
+			return
+		}
+		leaderNode, err := parseClusterLeader(event.Value)
+		if event.Type == db.EVENT_DELETE || err!=nil || !leaderNode.Handover {
+			cancel()
+		}
+	})
+	if err!=nil && ctx.Err() == nil {
+		o.logger.Warn(fmt.Sprintf("failed to await leader handover: %s", err.Error()))
+	}
+}
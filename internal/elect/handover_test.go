@@ -0,0 +1,183 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package elect
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/db/memdb"
+)
+
+const testCandidatePrefix = "/test/candidate/"
+
+func newTestOperator(t *testing.T, client db.Client, nodeId string, cash int64, opts ...Option) *Operator {
+	t.Helper()
+	opts = append([]Option{
+		WithLocalLeader(true, nodeId, cash), WithCandidates(testCandidatePrefix), WithContestTTL(1),
+	}, opts...)
+	operator := New(slog.New(slog.NewTextHandler(io.Discard, nil)), client, testContestKey, opts...)
+	operator.ServeAndDetach()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		operator.Terminate(ctx)
+	})
+	return operator
+}
+
+// agreeOn reports whether all operators report the specified leader.
+func agreeOn(operators map[string]*Operator, leader string) bool {
+	for id, operator := range operators {
+		current, local := operator.Leader()
+		if current != leader || local != (id == leader) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestStepDown(t *testing.T) {
+	ctx := context.Background()
+	client := memdb.New()
+	defer client.Terminate(ctx)
+
+	operators := map[string]*Operator{}
+	for id, cash := range map[string]int64{"rich": 30, "richer": 20, "poor": 10} {
+		operators[id] = newTestOperator(t, client, id, cash)
+	}
+	waitFor(t, func() bool { return agreeOn(operators, "rich") })
+	// every candidate must be registered, otherwise the handover could pick another candidate.
+	waitFor(t, func() bool {
+		candidates, err := client.GetRange(ctx, testCandidatePrefix)
+		return err == nil && len(candidates) == len(operators)
+	})
+
+	if err := operators["poor"].StepDown(ctx); !errors.Is(err, ErrNotLeader) {
+		t.Errorf("expected follower step down to fail with '%v', got '%v'", ErrNotLeader, err)
+	}
+
+	if err := operators["rich"].StepDown(ctx); err != nil {
+		t.Fatalf("failed to step down: %v", err)
+	}
+	waitFor(t, func() bool { return agreeOn(operators, "richer") })
+
+	// the candidate takes over the contestKey with its own leader lease.
+	waitFor(t, func() bool {
+		leaderStr, err := client.Get(ctx, testContestKey)
+		if err != nil {
+			return false
+		}
+		leader, err := parseClusterLeader(leaderStr)
+		return err == nil && leader.Id == "richer" && !leader.Handover
+	})
+
+	// the previous leader refrains from contesting while it steps down.
+	time.Sleep(time.Second * 2)
+	if !agreeOn(operators, "richer") {
+		t.Errorf("expected leader to stay at 'richer' while 'rich' steps down")
+	}
+}
+
+func TestStepDownLease(t *testing.T) {
+	ctx := context.Background()
+	client := memdb.New()
+	defer client.Terminate(ctx)
+
+	operator := newTestOperator(t, client, "leader", 10)
+	waitFor(t, func() bool { _, local := operator.Leader(); return local })
+
+	// the candidate is registered without an operator, therefore it never takes over the contestKey.
+	_, err := client.Set(ctx, testCandidatePrefix+"ghost", serializeClusterLeader(&clusterLeader{
+		Id: "ghost", Cash: 20,
+	}), 0)
+	if err != nil {
+		t.Fatalf("failed to register candidate: %v", err)
+	}
+	if err := operator.StepDown(ctx); err != nil {
+		t.Fatalf("failed to step down: %v", err)
+	}
+
+	leaderStr, err := client.Get(ctx, testContestKey)
+	if err != nil {
+		t.Fatalf("failed to read contestKey: %v", err)
+	}
+	leader, err := parseClusterLeader(leaderStr)
+	if err != nil || leader.Id != "ghost" || !leader.Handover {
+		t.Fatalf("expected handover to 'ghost', got '%s'", leaderStr)
+	}
+
+	// a contest cycle must not release the lease while the handover is pending.
+	time.Sleep(time.Millisecond * 1500)
+	if leaderStr, _ := client.Get(ctx, testContestKey); leaderStr == "" {
+		t.Fatalf("expected handover to outlive the contest cycle")
+	}
+
+	// the handover disappears together with the leader lease (e.g. if the leader crashes).
+	lease, err := operator.leaderLease.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("failed to acquire leader lease: %v", err)
+	}
+	if err := client.RevokeLease(ctx, lease); err != nil {
+		t.Fatalf("failed to revoke leader lease: %v", err)
+	}
+	if leaderStr, _ := client.Get(ctx, testContestKey); leaderStr != "" {
+		t.Errorf("expected handover to be removed with the leader lease, got '%s'", leaderStr)
+	}
+}
+
+func TestTerminateHandover(t *testing.T) {
+	ctx := context.Background()
+	client := memdb.New()
+	defer client.Terminate(ctx)
+
+	leader := New(slog.New(slog.NewTextHandler(io.Discard, nil)), client, testContestKey,
+		WithLocalLeader(true, "leader", 20), WithCandidates(testCandidatePrefix), WithContestTTL(1),
+	)
+	leader.ServeAndDetach()
+	waitFor(t, func() bool { _, local := leader.Leader(); return local })
+	follower := newTestOperator(t, client, "follower", 10)
+	waitFor(t, func() bool {
+		candidates, err := client.GetRange(ctx, testCandidatePrefix)
+		return err == nil && len(candidates) == 2
+	})
+
+	terminateCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+	if err := leader.Terminate(terminateCtx); err != nil {
+		t.Fatalf("failed to terminate leader: %v", err)
+	}
+
+	// the leader lease is only released after the follower took over, therefore the contestKey never
+	// disappears and the follower does not have to wait for an election.
+	leaderStr, err := client.Get(ctx, testContestKey)
+	if err != nil {
+		t.Fatalf("failed to read contestKey: %v", err)
+	}
+	if current, err := parseClusterLeader(leaderStr); err != nil || current.Id != "follower" {
+		t.Errorf("expected 'follower' to hold the contestKey after termination, got '%s'", leaderStr)
+	}
+	waitFor(t, func() bool { _, local := follower.Leader(); return local })
+}
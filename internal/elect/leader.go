@@ -28,6 +28,8 @@ import (
 type clusterLeader struct {
 	Id   string `json:"id"`
 	Cash int64  `json:"cash"`
+	// Handover marks a leader that was appointed by the previous leader (see Operator.StepDown()).
+	Handover bool `json:"handover,omitempty"`
 }
 
 // parseClusterLeader parses the leader string into a cluster leader.
@@ -0,0 +1,162 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package elect
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Registry starts and stops leader-only components (e.g. the scheduler) based on the leader state.
+// Its Hook() is registered as contest hook on the election, every time the local node becomes leader the
+// registered workers are started, as soon as the local node loses the leader they are stopped again.
+// Workers of a new term are only started after all workers of the previous term exited, which ensures that
+// a component never runs twice on the same node.
+type Registry struct {
+	logger *slog.Logger
+
+	// rootCtx is the parent of all worker contexts, cancelling it stops the workers immediately.
+	rootCtx       context.Context
+	rootCtxCancel context.CancelFunc
+
+	lock    sync.Mutex
+	workers []worker
+	// leader holds the id of the current leader reported by the election.
+	leader string
+	// termCtx is active while the local node is the leader (nil = local node is not the leader).
+	termCtx       context.Context
+	termCtxCancel context.CancelFunc
+	// termWg tracks the workers of the current term.
+	termWg *sync.WaitGroup
+	// operationWg tracks the workers of all terms.
+	operationWg sync.WaitGroup
+}
+
+// worker is a leader-only component registered on the registry.
+type worker struct {
+	name string
+	fn   func(context.Context)
+}
+
+// NewRegistry creates a new leader-gated component registry.
+func NewRegistry(logger *slog.Logger) *Registry {
+	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
+	return &Registry{
+		logger:        logger.WithGroup("elect-registry"),
+		rootCtx:       rootCtx,
+		rootCtxCancel: rootCtxCancel,
+		lock:          sync.Mutex{},
+		workers:       []worker{},
+		leader:        "",
+		termCtx:       nil,
+		termCtxCancel: nil,
+		termWg:        &sync.WaitGroup{},
+		operationWg:   sync.WaitGroup{},
+	}
+}
+
+// Add registers a leader-only worker. The worker function is started while the local node is the leader
+// and must return as soon as the provided context is cancelled. If the local node is currently the leader,
+// the worker is started immediately.
+func (r *Registry) Add(name string, fn func(context.Context)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	w := worker{name: name, fn: fn}
+	r.workers = append(r.workers, w)
+	if r.termCtx != nil {
+		r.start(r.termCtx, r.termWg, nil, w)
+	}
+}
+
+// Hook updates the leader state of the registry, it is registered as contest hook on the election.
+// The hook does not block, workers are started and stopped in the background.
+func (r *Registry) Hook(leader string, local bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.leader = leader
+	if r.rootCtx.Err() != nil {
+		return
+	}
+
+	if local && r.termCtx == nil {
+		r.logger.Info(fmt.Sprintf("local node is leader; starting %d leader components...", len(r.workers)))
+		prevWg := r.termWg
+		r.termWg = &sync.WaitGroup{}
+		r.termCtx, r.termCtxCancel = context.WithCancel(r.rootCtx)
+		for _, w := range r.workers {
+			r.start(r.termCtx, r.termWg, prevWg, w)
+		}
+	} else if !local && r.termCtx != nil {
+		r.logger.Info(fmt.Sprintf("local node lost leader to '%s'; stopping leader components...", leader))
+		r.termCtxCancel()
+		r.termCtx, r.termCtxCancel = nil, nil
+	}
+}
+
+// start launches the worker in the term, after the workers of the previous term exited (if any).
+func (r *Registry) start(ctx context.Context, wg, prevWg *sync.WaitGroup, w worker) {
+	wg.Add(1)
+	r.operationWg.Add(1)
+	go func() {
+		defer r.operationWg.Done()
+		defer wg.Done()
+		if prevWg != nil {
+			prevWg.Wait()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		r.logger.Debug(fmt.Sprintf("starting leader component '%s'", w.name))
+		w.fn(ctx)
+		r.logger.Debug(fmt.Sprintf("leader component '%s' stopped", w.name))
+	}()
+}
+
+// Leader returns the id of the current leader and whether the local node is the leader.
+func (r *Registry) Leader() (string, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.leader, r.termCtx != nil
+}
+
+// Terminate stops all leader-only workers and waits until they exited.
+// If this does not succeed in the provided context window, it returns without waiting.
+func (r *Registry) Terminate(ctx context.Context) error {
+	r.lock.Lock()
+	r.rootCtxCancel()
+	r.termCtx, r.termCtxCancel = nil, nil
+	r.lock.Unlock()
+
+	finChan := make(chan struct{})
+	go func() {
+		r.operationWg.Wait()
+		close(finChan)
+	}()
+	select {
+	case <-finChan:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("leader components did not stop in time")
+	}
+}
@@ -0,0 +1,148 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package elect
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cthul.io/cthul/pkg/db/memdb"
+)
+
+// testWorker counts the running instances of a leader-only worker.
+type testWorker struct {
+	running atomic.Int32
+	// overlap is set if the worker ever ran twice at the same time.
+	overlap atomic.Bool
+	started atomic.Int32
+}
+
+func (w *testWorker) run(ctx context.Context) {
+	if w.running.Add(1) > 1 {
+		w.overlap.Store(true)
+	}
+	w.started.Add(1)
+	<-ctx.Done()
+	// the delay ensures that a worker of the next term would overlap if it did not wait for this one.
+	time.Sleep(time.Millisecond * 50)
+	w.running.Add(-1)
+}
+
+func newTestRegistry(t *testing.T) *Registry {
+	t.Helper()
+	registry := NewRegistry(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		registry.Terminate(ctx)
+	})
+	return registry
+}
+
+func TestRegistry(t *testing.T) {
+	registry := newTestRegistry(t)
+	worker := &testWorker{}
+	registry.Add("worker", worker.run)
+
+	tests := []struct {
+		name        string
+		leader      string
+		local       bool
+		wantRunning int32
+		wantStarted int32
+	}{
+		{name: "follower", leader: "other", local: false, wantRunning: 0, wantStarted: 0},
+		{name: "term starts", leader: "local", local: true, wantRunning: 1, wantStarted: 1},
+		{name: "term continues", leader: "local", local: true, wantRunning: 1, wantStarted: 1},
+		{name: "term ends", leader: "other", local: false, wantRunning: 0, wantStarted: 1},
+		{name: "next term starts", leader: "local", local: true, wantRunning: 1, wantStarted: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry.Hook(tt.leader, tt.local)
+			waitFor(t, func() bool {
+				return worker.running.Load() == tt.wantRunning && worker.started.Load() == tt.wantStarted
+			})
+			if leader, local := registry.Leader(); leader != tt.leader || local != tt.local {
+				t.Errorf("expected leader '%s' (local %t), got '%s' (local %t)", tt.leader, tt.local, leader, local)
+			}
+		})
+	}
+	if worker.overlap.Load() {
+		t.Errorf("expected worker to never run twice at the same time")
+	}
+}
+
+func TestRegistryTermination(t *testing.T) {
+	registry := newTestRegistry(t)
+	registry.Hook("local", true)
+	worker := &testWorker{}
+	// workers added during a term are started immediately.
+	registry.Add("worker", worker.run)
+	waitFor(t, func() bool { return worker.running.Load() == 1 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	if err := registry.Terminate(ctx); err != nil {
+		t.Fatalf("failed to terminate registry: %v", err)
+	}
+	if running := worker.running.Load(); running != 0 {
+		t.Errorf("expected worker to be stopped after termination, %d still running", running)
+	}
+
+	// a terminated registry does not start a new term.
+	registry.Hook("local", true)
+	time.Sleep(time.Millisecond * 100)
+	if started := worker.started.Load(); started != 1 {
+		t.Errorf("expected no worker start after termination, got %d starts", started)
+	}
+}
+
+func TestRegistryStepDown(t *testing.T) {
+	ctx := context.Background()
+	client := memdb.New()
+	defer client.Terminate(ctx)
+
+	registry := newTestRegistry(t)
+	worker := &testWorker{}
+	registry.Add("worker", worker.run)
+
+	operator := newTestOperator(t, client, "leader", 20, WithContestHooks(registry.Hook))
+	newTestOperator(t, client, "follower", 10)
+	waitFor(t, func() bool { return worker.running.Load() == 1 })
+	waitFor(t, func() bool {
+		candidates, err := client.GetRange(ctx, testCandidatePrefix)
+		return err == nil && len(candidates) == 2
+	})
+
+	// the term ends with the handover, which stops the worker on the previous leader.
+	if err := operator.StepDown(ctx); err != nil {
+		t.Fatalf("failed to step down: %v", err)
+	}
+	waitFor(t, func() bool { return worker.running.Load() == 0 })
+	if leader, local := registry.Leader(); leader != "follower" || local {
+		t.Errorf("expected registry to report leader 'follower', got '%s' (local %t)", leader, local)
+	}
+}
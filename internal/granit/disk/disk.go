@@ -58,7 +58,7 @@ func New(logger *slog.Logger, client db.Client, opts ...Option) *Operator {
 	operator := &Operator{
 		client:         client,
 		logger:         logger.WithGroup("disk-operator"),
-		syncer:         syncer.New(logger.WithGroup("disk-operator"), client),
+		syncer:         nil,
 		nodeId:         "undefined",
 		updateCycleTTL: 30,
 		syncCycleTTL:   30,
@@ -67,6 +67,8 @@ func New(logger *slog.Logger, client db.Client, opts ...Option) *Operator {
 	for _, opt := range opts {
 		opt(operator)
 	}
+	// the syncer is created after the options are applied, as it reports the key status of the local node.
+	operator.syncer = syncer.New(logger.WithGroup("disk-operator"), client, syncer.WithNodeId(operator.nodeId))
 
 	return operator
 }
@@ -24,12 +24,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"cthul.io/cthul/pkg/api/granit/v1/disk"
+	"cthul.io/cthul/pkg/keyspace"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -78,7 +78,7 @@ func (o *Operator) synchronize() {
 					o.logger.Error(err.Error(), "device", device, "node", o.nodeId)
 					continue
 				}
-        oldNode, err := o.client.Set(ctx, fmt.Sprintf("/GRANIT/DISK/NODE/%s", device), o.nodeId, 0)
+        oldNode, err := o.client.Set(ctx, keyspace.GranitDisk.Node.Key(device), o.nodeId, 0)
 				if err != nil {
 					o.logger.Error(err.Error(), "device", device, "node", o.nodeId)
 					continue
@@ -98,17 +98,23 @@ func (o *Operator) synchronize() {
 		}
 	}()
 
-	o.syncer.Add("/GRANIT/DISK/CLUSTERNODES/", o.updateCycleTTL, func(ctx context.Context, k, rawCluster string) error {
-		id := strings.TrimPrefix(k, "/GRANIT/DISK/CLUSTERNODES/")
-		configKey := fmt.Sprintf("/GRANIT/DISK/CONFIG/%s", id)
+	o.syncer.Add(keyspace.GranitDisk.Cluster.Prefix(), o.updateCycleTTL, func(ctx context.Context, k, rawCluster string, deleted bool) error {
+		id := keyspace.GranitDisk.Cluster.Id(k)
+		configKey := keyspace.GranitDisk.Config.Key(id)
 
+		// a deleted cluster is handled like a cluster that does not contain the local node.
 		cluster := &disk.DiskCluster{}
-		err := proto.Unmarshal([]byte(rawCluster), cluster)
-		if err != nil {
-			return err
+		if !deleted {
+			err := proto.Unmarshal([]byte(rawCluster), cluster)
+			if err != nil {
+				return err
+			}
 		}
 		if _, ok := cluster.Nodes[o.nodeId]; ok {
-			o.syncer.Add(configKey, o.syncCycleTTL, func(ctx context.Context, k, v string) error {
+			o.syncer.Add(configKey, o.syncCycleTTL, func(ctx context.Context, k, v string, deleted bool) error {
+        if deleted {
+          return nil
+        }
         configMapLock.Lock()
         configMap[id] = v
         configMapLock.Unlock()
@@ -131,10 +137,10 @@ func (o *Operator) synchronize() {
 		return nil
 	})
 
-	o.syncer.Add("/GRANIT/DISK/REQNODE/", o.updateCycleTTL, func(ctx context.Context, k, reqnode string) error {
-		id := strings.TrimPrefix(k, "/GRANIT/DISK/REQNODE/")
+	o.syncer.Add(keyspace.GranitDisk.Reqnode.Prefix(), o.updateCycleTTL, func(ctx context.Context, k, reqnode string, deleted bool) error {
+		id := keyspace.GranitDisk.Reqnode.Id(k)
     primaryMapLock.Lock()
-    primaryMap[id] = reqnode == o.nodeId
+    primaryMap[id] = !deleted && reqnode == o.nodeId
     primaryMapLock.Unlock()
     syncChan <- id
 		return nil
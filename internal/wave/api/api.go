@@ -22,6 +22,7 @@ package api
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	golog "log"
@@ -32,6 +33,7 @@ import (
 	"time"
 
 	"cthul.io/cthul/internal/wave/api/domain"
+	"cthul.io/cthul/internal/wave/api/health"
 	"cthul.io/cthul/internal/wave/api/node"
 	"cthul.io/cthul/internal/wave/api/serial"
 	"cthul.io/cthul/internal/wave/api/video"
@@ -39,6 +41,7 @@ import (
 	"cthul.io/cthul/pkg/api/wave/v1/node/nodeconnect"
 	"cthul.io/cthul/pkg/api/wave/v1/serial/serialconnect"
 	"cthul.io/cthul/pkg/api/wave/v1/video/videoconnect"
+	"cthul.io/cthul/pkg/lifecycle"
 	domctrl "cthul.io/cthul/pkg/wave/domain"
 	nodectrl "cthul.io/cthul/pkg/wave/node"
 	serialctrl "cthul.io/cthul/pkg/wave/serial"
@@ -48,26 +51,39 @@ import (
 )
 
 type Endpoint struct {
-	addr       string
-	tlsConfig  *tls.Config
-	logger     *slog.Logger
-	origins    []string
-	mux        *http.ServeMux
+	addr      string
+	tlsConfig *tls.Config
+	logger    *slog.Logger
+	mux       *http.ServeMux
+	// reloadLock protects the settings that can be updated while the endpoint is serving.
+	reloadLock  sync.RWMutex
+	origins     []string
+	handler     http.Handler
+	certificate *tls.Certificate
+
 	serverLock sync.Mutex
 	server     *http.Server
+	// serveErr holds the error the server stopped with (nil = serving or terminated gracefully).
+	serveErrLock sync.Mutex
+	serveErr     error
 }
 
 type Option func(*Endpoint)
 
 func New(addr string, opts ...Option) *Endpoint {
 	endpoint := &Endpoint{
-		addr:       addr,
-		tlsConfig:  nil,
-		logger:     slog.Default().WithGroup("api-endpoint"),
-		origins:    []string{},
-		mux:        http.NewServeMux(),
-		serverLock: sync.Mutex{},
-		server:     nil,
+		addr:         addr,
+		tlsConfig:    nil,
+		logger:       slog.Default().WithGroup("api-endpoint"),
+		mux:          http.NewServeMux(),
+		reloadLock:   sync.RWMutex{},
+		origins:      []string{},
+		handler:      nil,
+		certificate:  nil,
+		serverLock:   sync.Mutex{},
+		server:       nil,
+		serveErrLock: sync.Mutex{},
+		serveErr:     nil,
 	}
 
 	for _, opt := range opts {
@@ -84,11 +100,17 @@ func WithLogger(logger *slog.Logger) Option {
 	}
 }
 
-// WithTLS enables tls encryption for the endpoint..
+// WithTLS enables tls encryption for the endpoint. The certificate can be replaced while serving
+// (see SetCertificate()).
 func WithTLS(cert tls.Certificate) Option {
 	return func(e *Endpoint) {
+		e.certificate = &cert
 		e.tlsConfig = &tls.Config{
-			Certificates: []tls.Certificate{cert},
+			GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+				e.reloadLock.RLock()
+				defer e.reloadLock.RUnlock()
+				return e.certificate, nil
+			},
 		}
 	}
 }
@@ -118,9 +140,27 @@ func WithSerial(controller *serialctrl.Controller) Option {
 	}
 }
 
-func WithNode(controller *nodectrl.Controller) Option {
+// WithNode registers the node service. The leader function reports the current cluster leader and the stepDown
+// function hands the leadership of the local node over to another node (see elect).
+// The reload function reloads the configuration of the local node.
+func WithNode(
+	controller *nodectrl.Controller,
+	leader func() (string, bool),
+	stepDown func(context.Context) error,
+	reload func() ([]string, []string, error),
+) Option {
+	return func(e *Endpoint) {
+		e.mux.Handle(nodeconnect.NewNodeServiceHandler(node.New(controller, leader, stepDown, reload)))
+	}
+}
+
+// WithHealth registers the health endpoints of the health registry. This includes the http probes
+// /healthz (liveness) and /readyz (readiness) and the standard grpc health service.
+func WithHealth(registry *lifecycle.Health) Option {
 	return func(e *Endpoint) {
-		e.mux.Handle(nodeconnect.NewNodeServiceHandler(node.New(controller)))
+		e.mux.Handle("/healthz", health.NewProbeHandler(registry, lifecycle.PROBE_LIVENESS))
+		e.mux.Handle("/readyz", health.NewProbeHandler(registry, lifecycle.PROBE_READINESS))
+		e.mux.Handle(health.NewHandler(health.New(registry)))
 	}
 }
 
@@ -132,12 +172,14 @@ func (e *Endpoint) ServeAndDetach() error {
 	if e.server != nil {
 		return fmt.Errorf("server cannot be started twice")
 	}
+	e.SetOrigins(e.origins)
 	e.server = &http.Server{
-		Handler: cors.New(cors.Options{
-			AllowedOrigins: e.origins,
-			AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodOptions},
-			AllowedHeaders: []string{"*"},
-		}).Handler(e.mux),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			e.reloadLock.RLock()
+			handler := e.handler
+			e.reloadLock.RUnlock()
+			handler.ServeHTTP(w, r)
+		}),
 		ErrorLog:    golog.New(io.Discard, "", 0),
 		IdleTimeout: 10 * time.Minute,
 	}
@@ -159,13 +201,54 @@ func (e *Endpoint) ServeAndDetach() error {
 	}
 
 	go func() {
-		if err := e.server.Serve(listener); err != nil {
+		if err := e.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			e.logger.Error(fmt.Sprintf("unrecoverable api error: %s", err.Error()))
+			e.serveErrLock.Lock()
+			e.serveErr = err
+			e.serveErrLock.Unlock()
 		}
 	}()
 	return nil
 }
 
+// SetOrigins replaces the allowed CORS origins, it can be called while the endpoint is serving.
+func (e *Endpoint) SetOrigins(allowed []string) {
+	handler := cors.New(cors.Options{
+		AllowedOrigins: allowed,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodOptions},
+		AllowedHeaders: []string{"*"},
+	}).Handler(e.mux)
+
+	e.reloadLock.Lock()
+	defer e.reloadLock.Unlock()
+	e.origins, e.handler = allowed, handler
+}
+
+// SetCertificate replaces the tls certificate, it can be called while the endpoint is serving.
+// New connections use the new certificate, established connections are not affected.
+// The certificate is only used if tls is enabled on the endpoint (see WithTLS()).
+func (e *Endpoint) SetCertificate(cert tls.Certificate) {
+	e.reloadLock.Lock()
+	defer e.reloadLock.Unlock()
+	e.certificate = &cert
+}
+
+// Health reports whether the api listener is serving. Returns an error if the server was not started yet
+// or if it stopped with an unrecoverable error.
+func (e *Endpoint) Health() error {
+	e.serveErrLock.Lock()
+	defer e.serveErrLock.Unlock()
+	if e.serveErr != nil {
+		return fmt.Errorf("api listener stopped: %w", e.serveErr)
+	}
+	e.serverLock.Lock()
+	defer e.serverLock.Unlock()
+	if e.server == nil {
+		return fmt.Errorf("api listener is not started")
+	}
+	return nil
+}
+
 // Terminate tries to gracefully shutdown the api endpoint (waiting for connections to finish)
 // if this fails or exceeds the provided context window, the connection is forcefully closed.
 // If forcefully closing the connection fails too, an error is returned.
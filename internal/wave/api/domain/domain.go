@@ -25,6 +25,7 @@ import (
 
 	"connectrpc.com/connect"
 	"cthul.io/cthul/pkg/api/wave/v1/domain"
+	"cthul.io/cthul/pkg/resource"
 	domctrl "cthul.io/cthul/pkg/wave/domain"
 	"github.com/google/uuid"
 )
@@ -43,7 +44,7 @@ func (d *Service) Get(ctx context.Context, r *connect.Request[domain.GetRequest]
 	// TODO: authorize
 	result, err := d.controller.Lookup(ctx, r.Msg.Id)
 	if err != nil {
-		var mismatchErr *domctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -61,7 +62,7 @@ func (d *Service) Stat(ctx context.Context, r *connect.Request[domain.StatReques
 	// TODO: authorize
 	result, err := d.controller.Stat(ctx, r.Msg.Id)
 	if err != nil {
-		var mismatchErr *domctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -79,7 +80,7 @@ func (d *Service) List(ctx context.Context, r *connect.Request[domain.ListReques
 	// TODO: authorize
 	result, err := d.controller.List(ctx)
 	if err != nil {
-		var mismatchErr *domctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -93,12 +94,30 @@ func (d *Service) List(ctx context.Context, r *connect.Request[domain.ListReques
 	}, nil
 }
 
+func (d *Service) Status(ctx context.Context, r *connect.Request[domain.StatusRequest]) (*connect.Response[domain.StatusResponse], error) {
+	// TODO: authorize
+	result, err := d.controller.Status(ctx, r.Msg.Id)
+	if err != nil {
+		var mismatchErr *resource.NodeMismatchErr
+		if errors.As(err, &mismatchErr) {
+			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
+			rpcErr.Meta().Add("Location", mismatchErr.Node)
+			return nil, rpcErr
+		}
+		return nil, err
+	}
+
+	return &connect.Response[domain.StatusResponse]{
+		Msg: &domain.StatusResponse{Status: result},
+	}, nil
+}
+
 func (d *Service) Create(ctx context.Context, r *connect.Request[domain.CreateRequest]) (*connect.Response[domain.CreateResponse], error) {
 	// TODO: authorize
 	id := uuid.New().String()
-	err := d.controller.Apply(ctx, id, r.Msg.Config)
+	err := d.controller.Create(ctx, id, r.Msg.Config)
 	if err != nil {
-		var mismatchErr *domctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -116,7 +135,7 @@ func (d *Service) Update(ctx context.Context, r *connect.Request[domain.UpdateRe
 	// TODO: authorize
 	err := d.controller.Apply(ctx, r.Msg.Id, r.Msg.Config)
 	if err != nil {
-		var mismatchErr *domctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -134,7 +153,7 @@ func (d *Service) Attach(ctx context.Context, r *connect.Request[domain.AttachRe
 	// TODO: authorize
 	err := d.controller.Attach(ctx, r.Msg.Id, r.Msg.Node, false)
 	if err != nil {
-		var mismatchErr *domctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -152,7 +171,7 @@ func (d *Service) Detach(ctx context.Context, r *connect.Request[domain.DetachRe
 	// TODO: authorize
 	err := d.controller.Detach(ctx, r.Msg.Id)
 	if err != nil {
-		var mismatchErr *domctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -170,7 +189,7 @@ func (d *Service) Delete(ctx context.Context, r *connect.Request[domain.DeleteRe
 	// TODO: authorize
 	err := d.controller.Delete(ctx, r.Msg.Id)
 	if err != nil {
-		var mismatchErr *domctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
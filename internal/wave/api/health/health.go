@@ -0,0 +1,146 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"cthul.io/cthul/pkg/lifecycle"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// watchInterval specifies the interval the health state is reevaluated for watching clients.
+const watchInterval = 5 * time.Second
+
+// Service implements the standard grpc health service (grpc.health.v1.Health) on top of the health registry.
+// The empty service name reports the overall readiness, other service names report the probe with this name.
+type Service struct {
+	health *lifecycle.Health
+}
+
+func New(health *lifecycle.Health) *Service {
+	return &Service{
+		health: health,
+	}
+}
+
+// NewHandler builds an http handler that serves the grpc health service (grpc, grpc-web and connect).
+func NewHandler(svc *Service) (string, http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle(grpc_health_v1.Health_Check_FullMethodName, connect.NewUnaryHandler(
+		grpc_health_v1.Health_Check_FullMethodName, svc.Check,
+	))
+	mux.Handle(grpc_health_v1.Health_Watch_FullMethodName, connect.NewServerStreamHandler(
+		grpc_health_v1.Health_Watch_FullMethodName, svc.Watch,
+	))
+	return "/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/", mux
+}
+
+func (s *Service) Check(ctx context.Context, r *connect.Request[grpc_health_v1.HealthCheckRequest]) (*connect.Response[grpc_health_v1.HealthCheckResponse], error) {
+	status, ok := s.status(r.Msg.Service)
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("unknown service '%s'", r.Msg.Service))
+	}
+
+	return &connect.Response[grpc_health_v1.HealthCheckResponse]{
+		Msg: &grpc_health_v1.HealthCheckResponse{Status: status},
+	}, nil
+}
+
+// Watch sends the serving status of the service and reports every change until the client disconnects.
+func (s *Service) Watch(ctx context.Context, r *connect.Request[grpc_health_v1.HealthCheckRequest], stream *connect.ServerStream[grpc_health_v1.HealthCheckResponse]) error {
+	lastStatus := grpc_health_v1.HealthCheckResponse_ServingStatus(-1)
+	for {
+		status, ok := s.status(r.Msg.Service)
+		if !ok {
+			status = grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if status != lastStatus {
+			err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: status})
+			if err != nil {
+				return err
+			}
+			lastStatus = status
+		}
+
+		select {
+		case <-time.After(watchInterval):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// status evaluates the serving status of the service. Returns false if the service is unknown.
+func (s *Service) status(service string) (grpc_health_v1.HealthCheckResponse_ServingStatus, bool) {
+	var err error
+	if service == "" {
+		err = s.health.Ready()
+	} else {
+		result, ok := s.health.Probe(service)
+		if !ok {
+			return grpc_health_v1.HealthCheckResponse_UNKNOWN, false
+		}
+		err = result.Err
+	}
+	if err != nil {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING, true
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING, true
+}
+
+// NewProbeHandler builds an http handler that reports the state of the specified probe type (e.g. /readyz).
+// It responds with 200 if all probes succeed and with 503 otherwise. The "verbose" query parameter lists
+// the result of every probe.
+func NewProbeHandler(health *lifecycle.Health, probeType lifecycle.PROBE_TYPE) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results := health.Results(probeType)
+
+		report := strings.Builder{}
+		healthy := true
+		for _, result := range results {
+			if result.Err != nil {
+				healthy = false
+				report.WriteString(fmt.Sprintf("[-] %s failed: %s\n", result.Name, result.Err.Error()))
+			} else {
+				report.WriteString(fmt.Sprintf("[+] %s ok\n", result.Name))
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if r.URL.Query().Has("verbose") || !healthy {
+			fmt.Fprint(w, report.String())
+		}
+		if healthy {
+			fmt.Fprintf(w, "%s check passed\n", probeType)
+		} else {
+			fmt.Fprintf(w, "%s check failed\n", probeType)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"cthul.io/cthul/pkg/lifecycle"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func newTestService(database error) *Service {
+	health := lifecycle.NewHealth()
+	health.AddProbe("api", lifecycle.PROBE_LIVENESS, func() error { return nil })
+	health.AddProbe("database", lifecycle.PROBE_READINESS, func() error { return database })
+	return New(health)
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		database   error
+		service    string
+		wantStatus grpc_health_v1.HealthCheckResponse_ServingStatus
+		wantCode   connect.Code
+	}{
+		{
+			name:       "overall serving",
+			service:    "",
+			wantStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+		},
+		{
+			name:       "overall not serving",
+			database:   errors.New("database unreachable"),
+			service:    "",
+			wantStatus: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:       "failing probe not serving",
+			database:   errors.New("database unreachable"),
+			service:    "database",
+			wantStatus: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:       "healthy probe serving",
+			database:   errors.New("database unreachable"),
+			service:    "api",
+			wantStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+		},
+		{
+			name:     "unknown service",
+			service:  "missing",
+			wantCode: connect.CodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(tt.database)
+			resp, err := svc.Check(context.Background(), connect.NewRequest(
+				&grpc_health_v1.HealthCheckRequest{Service: tt.service},
+			))
+			if tt.wantCode != 0 {
+				if connect.CodeOf(err) != tt.wantCode {
+					t.Fatalf("expected code %s, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Msg.Status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, resp.Msg.Status)
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		database   error
+		service    string
+		wantStatus grpc_health_v1.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:       "serving",
+			service:    "",
+			wantStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+		},
+		{
+			name:       "not serving",
+			database:   errors.New("database unreachable"),
+			service:    "database",
+			wantStatus: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:       "unknown service",
+			service:    "missing",
+			wantStatus: grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, handler := NewHandler(newTestService(tt.database))
+			mux := http.NewServeMux()
+			mux.Handle(path, handler)
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			client := connect.NewClient[grpc_health_v1.HealthCheckRequest, grpc_health_v1.HealthCheckResponse](
+				server.Client(), server.URL+grpc_health_v1.Health_Watch_FullMethodName,
+			)
+			ctx, cancel := context.WithCancel(context.Background())
+			stream, err := client.CallServerStream(ctx, connect.NewRequest(
+				&grpc_health_v1.HealthCheckRequest{Service: tt.service},
+			))
+			if err != nil {
+				t.Fatalf("failed to open watch stream: %v", err)
+			}
+			// the stream is cancelled before it is closed, as closing drains the endless watch stream.
+			defer stream.Close()
+			defer cancel()
+
+			if !stream.Receive() {
+				t.Fatalf("expected initial status, got %v", stream.Err())
+			}
+			if status := stream.Msg().Status; status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, status)
+			}
+		})
+	}
+}
@@ -22,18 +22,34 @@ package node
 import (
 	"connectrpc.com/connect"
 	"context"
+	"cthul.io/cthul/internal/elect"
 	"cthul.io/cthul/pkg/api/wave/v1/node"
+	"cthul.io/cthul/pkg/resource"
 	nodectrl "cthul.io/cthul/pkg/wave/node"
 	"errors"
 )
 
 type Service struct {
 	controller *nodectrl.Controller
+	// leader returns the id of the current cluster leader reported by the election.
+	leader func() (string, bool)
+	// stepDown hands the leadership of the local node over to the next best candidate.
+	stepDown func(context.Context) error
+	// reload reloads the configuration of the local node and returns the applied and restart-required settings.
+	reload func() ([]string, []string, error)
 }
 
-func New(controller *nodectrl.Controller) *Service {
+func New(
+	controller *nodectrl.Controller,
+	leader func() (string, bool),
+	stepDown func(context.Context) error,
+	reload func() ([]string, []string, error),
+) *Service {
 	return &Service{
     controller: controller,
+    leader: leader,
+    stepDown: stepDown,
+    reload: reload,
   }
 }
 
@@ -41,7 +57,7 @@ func (d *Service) Get(ctx context.Context, r *connect.Request[node.GetRequest])
   // TODO: authorize
 	result, err := d.controller.Lookup(ctx, r.Msg.Id)
 	if err != nil {
-		var mismatchErr *nodectrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -59,7 +75,7 @@ func (d *Service) List(ctx context.Context, r *connect.Request[node.ListRequest]
   // TODO: authorize
 	result, err := d.controller.List(ctx)
 	if err != nil {
-		var mismatchErr *nodectrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -72,3 +88,55 @@ func (d *Service) List(ctx context.Context, r *connect.Request[node.ListRequest]
 		Msg: &node.ListResponse{Nodes: result},
 	}, nil
 }
+
+func (d *Service) GetLeader(ctx context.Context, r *connect.Request[node.GetLeaderRequest]) (*connect.Response[node.GetLeaderResponse], error) {
+  // TODO: authorize
+	leader, _ := d.leader()
+
+	return &connect.Response[node.GetLeaderResponse]{
+		Msg: &node.GetLeaderResponse{Id: leader},
+	}, nil
+}
+
+func (d *Service) StepDown(ctx context.Context, r *connect.Request[node.StepDownRequest]) (*connect.Response[node.StepDownResponse], error) {
+  // TODO: authorize
+	err := d.stepDown(ctx)
+	if err != nil {
+		if errors.Is(err, elect.ErrNotLeader) {
+			rpcErr := connect.NewError(connect.CodeFailedPrecondition, err)
+			if leader, _ := d.leader(); leader != "" {
+				rpcErr.Meta().Add("Location", leader)
+			}
+			return nil, rpcErr
+		}
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+	leader, _ := d.leader()
+
+	return &connect.Response[node.StepDownResponse]{
+		Msg: &node.StepDownResponse{Id: leader},
+	}, nil
+}
+
+func (d *Service) ReloadConfig(ctx context.Context, r *connect.Request[node.ReloadConfigRequest]) (*connect.Response[node.ReloadConfigResponse], error) {
+  // TODO: authorize
+	applied, restartRequired, err := d.reload()
+	if err != nil {
+		rpcErr := connect.NewError(connect.CodeFailedPrecondition, err)
+		// settings may be partially applied if only some reload hooks failed,
+		// the result is attached to the error so that the caller knows the state of the node.
+		if applied != nil || restartRequired != nil {
+			detail, detailErr := connect.NewErrorDetail(
+				&node.ReloadConfigResponse{Applied: applied, RestartRequired: restartRequired},
+			)
+			if detailErr == nil {
+				rpcErr.AddDetail(detail)
+			}
+		}
+		return nil, rpcErr
+	}
+
+	return &connect.Response[node.ReloadConfigResponse]{
+		Msg: &node.ReloadConfigResponse{Applied: applied, RestartRequired: restartRequired},
+	}, nil
+}
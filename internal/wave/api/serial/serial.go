@@ -26,6 +26,7 @@ import (
 
 	"connectrpc.com/connect"
 	"cthul.io/cthul/pkg/api/wave/v1/serial"
+	"cthul.io/cthul/pkg/resource"
 	serialctrl "cthul.io/cthul/pkg/wave/serial"
 	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
@@ -45,7 +46,7 @@ func (d *Service) Get(ctx context.Context, r *connect.Request[serial.GetRequest]
 	// TODO: authorize
 	result, err := d.controller.Lookup(ctx, r.Msg.Id)
 	if err != nil {
-		var mismatchErr *serialctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -66,7 +67,7 @@ func (d *Service) Connect(ctx context.Context, r *connect.BidiStream[serial.Conn
 
 	err := d.controller.Connect(ctx, r.RequestHeader().Get("id"), reader, writer)
 	if err != nil {
-		var mismatchErr *serialctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -124,7 +125,7 @@ func (d *Service) List(ctx context.Context, r *connect.Request[serial.ListReques
 	// TODO: authorize
 	result, err := d.controller.List(ctx)
 	if err != nil {
-		var mismatchErr *serialctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -138,12 +139,30 @@ func (d *Service) List(ctx context.Context, r *connect.Request[serial.ListReques
 	}, nil
 }
 
+func (d *Service) Status(ctx context.Context, r *connect.Request[serial.StatusRequest]) (*connect.Response[serial.StatusResponse], error) {
+	// TODO: authorize
+	result, err := d.controller.Status(ctx, r.Msg.Id)
+	if err != nil {
+		var mismatchErr *resource.NodeMismatchErr
+		if errors.As(err, &mismatchErr) {
+			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
+			rpcErr.Meta().Add("Location", mismatchErr.Node)
+			return nil, rpcErr
+		}
+		return nil, err
+	}
+
+	return &connect.Response[serial.StatusResponse]{
+		Msg: &serial.StatusResponse{Status: result},
+	}, nil
+}
+
 func (d *Service) Create(ctx context.Context, r *connect.Request[serial.CreateRequest]) (*connect.Response[serial.CreateResponse], error) {
 	// TODO: authorize
 	id := uuid.New().String()
-	err := d.controller.Apply(ctx, id, r.Msg.Config)
+	err := d.controller.Create(ctx, id, r.Msg.Config)
 	if err != nil {
-		var mismatchErr *serialctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -161,7 +180,7 @@ func (d *Service) Update(ctx context.Context, r *connect.Request[serial.UpdateRe
 	// TODO: authorize
 	err := d.controller.Apply(ctx, r.Msg.Id, r.Msg.Config)
 	if err != nil {
-		var mismatchErr *serialctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -179,7 +198,7 @@ func (d *Service) Delete(ctx context.Context, r *connect.Request[serial.DeleteRe
 	// TODO: authorize
 	err := d.controller.Delete(ctx, r.Msg.Id)
 	if err != nil {
-		var mismatchErr *serialctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
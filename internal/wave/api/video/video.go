@@ -26,6 +26,7 @@ import (
 
 	"connectrpc.com/connect"
 	"cthul.io/cthul/pkg/api/wave/v1/video"
+	"cthul.io/cthul/pkg/resource"
 	videoctrl "cthul.io/cthul/pkg/wave/video"
 	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
@@ -45,7 +46,7 @@ func (d *Service) Get(ctx context.Context, r *connect.Request[video.GetRequest])
 	// TODO: authorize
 	result, err := d.controller.Lookup(ctx, r.Msg.Id)
 	if err != nil {
-		var mismatchErr *videoctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -66,7 +67,7 @@ func (d *Service) Connect(ctx context.Context, r *connect.BidiStream[video.Conne
 
 	err := d.controller.Connect(ctx, r.RequestHeader().Get("id"), reader, writer)
 	if err != nil {
-		var mismatchErr *videoctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -124,7 +125,7 @@ func (d *Service) List(ctx context.Context, r *connect.Request[video.ListRequest
 	// TODO: authorize
 	result, err := d.controller.List(ctx)
 	if err != nil {
-		var mismatchErr *videoctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -138,12 +139,30 @@ func (d *Service) List(ctx context.Context, r *connect.Request[video.ListRequest
 	}, nil
 }
 
+func (d *Service) Status(ctx context.Context, r *connect.Request[video.StatusRequest]) (*connect.Response[video.StatusResponse], error) {
+	// TODO: authorize
+	result, err := d.controller.Status(ctx, r.Msg.Id)
+	if err != nil {
+		var mismatchErr *resource.NodeMismatchErr
+		if errors.As(err, &mismatchErr) {
+			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
+			rpcErr.Meta().Add("Location", mismatchErr.Node)
+			return nil, rpcErr
+		}
+		return nil, err
+	}
+
+	return &connect.Response[video.StatusResponse]{
+		Msg: &video.StatusResponse{Status: result},
+	}, nil
+}
+
 func (d *Service) Create(ctx context.Context, r *connect.Request[video.CreateRequest]) (*connect.Response[video.CreateResponse], error) {
 	// TODO: authorize
 	id := uuid.New().String()
-	err := d.controller.Apply(ctx, id, r.Msg.Config)
+	err := d.controller.Create(ctx, id, r.Msg.Config)
 	if err != nil {
-		var mismatchErr *videoctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -161,7 +180,7 @@ func (d *Service) Update(ctx context.Context, r *connect.Request[video.UpdateReq
 	// TODO: authorize
 	err := d.controller.Apply(ctx, r.Msg.Id, r.Msg.Config)
 	if err != nil {
-		var mismatchErr *videoctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
@@ -179,7 +198,7 @@ func (d *Service) Delete(ctx context.Context, r *connect.Request[video.DeleteReq
 	// TODO: authorize
 	err := d.controller.Delete(ctx, r.Msg.Id)
 	if err != nil {
-		var mismatchErr *videoctrl.NodeMismatchErr
+		var mismatchErr *resource.NodeMismatchErr
 		if errors.As(err, &mismatchErr) {
 			rpcErr := connect.NewError(connect.CodeNotFound, mismatchErr)
 			rpcErr.Meta().Add("Location", mismatchErr.Node)
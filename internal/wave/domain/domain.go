@@ -26,6 +26,7 @@ import (
 
 	"cthul.io/cthul/pkg/adapter/domain"
 	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/informer"
 	"cthul.io/cthul/pkg/syncer"
 )
 
@@ -38,6 +39,8 @@ type Operator struct {
 	client db.Client
   logger *slog.Logger
   syncer *syncer.Syncer
+  // informer caches the domain state, it is used to read the desired domains without querying the database.
+  informer *informer.Informer
 
 	// nodeId specifies the id of the node, this is used to determine which domains must be applieo.
 	nodeId string
@@ -70,7 +73,8 @@ func New(logger *slog.Logger, client db.Client, adapter domain.Adapter, opts ...
 		adapter: adapter,
 		client: client,
 		logger: logger.WithGroup("domain-operator"),
-    syncer: syncer.New(logger.WithGroup("domain-operator"), client),
+    syncer: nil,
+    informer: nil,
 		nodeId: "undefined",
 		updateCycleTTL: 10,
     localCycleTTL: 60,
@@ -83,6 +87,8 @@ func New(logger *slog.Logger, client db.Client, adapter domain.Adapter, opts ...
 	for _, opt := range opts {
 		opt(operator)
 	}
+	// the syncer is created after the options are applied, as it reports the key status of the local node.
+	operator.syncer = syncer.New(logger.WithGroup("domain-operator"), client, syncer.WithNodeId(operator.nodeId))
 
 	return operator
 }
@@ -96,6 +102,14 @@ func WithNodeId(id string) Option {
 	}
 }
 
+// WithInformer defines an informer that caches the domain state (see resource.NewInformer()).
+// If set, the prune cycle reads the desired domains from the cache instead of the database.
+func WithInformer(informer *informer.Informer) Option {
+	return func(o *Operator) {
+		o.informer = informer
+	}
+}
+
 // WithUpdateCylceTTL defines a custom update cycle interval.
 // Every cycle checks which domains are managed by the local node and fully refreshes all running syncers.
 // Syncers are also incrementally updated in realtime when updated on database, this cycle just fully resyncs.
@@ -125,6 +139,11 @@ func (o *Operator) ServeAndDetach() {
   o.synchronize()
 }
 
+// Health returns an error if the domain states are currently not watched incrementally.
+func (o *Operator) Health() error {
+  return o.syncer.Health()
+}
+
 func (o *Operator) Terminate(ctx context.Context) error {
   o.syncer.Shutdown()
   o.rootCtxCancel()
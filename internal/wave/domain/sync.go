@@ -22,13 +22,13 @@ package domain
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"encoding/json"
 	"errors"
 
 	"cthul.io/cthul/pkg/api/wave/v1/domain"
+	"cthul.io/cthul/pkg/keyspace"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -60,16 +60,26 @@ func (o *Operator) synchronize() {
 		}
 	}()
 
-	o.syncer.Add("/WAVE/DOMAIN/REQNODE/", o.updateCycleTTL, func(ctx context.Context, k, reqnode string) error {
-		id := strings.TrimPrefix(k, "/WAVE/DOMAIN/REQNODE/")
-		configKey := fmt.Sprintf("/WAVE/DOMAIN/CONFIG/%s", id)
+	o.syncer.Add(keyspace.WaveDomain.Reqnode.Prefix(), o.updateCycleTTL, func(ctx context.Context, k, reqnode string, deleted bool) error {
+		id := keyspace.WaveDomain.Reqnode.Id(k)
+		configKey := keyspace.WaveDomain.Config.Key(id)
+		if deleted {
+			// domain was detached or deleted, it is no longer managed by any node and must be removed locally.
+			o.syncer.Remove(configKey, false)
+			o.pruneDomain(ctx, id, "")
+			return nil
+		}
 		if reqnode == o.nodeId {
-			o.syncer.Add(configKey, o.syncCycleTTL, func(ctx context.Context, k, v string) error {
+			o.syncer.Add(configKey, o.syncCycleTTL, func(ctx context.Context, k, v string, deleted bool) error {
+        if deleted {
+          // domain removal is handled by the reqnode deletion, there is nothing to apply.
+          return nil
+        }
         err := o.applyConfig(ctx, id, v)
         if err!=nil {
           return err
         }
-				_, err = o.client.Set(ctx, fmt.Sprintf("/WAVE/DOMAIN/NODE/%s", id), reqnode, 0)
+				_, err = o.client.Set(ctx, keyspace.WaveDomain.Node.Key(id), reqnode, 0)
 				if err != nil {
 					return err
 				}
@@ -126,12 +136,24 @@ func (o *Operator) applyConfig(ctx context.Context, id, rawConfig string) error
 // pruneAllDomains compares the local domains with the desired domains on the database. All domains that are
 // present on the node but not on the database (managed by this node) are removed with pruneDomain().
 func (o *Operator) pruneAllDomains(ctx context.Context) {
-	domains, err := o.client.GetRange(o.rootCtx, "/WAVE/DOMAIN/REQNODE/")
-	if err != nil {
-		o.logger.Error(fmt.Sprintf(
-			"failed to load domains: %s; skipping prune process...", err.Error(),
-		))
-		return
+	var reqnodes map[string]string
+	if o.informer != nil && o.informer.Synced() {
+		reqnodes = o.informer.List()
+	} else {
+		var err error
+		reqnodes, err = o.client.GetRange(o.rootCtx, keyspace.WaveDomain.Reqnode.Prefix())
+		if err != nil {
+			o.logger.Error(fmt.Sprintf(
+				"failed to load domains: %s; skipping prune process...", err.Error(),
+			))
+			return
+		}
+	}
+	domains := map[string]string{}
+	for key, reqnode := range reqnodes {
+		if id, ok := keyspace.WaveDomain.Reqnode.Parse(key); ok {
+			domains[id] = reqnode
+		}
 	}
 
 	// create localdomain snapshot to avoid blocking the mutex while pruning.
@@ -171,7 +193,7 @@ func (o *Operator) pruneDomain(ctx context.Context, id, node string) {
 
 	o.logger.Debug(fmt.Sprintf("starting graceful destruction of domain '%s'...", id))
 
-	rawConfig, err := o.client.Get(ctx, fmt.Sprintf("/WAVE/DOMAIN/CONFIG/%s", id))
+	rawConfig, err := o.client.Get(ctx, keyspace.WaveDomain.Config.Key(id))
 	if err != nil {
 		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 			o.logger.Warn(fmt.Sprintf(
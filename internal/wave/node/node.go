@@ -25,6 +25,7 @@ import (
 	"log/slog"
 
 	"cthul.io/cthul/pkg/db"
+	nodestruct "cthul.io/cthul/pkg/api/wave/v1/node"
 )
 
 // Operator is responsible to monitor and measure the state and resources of the host node.
@@ -47,14 +48,31 @@ type Operator struct {
 	nodeId string
 	// cycleTTL specifies the interval for scheduler cycles.
 	cycleTTL int64
+	// configLock protects the settings that can be reconfigured while the operator runs.
+	configLock sync.RWMutex
 	// maintenance specifies whether maintenance mode is enabled.
 	maintenance bool
 	// affinity holds node affinity tags used for scheduling decisions.
 	affinity []string
+	// labels holds key/value node labels used for scheduling decisions (e.g. topology of spread constraints).
+	labels map[string]string
 	// cpuFactor specifies how much host cpu is incorporated to the reported values.
 	cpuFactor float64
 	// memoryFactor specifies how much host memory is incorporated to the reported values.
 	memoryFactor float64
+	// cpuOvercommit and memoryOvercommit specify the ratio of committable to physical resources
+	// (e.g. 4 = domains can commit 4 vcpus per core).
+	cpuOvercommit    float64
+	memoryOvercommit float64
+	// reservedCpu and reservedMemory specify host resources that are never committed to domains.
+	reservedCpu    float64
+	reservedMemory int64
+	// healthChecks holds checks evaluated on every cycle, if one fails the node is reported as degraded.
+	healthChecks []func() error
+
+	// nodeInfo holds the node information measured in the last cycle (nil = not measured yet).
+	nodeInfo     *nodestruct.Node
+	nodeInfoLock sync.RWMutex
 }
 
 type OperatorOption func(*Operator)
@@ -63,19 +81,28 @@ func New(logger *slog.Logger, client db.Client, opts ...OperatorOption) *Operato
 	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
 	workCtx, workCtxCancel := context.WithCancel(rootCtx)
 	operator := &Operator{
-		rootCtx:       rootCtx,
-		rootCtxCancel: rootCtxCancel,
-		workCtx:       workCtx,
-		workCtxCancel: workCtxCancel,
-		finChan:       make(chan struct{}),
-		client:        client,
-		logger:        logger.WithGroup("node-operator"),
-		nodeId:        "undefined",
-		cycleTTL:      5,
-		maintenance:   false,
-		affinity:      []string{},
-		cpuFactor:     1,
-		memoryFactor:  1,
+		rootCtx:          rootCtx,
+		rootCtxCancel:    rootCtxCancel,
+		workCtx:          workCtx,
+		workCtxCancel:    workCtxCancel,
+		finChan:          make(chan struct{}),
+		client:           client,
+		logger:           logger.WithGroup("node-operator"),
+		nodeId:           "undefined",
+		cycleTTL:         5,
+		configLock:       sync.RWMutex{},
+		maintenance:      false,
+		affinity:         []string{},
+		labels:           map[string]string{},
+		cpuFactor:        1,
+		memoryFactor:     1,
+		cpuOvercommit:    1,
+		memoryOvercommit: 1,
+		reservedCpu:      0,
+		reservedMemory:   0,
+		healthChecks:     []func() error{},
+		nodeInfo:         nil,
+		nodeInfoLock:     sync.RWMutex{},
 	}
 
 	for _, opt := range opts {
@@ -116,6 +143,13 @@ func WithAffinity(tags ...string) OperatorOption {
 	}
 }
 
+// WithLabels defines key/value node labels (e.g. rack=a). The defined labels are reported to the cluster.
+func WithLabels(labels map[string]string) OperatorOption {
+	return func(n *Operator) {
+		n.labels = labels
+	}
+}
+
 // WithResourceFactor defines custom resource factors. The resource factors specify how much host resources
 // are incorporated to the reported resource values.
 // (e.g. 10 cores with 8 available and a factor of 0.8 = 8 cores with 6 available).
@@ -126,6 +160,32 @@ func WithResourceFactor(cpuFactor, memoryFactor float64) OperatorOption {
 	}
 }
 
+// WithOvercommit defines custom overcommit ratios. The ratios specify how many resources domains can commit
+// per physical resource (e.g. 8 cores with a cpu ratio of 4 = 32 committable vcpus).
+func WithOvercommit(cpuRatio, memoryRatio float64) OperatorOption {
+	return func(n *Operator) {
+		n.cpuOvercommit = cpuRatio
+		n.memoryOvercommit = memoryRatio
+	}
+}
+
+// WithReservedCapacity defines host resources (cpu cores & memory bytes) that are reserved for the host.
+// Reserved resources are subtracted from the committable resources reported to the cluster.
+func WithReservedCapacity(cpu float64, memory int64) OperatorOption {
+	return func(n *Operator) {
+		n.reservedCpu = cpu
+		n.reservedMemory = memory
+	}
+}
+
+// WithHealthChecks defines checks evaluated on every cycle (e.g. the aggregated readiness of lifecycle.Health).
+// If a check fails, the node is reported as degraded to the cluster, which prevents scheduling onto it.
+func WithHealthChecks(checks ...func() error) OperatorOption {
+	return func(n *Operator) {
+		n.healthChecks = append(n.healthChecks, checks...)
+	}
+}
+
 // ServeAndDetach starts the Operator reporting process in a detached goroutine.
 func (n *Operator) ServeAndDetach() {
 	wg := sync.WaitGroup{}
@@ -141,6 +201,82 @@ func (n *Operator) ServeAndDetach() {
 	}()
 }
 
+// SetMaintenance enables or disables the maintenance mode of the running operator (applied on the next cycle).
+func (n *Operator) SetMaintenance(maintenance bool) {
+	n.configLock.Lock()
+	defer n.configLock.Unlock()
+	n.maintenance = maintenance
+}
+
+// SetAffinity updates the affinity tags of the running operator (applied on the next cycle).
+func (n *Operator) SetAffinity(tags ...string) {
+	n.configLock.Lock()
+	defer n.configLock.Unlock()
+	n.affinity = tags
+}
+
+// SetLabels updates the node labels of the running operator (applied on the next cycle).
+func (n *Operator) SetLabels(labels map[string]string) {
+	n.configLock.Lock()
+	defer n.configLock.Unlock()
+	n.labels = labels
+}
+
+// SetResourceFactor updates the resource factors of the running operator (applied on the next cycle).
+func (n *Operator) SetResourceFactor(cpuFactor, memoryFactor float64) {
+	n.configLock.Lock()
+	defer n.configLock.Unlock()
+	n.cpuFactor = cpuFactor
+	n.memoryFactor = memoryFactor
+}
+
+// SetOvercommit updates the overcommit ratios of the running operator (applied on the next cycle).
+func (n *Operator) SetOvercommit(cpuRatio, memoryRatio float64) {
+	n.configLock.Lock()
+	defer n.configLock.Unlock()
+	n.cpuOvercommit = cpuRatio
+	n.memoryOvercommit = memoryRatio
+}
+
+// SetReservedCapacity updates the reserved host resources of the running operator (applied on the next cycle).
+func (n *Operator) SetReservedCapacity(cpu float64, memory int64) {
+	n.configLock.Lock()
+	defer n.configLock.Unlock()
+	n.reservedCpu = cpu
+	n.reservedMemory = memory
+}
+
+// Cash computes the election weight of the local node from the node information measured in the last cycle.
+// Nodes in maintenance (or not measured yet) have no cash, degraded nodes have minimal cash and healthy nodes
+// gain cash based on the share of available resources (100 - 1000).
+func (n *Operator) Cash() int64 {
+	n.nodeInfoLock.RLock()
+	defer n.nodeInfoLock.RUnlock()
+	if n.nodeInfo == nil || n.nodeInfo.Config == nil {
+		return 0
+	}
+	switch n.nodeInfo.Config.State {
+	case nodestruct.NodeState_NODE_STATE_HEALTHY:
+		cpuShare := resourceShare(n.nodeInfo.Config.AvailableCpu, n.nodeInfo.Config.AllocatedCpu)
+		memShare := resourceShare(
+			float64(n.nodeInfo.Config.AvailableMemory), float64(n.nodeInfo.Config.AllocatedMemory),
+		)
+		return 100 + int64(900*(0.5*cpuShare+0.5*memShare))
+	case nodestruct.NodeState_NODE_STATE_DEGRADED:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// resourceShare returns the available share of an allocated resource (clamped to 0 - 1).
+func resourceShare(available, allocated float64) float64 {
+	if allocated <= 0 {
+		return 0
+	}
+	return min(max(available/allocated, 0), 1)
+}
+
 // Terminate shuts down the node operator gracefully, if shutdown did not complete in the provided context window
 // the operator is terminated forcefully. Never returns an error (just there to match termination pattern).
 func (n *Operator) Terminate(ctx context.Context) error {
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"time"
 
+	"cthul.io/cthul/pkg/db"
 	"cthul.io/cthul/pkg/wave/node"
   nodestruct "cthul.io/cthul/pkg/api/wave/v1/node"
 
@@ -34,8 +35,11 @@ import (
 // register registers the local node periodically in the cluster. This process reports the nodes
 // associated state and allows other wave components like the scheduler to discover it.
 // On every cycle the node state & resources are measured and reevaluated.
+// The registration is attached to a lease that is kept alive in the background, if the node crashes
+// the registration disappears as soon as the lease expires.
 func (n *Operator) register() {
 	nodeController := node.New(n.nodeId, n.client)
+	nodeLease := db.NewLease(n.client, n.cycleTTL*2)
 	
 	for {
 		ctx, cancel := context.WithTimeout(n.workCtx, time.Second*time.Duration(n.cycleTTL))
@@ -43,12 +47,22 @@ func (n *Operator) register() {
 		
 		n.logger.Debug("measuring local node resource capacity...")
 		node, err := n.acquireNodeInfo(ctx)
+		// the node information is also exposed to the election (see Cash()), failed measurements reset it.
+		n.nodeInfoLock.Lock()
+		n.nodeInfo = node
+		n.nodeInfoLock.Unlock()
 		if err!=nil {
 			n.logger.Error(fmt.Sprintf("cannot report node state: %s", err.Error()))
 		} else {
-			err = nodeController.Register(ctx, n.nodeId, node, n.cycleTTL*2)
+
+			lease, err := nodeLease.Acquire(ctx)
 			if err != nil {
-				n.logger.Error(fmt.Sprintf("failed to register node: %s", err.Error()))
+				n.logger.Error(fmt.Sprintf("failed to acquire node lease: %s", err.Error()))
+			} else {
+				err = nodeController.Register(ctx, n.nodeId, node, lease)
+				if err != nil {
+					n.logger.Error(fmt.Sprintf("failed to register node: %s", err.Error()))
+				}
 			}
 		}
 
@@ -60,6 +74,10 @@ func (n *Operator) register() {
 			if err != nil {
 				n.logger.Error("failed to unregister node before termination")
 			}
+			err = nodeLease.Release(n.rootCtx)
+			if err != nil {
+				n.logger.Error("failed to revoke node lease before termination")
+			}
 			return
 		}
 	}
@@ -68,15 +86,31 @@ func (n *Operator) register() {
 // acquireNodeInfo acquires an informational node by reading local machine specs (cpu, mem, etc)
 // and further attributes statically defined on the operator.
 func (n *Operator) acquireNodeInfo(ctx context.Context) (*nodestruct.Node, error) {
+	n.configLock.RLock()
+	affinity, labels, maintenance := n.affinity, n.labels, n.maintenance
+	cpuFactor, memoryFactor := n.cpuFactor, n.memoryFactor
+	cpuOvercommit, memoryOvercommit := n.cpuOvercommit, n.memoryOvercommit
+	reservedCpu, reservedMemory := n.reservedCpu, n.reservedMemory
+	n.configLock.RUnlock()
+
 	node := nodestruct.Node{
     Config: &nodestruct.NodeConfig{
-      Affinity: n.affinity,
+      Affinity: affinity,
+      Labels: labels,
       State: nodestruct.NodeState_NODE_STATE_HEALTHY,
     },
 	}
 
-	if n.maintenance {
+	if maintenance {
     node.Config.State = nodestruct.NodeState_NODE_STATE_MAINTENANCE
+	} else {
+		for _, check := range n.healthChecks {
+			if err := check(); err != nil {
+				n.logger.Warn(fmt.Sprintf("node is degraded: %s", err.Error()))
+				node.Config.State = nodestruct.NodeState_NODE_STATE_DEGRADED
+				break
+			}
+		}
 	}
 
 	cpuCores, err := cpu.InfoWithContext(ctx)
@@ -88,21 +122,25 @@ func (n *Operator) acquireNodeInfo(ctx context.Context) (*nodestruct.Node, error
 		totalCpuCores += core.Cores
 	}
 	// total cpu cores * factor (e.g. 10 * 0.8 = 8 cores)
-	node.Config.AllocatedCpu = float64(totalCpuCores) * n.cpuFactor
+	node.Config.AllocatedCpu = float64(totalCpuCores) * cpuFactor
+	// (factored cpu cores - reserved cores) * overcommit ratio (e.g. (8 - 2) * 4 = 24 vcpus)
+	node.Config.CommittableCpu = max(node.Config.AllocatedCpu - reservedCpu, 0) * cpuOvercommit
 
 	cpuLoad, err := cpu.PercentWithContext(ctx, 0, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to measure cpu load")
 	}
 	// cpu factor - cpu load * total cpu cores (e.g. (0.8 - 0.4) * 10 = 4 cores)
-	node.Config.AvailableCpu = (n.cpuFactor * 100 - cpuLoad[0]) / 100 * float64(totalCpuCores)
+	node.Config.AvailableCpu = (cpuFactor * 100 - cpuLoad[0]) / 100 * float64(totalCpuCores)
 
 	memoryUsage, err := mem.VirtualMemoryWithContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire virtual memory information")
 	}
 	// total mem bytes * memfactor (e.g. 4096 * 0.8 = 3276 bytes)
-	node.Config.AllocatedMemory = int64(float64(memoryUsage.Total) * n.memoryFactor)
+	node.Config.AllocatedMemory = int64(float64(memoryUsage.Total) * memoryFactor)
+	// (factored mem bytes - reserved mem bytes) * overcommit ratio (e.g. (3276 - 1000) * 1 = 2276 bytes)
+	node.Config.CommittableMemory = int64(float64(max(node.Config.AllocatedMemory - reservedMemory, 0)) * memoryOvercommit)
 	// factored mem bytes * used mem bytes (e.g. 3276 - 2000 = 1276 bytes)
 	node.Config.AvailableMemory = node.Config.AllocatedMemory - int64(memoryUsage.Used)
 
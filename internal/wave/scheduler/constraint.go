@@ -0,0 +1,147 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scheduler
+
+import (
+	"fmt"
+
+	"cthul.io/cthul/pkg/api/wave/v1/domain"
+	"cthul.io/cthul/pkg/api/wave/v1/node"
+)
+
+// checkConstraints evaluates the anti-affinity and spread constraints of the domain if it is placed on the
+// target node. Domains are accounted on their requested node (reqnode), spread topologies are built from the
+// provided nodes. Returns the number of violated soft constraints or an error if a hard constraint is violated.
+func checkConstraints(
+	dom *domain.Domain,
+	nodeId string,
+	domains map[string]*domain.Domain,
+	nodes map[string]*node.Node,
+) (int, error) {
+	violations := 0
+	for _, constraint := range dom.GetConfig().GetAntiAffinity() {
+		err := checkAntiAffinity(dom, constraint, nodeId, domains)
+		if err == nil {
+			continue
+		}
+		if isHard(constraint.GetMode()) {
+			return 0, err
+		}
+		violations++
+	}
+	for _, constraint := range dom.GetConfig().GetSpreadConstraints() {
+		err := checkSpread(dom, constraint, nodeId, domains, nodes)
+		if err == nil {
+			continue
+		}
+		if isHard(constraint.GetMode()) {
+			return 0, err
+		}
+		violations++
+	}
+	return violations, nil
+}
+
+// checkAntiAffinity returns an error if another domain of the anti-affinity group is requested on the node.
+func checkAntiAffinity(
+	dom *domain.Domain,
+	constraint *domain.AntiAffinity,
+	nodeId string,
+	domains map[string]*domain.Domain,
+) error {
+	for otherId, other := range domains {
+		if other == dom || other.Reqnode != nodeId {
+			continue
+		}
+		for _, otherConstraint := range other.GetConfig().GetAntiAffinity() {
+			if otherConstraint.GetGroup() == constraint.GetGroup() {
+				return fmt.Errorf(
+					"anti-affinity group '%s' is already placed on '%s' (domain '%s')",
+					constraint.GetGroup(), nodeId, otherId,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// checkSpread returns an error if placing the domain on the node exceeds the max skew of the spread constraint.
+// The skew is the number of group domains in the topology domain of the node (including the placed domain)
+// minus the number of group domains in the least populated topology domain.
+// Nodes without the topology label are not part of the topology and never satisfy the constraint.
+func checkSpread(
+	dom *domain.Domain,
+	constraint *domain.SpreadConstraint,
+	nodeId string,
+	domains map[string]*domain.Domain,
+	nodes map[string]*node.Node,
+) error {
+	topologyKey := constraint.GetTopologyKey()
+	topology, ok := nodes[nodeId].GetConfig().GetLabels()[topologyKey]
+	if !ok {
+		return fmt.Errorf("node '%s' has no topology label '%s'", nodeId, topologyKey)
+	}
+
+	counts := map[string]int64{}
+	for _, nod := range nodes {
+		if value, ok := nod.GetConfig().GetLabels()[topologyKey]; ok {
+			counts[value] = 0
+		}
+	}
+	for _, other := range domains {
+		if other == dom || !inSpreadGroup(other, constraint) {
+			continue
+		}
+		value, ok := nodes[other.Reqnode].GetConfig().GetLabels()[topologyKey]
+		if !ok {
+			continue
+		}
+		counts[value]++
+	}
+
+	minCount := counts[topology]
+	for _, count := range counts {
+		minCount = min(minCount, count)
+	}
+	maxSkew := max(constraint.GetMaxSkew(), 1)
+	if skew := counts[topology] + 1 - minCount; skew > maxSkew {
+		return fmt.Errorf(
+			"spread group '%s' exceeds max skew on '%s' (%s=%s: skew %d > %d)",
+			constraint.GetGroup(), nodeId, topologyKey, topology, skew, maxSkew,
+		)
+	}
+	return nil
+}
+
+// inSpreadGroup checks if the domain declares a spread constraint with the same group and topology.
+func inSpreadGroup(dom *domain.Domain, constraint *domain.SpreadConstraint) bool {
+	for _, otherConstraint := range dom.GetConfig().GetSpreadConstraints() {
+		if otherConstraint.GetGroup() == constraint.GetGroup() &&
+			otherConstraint.GetTopologyKey() == constraint.GetTopologyKey() {
+			return true
+		}
+	}
+	return false
+}
+
+// isHard checks if the constraint mode is hard (unspecified modes are treated as hard).
+func isHard(mode domain.ConstraintMode) bool {
+	return mode != domain.ConstraintMode_CONSTRAINT_MODE_SOFT
+}
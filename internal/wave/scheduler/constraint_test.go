@@ -0,0 +1,76 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scheduler
+
+import (
+	"testing"
+
+	"cthul.io/cthul/pkg/api/wave/v1/domain"
+	"cthul.io/cthul/pkg/api/wave/v1/node"
+)
+
+func TestConstraintFilter(t *testing.T) {
+	hard, soft := domain.ConstraintMode_CONSTRAINT_MODE_HARD, domain.ConstraintMode_CONSTRAINT_MODE_SOFT
+	antiAffinity := func(reqnode string, mode domain.ConstraintMode) *domain.Domain {
+		dom := testDomain(reqnode, 1, 1024)
+		dom.Config.AntiAffinity = []*domain.AntiAffinity{{Group: "db", Mode: mode}}
+		return dom
+	}
+	spread := func(reqnode string, mode domain.ConstraintMode) *domain.Domain {
+		dom := testDomain(reqnode, 1, 1024)
+		dom.Config.SpreadConstraints = []*domain.SpreadConstraint{{
+			Group: "web", TopologyKey: "zone", MaxSkew: 1, Mode: mode,
+		}}
+		return dom
+	}
+
+	tests := []struct {
+		name           string
+		domain         *domain.Domain
+		other          *domain.Domain
+		nodeId         string
+		wantErr        string
+		wantViolations int
+	}{
+		{"anti-affinity group on node", antiAffinity("", hard), antiAffinity("n1", hard), "n1", "anti-affinity", 0},
+		{"anti-affinity group elsewhere", antiAffinity("", hard), antiAffinity("n1", hard), "n2", "", 0},
+		{"soft anti-affinity", antiAffinity("", soft), antiAffinity("n1", hard), "n1", "", 1},
+		{"spread exceeds skew", spread("", hard), spread("n1", hard), "n1", "max skew", 0},
+		{"spread within skew", spread("", hard), spread("n1", hard), "n2", "", 0},
+		{"spread without topology label", spread("", hard), spread("n1", hard), "n3", "topology label", 0},
+		{"soft spread", spread("", soft), spread("n1", hard), "n1", "", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := map[string]*node.Node{
+				"n1": testNode(4, 4, 4096, 4096, map[string]string{"zone": "a"}),
+				"n2": testNode(4, 4, 4096, 4096, map[string]string{"zone": "b"}),
+				"n3": testNode(4, 4, 4096, 4096, nil),
+			}
+			domains := map[string]*domain.Domain{"d": tt.domain, "other": tt.other}
+
+			p := newPlacement(tt.domain, domains, nodes, testSettings())
+			checkErr(t, constraintFilter{}.filter(p, tt.nodeId, nodes[tt.nodeId]), tt.wantErr)
+			if got := p.softViolations[tt.nodeId]; got != tt.wantViolations {
+				t.Errorf("soft violations = %d, want %d", got, tt.wantViolations)
+			}
+		})
+	}
+}
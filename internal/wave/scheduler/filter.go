@@ -0,0 +1,115 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scheduler
+
+import (
+	"fmt"
+
+	"cthul.io/cthul/pkg/api/wave/v1/node"
+)
+
+// eligibleFilter removes nodes that are unhealthy or do not match the affinity tags and required
+// node affinity selectors of the domain.
+type eligibleFilter struct{}
+
+func (eligibleFilter) name() string { return "eligibility" }
+
+func (eligibleFilter) filter(p *placement, _ string, nod *node.Node) error {
+	return checkEligible(p, nod)
+}
+
+// checkEligible returns an error if the node is not healthy or does not match the affinity of the domain.
+func checkEligible(p *placement, nod *node.Node) error {
+	if nod.Error != "" {
+		return fmt.Errorf("node information is malformed: %s", nod.Error)
+	}
+	if nod.Config.State != node.NodeState_NODE_STATE_HEALTHY {
+		return fmt.Errorf("node is not healthy")
+	}
+	// the legacy affinity tags are only required if the domain does not define a node affinity.
+	if (len(p.domain.Config.Affinity) > 0 || p.domain.Config.NodeAffinity == nil) &&
+		!checkAffinity(p.domain.Config.Affinity, nod.Config.Affinity) {
+		return fmt.Errorf("node does not match any affinity tag")
+	}
+	if !matchRequirements(p.domain.Config.GetNodeAffinity().GetRequired(), nod.Config.GetLabels()) {
+		return fmt.Errorf("node labels do not match the required node affinity")
+	}
+	return nil
+}
+
+// commitFilter removes nodes whose committable capacity would be exceeded by the domain. The resources of all
+// domains requested on the node are committed regardless of their actual usage, this prevents overcommitting
+// a node that currently looks idle.
+type commitFilter struct{}
+
+func (commitFilter) name() string { return "committable resources" }
+
+func (commitFilter) filter(p *placement, nodeId string, nod *node.Node) error {
+	cpu, mem := p.commitment(nodeId)
+	if cpuLimit := nod.Config.CommittableCpu * p.cpuCommitRatio; cpu > cpuLimit {
+		return fmt.Errorf("committed cpu exceeds the limit (%.2f / %.2f vcpus)", cpu, cpuLimit)
+	}
+	if memLimit := float64(nod.Config.CommittableMemory) * p.memCommitRatio; float64(mem) > memLimit {
+		return fmt.Errorf("committed memory exceeds the limit (%d / %d bytes)", mem, int64(memLimit))
+	}
+	return nil
+}
+
+// availableFilter removes nodes that currently do not provide enough available resources.
+// This is done to prevent moving a domain to a node that has currently not sufficient capacity.
+// For example if a high load cluster node failsover, instead of moving all domains at once to another
+// available node, every cycle just moves the amount of nodes the currently fit within the current capacity.
+type availableFilter struct{}
+
+func (availableFilter) name() string { return "available resources" }
+
+func (availableFilter) filter(p *placement, _ string, nod *node.Node) error {
+	if nod.Config.AvailableCpu <= p.assumedCpu {
+		return fmt.Errorf("insufficient available cpu")
+	}
+	if nod.Config.AvailableMemory <= p.assumedMem {
+		return fmt.Errorf("insufficient available memory")
+	}
+	return nil
+}
+
+// constraintFilter removes nodes that violate a hard placement constraint (anti-affinity & spread) of the
+// domain. Violated soft constraints are recorded on the placement. Spread topologies are built from all
+// eligible nodes (regardless of their available resources).
+type constraintFilter struct{}
+
+func (constraintFilter) name() string { return "placement constraint" }
+
+func (constraintFilter) filter(p *placement, nodeId string, _ *node.Node) error {
+	if p.topology == nil {
+		p.topology = map[string]*node.Node{}
+		for id, nod := range p.nodes {
+			if checkEligible(p, nod) == nil {
+				p.topology[id] = nod
+			}
+		}
+	}
+	violations, err := checkConstraints(p.domain, nodeId, p.domains, p.topology)
+	if err != nil {
+		return err
+	}
+	p.softViolations[nodeId] = violations
+	return nil
+}
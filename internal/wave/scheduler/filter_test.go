@@ -0,0 +1,80 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scheduler
+
+import (
+	"testing"
+
+	"cthul.io/cthul/pkg/api/wave/v1/domain"
+	"cthul.io/cthul/pkg/api/wave/v1/node"
+)
+
+func TestAvailableFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		availableCpu float64
+		availableMem int64
+		wantErr      string
+	}{
+		{"enough resources", 2.5, 2048, ""},
+		{"cpu equals usage", 2, 2048, "available cpu"},
+		{"insufficient memory", 4, 512, "available memory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dom := testDomain("", 4, 2048)
+			nod := testNode(8, tt.availableCpu, 8192, tt.availableMem, nil)
+
+			// the domain is assumed to consume 2 vcpus and 1024 bytes.
+			settings := testSettings()
+			settings.cpuUsageFactor, settings.memUsageFactor = 0.5, 0.5
+			p := newPlacement(dom, map[string]*domain.Domain{"d": dom}, map[string]*node.Node{"n": nod}, settings)
+			checkErr(t, availableFilter{}.filter(p, "n", nod), tt.wantErr)
+		})
+	}
+}
+
+func TestCommitFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		vcpus    int64
+		mem      int64
+		cpuRatio float64
+		wantErr  string
+	}{
+		{"fits exactly", 2, 2048, 1, ""},
+		{"exceeds cpu", 3, 1024, 1, "committed cpu"},
+		{"exceeds memory", 1, 3072, 1, "committed memory"},
+		{"overcommit ratio", 3, 1024, 1.5, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dom := testDomain("", tt.vcpus, tt.mem)
+			domains := map[string]*domain.Domain{"d": dom, "other": testDomain("n", 2, 2048)}
+			// the committed resources are independent of the currently available resources.
+			nod := testNode(4, 4, 4096, 4096, nil)
+
+			settings := testSettings()
+			settings.cpuCommitRatio = tt.cpuRatio
+			p := newPlacement(dom, domains, map[string]*node.Node{"n": nod}, settings)
+			checkErr(t, commitFilter{}.filter(p, "n", nod), tt.wantErr)
+		})
+	}
+}
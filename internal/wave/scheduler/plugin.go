@@ -0,0 +1,195 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scheduler
+
+import (
+	"fmt"
+
+	"cthul.io/cthul/pkg/api/wave/v1/domain"
+	"cthul.io/cthul/pkg/api/wave/v1/node"
+)
+
+type SCORE_PLUGIN string
+
+const (
+	// SCORE_SPREAD prefers nodes with the least allocated resources (distributes domains across the cluster).
+	SCORE_SPREAD SCORE_PLUGIN = "spread"
+	// SCORE_BINPACK prefers nodes with the most allocated resources (consolidates domains on few nodes).
+	SCORE_BINPACK SCORE_PLUGIN = "binpack"
+	// SCORE_BALANCED prefers nodes whose cpu and memory allocation stay balanced after the placement.
+	SCORE_BALANCED SCORE_PLUGIN = "balanced"
+	// SCORE_AFFINITY prefers nodes matching the preferred node affinity selectors of the domain.
+	SCORE_AFFINITY SCORE_PLUGIN = "affinity"
+)
+
+// placementSettings holds the scheduler settings applied to a placement.
+type placementSettings struct {
+	// cpuUsageFactor and memUsageFactor specify the share of its provisioned resources a domain is assumed
+	// to consume on the node it is placed on.
+	cpuUsageFactor float64
+	memUsageFactor float64
+	// cpuCommitRatio and memCommitRatio specify the share of the committable node resources
+	// that can be committed by domains (1 = the full committable capacity).
+	cpuCommitRatio float64
+	memCommitRatio float64
+	// scoreWeights specifies the weight of every score plugin used to rate the nodes.
+	scoreWeights map[SCORE_PLUGIN]float64
+}
+
+// placement holds the state of a single domain placement evaluation, it is shared by all plugins.
+type placement struct {
+	domain  *domain.Domain
+	domains map[string]*domain.Domain
+	nodes   map[string]*node.Node
+	placementSettings
+	// assumedCpu and assumedMem hold the resources the domain is assumed to consume on the node.
+	assumedCpu float64
+	assumedMem int64
+	// committedCpu and committedMem hold the resources of all domains requested on a node (by node id).
+	committedCpu map[string]float64
+	committedMem map[string]int64
+	// softViolations holds the number of violated soft constraints on a node (by node id).
+	softViolations map[string]int
+	// topology holds the nodes used to evaluate spread constraints (built by the constraint filter).
+	topology map[string]*node.Node
+}
+
+// newPlacement creates the placement state of the domain.
+func newPlacement(
+	dom *domain.Domain,
+	domains map[string]*domain.Domain,
+	nodes map[string]*node.Node,
+	settings placementSettings,
+) *placement {
+	assumedCpu, assumedMem := assumedUsage(dom, settings)
+	p := &placement{
+		domain:            dom,
+		domains:           domains,
+		nodes:             nodes,
+		placementSettings: settings,
+		assumedCpu:        assumedCpu,
+		assumedMem:        assumedMem,
+		committedCpu:      map[string]float64{},
+		committedMem:      map[string]int64{},
+		softViolations:    map[string]int{},
+		topology:          nil,
+	}
+	for _, other := range domains {
+		if other == dom {
+			continue
+		}
+		p.committedCpu[other.Reqnode] += float64(other.GetConfig().GetResourceConfig().GetVcpus())
+		p.committedMem[other.Reqnode] += other.GetConfig().GetResourceConfig().GetMemory()
+	}
+	return p
+}
+
+// assumedUsage returns the cpu and memory the domain is assumed to consume on a node.
+// The usage factors define how much of its provisioned resources a domain is assumed to consume.
+// this is a heuristic to "guess" how much cpu/mem the domain will actually consume on the cluster node.
+// defaulting to 100% is a pretty dumb idea because most domains don't use 100% of their provisioned capacity.
+func assumedUsage(dom *domain.Domain, settings placementSettings) (float64, int64) {
+	resources := dom.GetConfig().GetResourceConfig()
+	return float64(resources.GetVcpus()) * settings.cpuUsageFactor,
+		int64(float64(resources.GetMemory()) * settings.memUsageFactor)
+}
+
+// commitment returns the cpu and memory committed on the node if the domain is placed on it.
+func (p *placement) commitment(nodeId string) (float64, int64) {
+	cpu := p.committedCpu[nodeId] + float64(p.domain.GetConfig().GetResourceConfig().GetVcpus())
+	mem := p.committedMem[nodeId] + p.domain.GetConfig().GetResourceConfig().GetMemory()
+	return cpu, mem
+}
+
+// allocation returns the share of the committable cpu and memory (0 - 1) of the node
+// that is committed if the domain is placed on it.
+func (p *placement) allocation(nodeId string, nod *node.Node) (float64, float64) {
+	cpu, mem := p.commitment(nodeId)
+	return fraction(cpu, nod.Config.CommittableCpu), fraction(float64(mem), float64(nod.Config.CommittableMemory))
+}
+
+// fraction returns the share of used on capacity clamped to 0 - 1 (a node without capacity is fully allocated).
+func fraction(used, capacity float64) float64 {
+	if capacity <= 0 {
+		return 1
+	}
+	return min(max(used/capacity, 0), 1)
+}
+
+// filterPlugin removes nodes the domain cannot be placed on.
+type filterPlugin interface {
+	name() string
+	// filter returns an error if the domain cannot be placed on the node.
+	filter(p *placement, nodeId string, nod *node.Node) error
+}
+
+// scorePlugin rates the nodes that passed all filters.
+type scorePlugin interface {
+	// score rates the node with 0 (worst) - 100 (best) points.
+	score(p *placement, nodeId string, nod *node.Node) float64
+}
+
+// scorePlugins holds the built-in score plugins.
+var scorePlugins = map[SCORE_PLUGIN]scorePlugin{
+	SCORE_SPREAD:   spreadScore{},
+	SCORE_BINPACK:  binpackScore{},
+	SCORE_BALANCED: balancedScore{},
+	SCORE_AFFINITY: affinityScore{},
+}
+
+// runPipeline places the domain on the best node. The filters run in order, every filter only evaluates
+// the nodes that passed the previous filters. The remaining nodes are rated by the weighted score plugins,
+// nodes with less violated soft constraints are preferred regardless of their score.
+func runPipeline(p *placement, filters []filterPlugin) (string, error) {
+	candidates := p.nodes
+	for _, filter := range filters {
+		passed := map[string]*node.Node{}
+		var filterErr error
+		for nodeId, nod := range candidates {
+			if err := filter.filter(p, nodeId, nod); err != nil {
+				filterErr = err
+				continue
+			}
+			passed[nodeId] = nod
+		}
+		if len(passed) < 1 {
+			return "", fmt.Errorf("no cluster node passes the %s filter: %w", filter.name(), filterErr)
+		}
+		candidates = passed
+	}
+
+	chosenNodeId, chosenScore, chosenViolations := "", 0.0, 0
+	for nodeId, nod := range candidates {
+		score := 0.0
+		for plugin, weight := range p.scoreWeights {
+			if scorer, ok := scorePlugins[plugin]; ok && weight > 0 {
+				score += scorer.score(p, nodeId, nod) * weight
+			}
+		}
+
+		violations := p.softViolations[nodeId]
+		if chosenNodeId == "" || violations < chosenViolations {
+			chosenNodeId, chosenScore, chosenViolations = nodeId, score, violations
+		} else if violations == chosenViolations && chosenScore < score {
+			chosenNodeId, chosenScore, chosenViolations = nodeId, score, violations
+		}
+	}
+	return chosenNodeId, nil
+}
@@ -0,0 +1,175 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scheduler
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"cthul.io/cthul/pkg/api/wave/v1/domain"
+	"cthul.io/cthul/pkg/api/wave/v1/node"
+)
+
+// testNode creates a healthy node whose committable resources equal its allocated resources.
+func testNode(cpu, availableCpu float64, mem, availableMem int64, labels map[string]string) *node.Node {
+	return &node.Node{
+		Config: &node.NodeConfig{
+			State:             node.NodeState_NODE_STATE_HEALTHY,
+			AllocatedCpu:      cpu,
+			AvailableCpu:      availableCpu,
+			AllocatedMemory:   mem,
+			AvailableMemory:   availableMem,
+			CommittableCpu:    cpu,
+			CommittableMemory: mem,
+			Labels:            labels,
+		},
+	}
+}
+
+// testDomain creates a stopped domain requested on the node. The empty node affinity disables the
+// legacy affinity tags, so the domain is eligible on every healthy node.
+func testDomain(reqnode string, vcpus, mem int64) *domain.Domain {
+	return &domain.Domain{
+		Reqnode: reqnode,
+		Node:    reqnode,
+		Config: &domain.DomainConfig{
+			State:          domain.DomainState_DOMAIN_STATE_DOWN,
+			ResourceConfig: &domain.ResourceConfig{Vcpus: vcpus, Memory: mem},
+			NodeAffinity:   &domain.NodeAffinity{},
+		},
+	}
+}
+
+func testSettings() placementSettings {
+	return placementSettings{
+		cpuUsageFactor: 1,
+		memUsageFactor: 1,
+		cpuCommitRatio: 1,
+		memCommitRatio: 1,
+		scoreWeights:   map[SCORE_PLUGIN]float64{SCORE_SPREAD: 1},
+	}
+}
+
+func requirement(key string, operator domain.SelectorOperator, values ...string) *domain.SelectorRequirement {
+	return &domain.SelectorRequirement{Key: key, Operator: operator, Values: values}
+}
+
+func TestScorePlugins(t *testing.T) {
+	preferred := &domain.NodeAffinity{Preferred: []*domain.PreferredSelector{
+		{Weight: 3, Requirements: []*domain.SelectorRequirement{
+			requirement("zone", domain.SelectorOperator_SELECTOR_OPERATOR_IN, "a"),
+		}},
+		{Weight: 1, Requirements: []*domain.SelectorRequirement{
+			requirement("disk", domain.SelectorOperator_SELECTOR_OPERATOR_EXISTS),
+		}},
+	}}
+
+	// after the placement the node has 50% of its cpu and 100% of its memory committed.
+	tests := []struct {
+		name     string
+		plugin   SCORE_PLUGIN
+		affinity *domain.NodeAffinity
+		node     *node.Node
+		want     float64
+	}{
+		{"spread", SCORE_SPREAD, nil, testNode(4, 4, 4096, 4096, nil), 25},
+		{"binpack", SCORE_BINPACK, nil, testNode(4, 4, 4096, 4096, nil), 75},
+		{"balanced", SCORE_BALANCED, nil, testNode(4, 4, 4096, 4096, nil), 50},
+		{"spread without capacity", SCORE_SPREAD, nil, testNode(0, 0, 0, 0, nil), 0},
+		{"affinity partial match", SCORE_AFFINITY, preferred, testNode(4, 4, 4096, 4096, map[string]string{"zone": "a"}), 75},
+		{"affinity full match", SCORE_AFFINITY, preferred, testNode(4, 4, 4096, 4096, map[string]string{"zone": "a", "disk": "ssd"}), 100},
+		{"affinity without preference", SCORE_AFFINITY, &domain.NodeAffinity{}, testNode(4, 4, 4096, 4096, nil), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dom := testDomain("", 1, 1024)
+			dom.Config.NodeAffinity = tt.affinity
+			domains := map[string]*domain.Domain{"d": dom, "other": testDomain("n", 1, 3072)}
+
+			p := newPlacement(dom, domains, map[string]*node.Node{"n": tt.node}, testSettings())
+			if got := scorePlugins[tt.plugin].score(p, "n", tt.node); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("%s score = %v, want %v", tt.plugin, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunPipeline(t *testing.T) {
+	filters := []filterPlugin{eligibleFilter{}, commitFilter{}, availableFilter{}, constraintFilter{}}
+	tests := []struct {
+		name    string
+		weights map[SCORE_PLUGIN]float64
+		modify  func(*domain.Domain, map[string]*node.Node)
+		want    string
+		wantErr string
+	}{
+		{"spread prefers the idle node", map[SCORE_PLUGIN]float64{SCORE_SPREAD: 1}, nil, "idle", ""},
+		{"binpack prefers the busy node", map[SCORE_PLUGIN]float64{SCORE_BINPACK: 1}, nil, "busy", ""},
+		{"filters remove nodes", map[SCORE_PLUGIN]float64{SCORE_SPREAD: 1}, func(_ *domain.Domain, nodes map[string]*node.Node) {
+			nodes["idle"].Config.State = node.NodeState_NODE_STATE_MAINTENANCE
+		}, "busy", ""},
+		{"soft violations outweigh the score", map[SCORE_PLUGIN]float64{SCORE_SPREAD: 1}, func(d *domain.Domain, _ map[string]*node.Node) {
+			d.Config.AntiAffinity = []*domain.AntiAffinity{{Group: "db", Mode: domain.ConstraintMode_CONSTRAINT_MODE_SOFT}}
+		}, "busy", ""},
+		{"no node passes", map[SCORE_PLUGIN]float64{SCORE_SPREAD: 1}, func(d *domain.Domain, _ map[string]*node.Node) {
+			d.Config.ResourceConfig.Vcpus = 16
+		}, "", "no cluster node passes the committable resources filter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dom := testDomain("", 1, 1024)
+			other := testDomain("idle", 1, 1024)
+			other.Config.AntiAffinity = []*domain.AntiAffinity{{Group: "db", Mode: domain.ConstraintMode_CONSTRAINT_MODE_HARD}}
+			domains := map[string]*domain.Domain{
+				"d": dom, "other": other, "busy-1": testDomain("busy", 2, 2048), "busy-2": testDomain("busy", 2, 2048),
+			}
+			nodes := map[string]*node.Node{
+				"idle": testNode(8, 8, 8192, 8192, nil),
+				"busy": testNode(8, 4, 8192, 4096, nil),
+			}
+			if tt.modify != nil {
+				tt.modify(dom, nodes)
+			}
+
+			settings := testSettings()
+			settings.scoreWeights = tt.weights
+			got, err := runPipeline(newPlacement(dom, domains, nodes, settings), filters)
+			checkErr(t, err, tt.wantErr)
+			if got != tt.want {
+				t.Errorf("runPipeline() = '%s', want '%s'", got, tt.want)
+			}
+		})
+	}
+}
+
+// checkErr fails the test if the error does not contain wantErr ("" = no error expected).
+func checkErr(t *testing.T, err error, wantErr string) {
+	t.Helper()
+	if wantErr == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if err == nil || !strings.Contains(err.Error(), wantErr) {
+		t.Fatalf("error = %v, want error containing '%s'", err, wantErr)
+	}
+}
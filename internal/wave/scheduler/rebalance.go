@@ -0,0 +1,265 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scheduler
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"cthul.io/cthul/pkg/api/wave/v1/domain"
+	"cthul.io/cthul/pkg/api/wave/v1/node"
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/keyspace"
+)
+
+// rebalanceSettings holds the settings of the rebalancer.
+type rebalanceSettings struct {
+	// cycleTTL specifies the interval for rebalancer cycles.
+	cycleTTL int64
+	// hotThreshold specifies the node load (0 - 1) above which domains are moved away from the node.
+	hotThreshold float64
+	// skewThreshold specifies how much the node load (0 - 1) can exceed the average cluster load
+	// before domains are moved away from the node.
+	skewThreshold float64
+	// maxMoves specifies the number of domains moved per cycle.
+	maxMoves int64
+	// cooldown specifies the time (seconds) before a moved domain can be moved again.
+	cooldown int64
+	// moveRunning specifies whether running domains are moved (they are restarted on the target node).
+	moveRunning bool
+	// dryRun specifies whether the planned moves are only logged.
+	dryRun bool
+}
+
+// startRebalanceCycle starts the rebalancer cycle. Every cycle evaluates the load of the healthy nodes, nodes that
+// are hot (load above the hot threshold) or unevenly loaded (load above the average load + skew threshold) are
+// relieved by moving domains to other nodes. Domains are moved via reqnode (see domain.Controller.Attach()),
+// this is a cold move: the domain is destroyed on the source node and recreated on the target node.
+// The adapters do not support live migration, therefore running domains are only moved if explicitly allowed.
+// The ctx can be cancelled to stop the rebalancer, this will stop the rebalancer AFTER the current cycle.
+func (s *Scheduler) startRebalanceCycle(ctx context.Context) {
+	for {
+		settings := s.rebalanceSettings()
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.workCtx.Done():
+			return
+		case <-time.After(time.Second * time.Duration(settings.cycleTTL)):
+			break
+		}
+
+		if s.fence != nil {
+			if _, ok := s.fence(); !ok {
+				s.logger.Debug("rebalancer leader term ended; waiting for next cycle...")
+				continue
+			}
+		}
+
+		if err := s.rebalanceCycle(settings); err != nil {
+			s.logger.Error(fmt.Sprintf("rebalancer cycle failed: %s; waiting for next cycle...", err.Error()))
+		}
+	}
+}
+
+// rebalanceCycle executes a single rebalancer cycle. Source nodes are relieved starting with the highest load,
+// candidate domains are picked by their move priority (see movePriority()) and placed with the placement
+// pipeline. A move is only executed if it lowers the load of the source node without making the target
+// node the new hotspot.
+func (s *Scheduler) rebalanceCycle(settings rebalanceSettings) error {
+	domains, err := s.domainController.List(s.workCtx)
+	if err != nil {
+		return fmt.Errorf("failed to load domains: %w", err)
+	}
+	nodes, err := s.nodeController.List(s.workCtx)
+	if err != nil {
+		return fmt.Errorf("failed to load nodes: %w", err)
+	}
+	cooldowns, err := s.client.GetRange(s.workCtx, keyspace.WaveRebalanceCooldown.Prefix())
+	if err != nil {
+		return fmt.Errorf("failed to load domain cooldowns: %w", err)
+	}
+
+	loads := map[string]float64{}
+	totalLoad := 0.0
+	for nodeId, nod := range nodes {
+		if nod.Error != "" || nod.Config.State != node.NodeState_NODE_STATE_HEALTHY {
+			continue
+		}
+		loads[nodeId] = nodeLoad(nod, 0, 0)
+		totalLoad += loads[nodeId]
+	}
+	if len(loads) < 2 {
+		return nil
+	}
+	averageLoad := totalLoad / float64(len(loads))
+	overloaded := func(load float64) bool {
+		return load > settings.hotThreshold || load > averageLoad+settings.skewThreshold
+	}
+
+	// nodes with pending moves (domains that are not yet moved to or away from the node) are not relieved,
+	// their measured load does not reflect the pending moves yet.
+	pending := map[string]bool{}
+	for _, dom := range domains {
+		if dom.Node != dom.Reqnode {
+			pending[dom.Node], pending[dom.Reqnode] = true, true
+		}
+	}
+
+	sources := []string{}
+	for nodeId, load := range loads {
+		if overloaded(load) && !pending[nodeId] {
+			sources = append(sources, nodeId)
+		}
+	}
+	slices.SortFunc(sources, func(a, b string) int {
+		return cmp.Compare(loads[b], loads[a])
+	})
+
+	placement := s.placementSettings()
+	moves := int64(0)
+	for _, sourceId := range sources {
+		source := nodes[sourceId]
+		candidates := []string{}
+		priorities := map[string]float64{}
+		for domainId, dom := range domains {
+			if dom.Error != "" || dom.Reqnode != sourceId || dom.Node != dom.Reqnode {
+				continue
+			}
+			if _, ok := cooldowns[keyspace.WaveRebalanceCooldown.Key(domainId)]; ok {
+				continue
+			}
+			if isRunning(dom) && !settings.moveRunning {
+				continue
+			}
+			candidates = append(candidates, domainId)
+			priorities[domainId] = movePriority(dom, source, placement)
+		}
+		slices.SortFunc(candidates, func(a, b string) int {
+			return cmp.Compare(priorities[b], priorities[a])
+		})
+
+		for _, domainId := range candidates {
+			if moves >= settings.maxMoves {
+				return nil
+			}
+			if !overloaded(nodeLoad(source, 0, 0)) {
+				break
+			}
+			dom := domains[domainId]
+			assumedCpu, assumedMem := assumedUsage(dom, placement)
+			targetId, _, err := s.findNode(dom, domains, nodes, rebalanceFilter{
+				sourceId:   sourceId,
+				sourceLoad: nodeLoad(source, -assumedCpu, -assumedMem),
+			})
+			if err != nil {
+				s.logger.Debug(fmt.Sprintf("skipping rebalance of '%s': %s", domainId, err.Error()))
+				continue
+			}
+
+			if settings.dryRun {
+				s.logger.Info(fmt.Sprintf("dry-run: would move '%s' from '%s' to '%s'", domainId, sourceId, targetId))
+			} else {
+				// the cooldown is written together with the request, so that a move is never recorded without
+				// cooldown and a deposed leader cannot set cooldowns (the fence applies to both).
+				operations := []db.Operation{}
+				if settings.cooldown > 0 {
+					operations = append(operations, db.Operation{
+						Type:  db.OPERATION_SET,
+						Key:   keyspace.WaveRebalanceCooldown.Key(domainId),
+						Value: serializeTime(time.Now()),
+						TTL:   settings.cooldown,
+					})
+				}
+				if err := s.attach(domainId, targetId, operations...); err != nil {
+					// findNode already accounted the domain on the target node, the move did not happen.
+					target := nodes[targetId]
+					target.Config.AvailableCpu += assumedCpu
+					target.Config.AvailableMemory += assumedMem
+					s.logger.Error(fmt.Sprintf("failed to move '%s': %s", domainId, err.Error()))
+					continue
+				}
+				s.logger.Info(fmt.Sprintf("moved '%s' from '%s' to '%s'", domainId, sourceId, targetId))
+			}
+
+			// the move is accounted in the cycle state, so subsequent moves are evaluated on the new state.
+			source.Config.AvailableCpu += assumedCpu
+			source.Config.AvailableMemory += assumedMem
+			dom.Reqnode = targetId
+			moves++
+		}
+	}
+	return nil
+}
+
+// rebalanceFilter removes the source node and nodes that would end up with a load equal or higher than the
+// relieved source node. This prevents moves that just shift the hotspot to another node.
+type rebalanceFilter struct {
+	sourceId   string
+	sourceLoad float64
+}
+
+func (rebalanceFilter) name() string { return "rebalance" }
+
+func (f rebalanceFilter) filter(p *placement, nodeId string, nod *node.Node) error {
+	if nodeId == f.sourceId {
+		return fmt.Errorf("node is the source node")
+	}
+	if nodeLoad(nod, p.assumedCpu, p.assumedMem) >= f.sourceLoad {
+		return fmt.Errorf("moving the domain does not reduce the load imbalance")
+	}
+	return nil
+}
+
+// nodeLoad returns the load of the node (0 - 1) if the additional cpu and memory is consumed on it.
+// The load is the higher share of the used cpu and memory.
+func nodeLoad(nod *node.Node, cpu float64, mem int64) float64 {
+	cpuUsed := nod.Config.AllocatedCpu - nod.Config.AvailableCpu + cpu
+	memUsed := nod.Config.AllocatedMemory - nod.Config.AvailableMemory + mem
+	return max(
+		fraction(cpuUsed, nod.Config.AllocatedCpu), fraction(float64(memUsed), float64(nod.Config.AllocatedMemory)),
+	)
+}
+
+// movePriority rates the domain as move candidate by relief / cost. The relief is the load the domain is
+// assumed to take from the source node, the cost estimates the disruption caused by the move.
+// Stopped domains are cheap to move, running domains are restarted (cold move), which gets more expensive
+// with the memory size of the domain (more state is lost and must be rebuilt).
+func movePriority(dom *domain.Domain, source *node.Node, settings placementSettings) float64 {
+	assumedCpu, assumedMem := assumedUsage(dom, settings)
+	relief := max(fraction(assumedCpu, source.Config.AllocatedCpu),
+		fraction(float64(assumedMem), float64(source.Config.AllocatedMemory)))
+
+	cost := 1.0
+	if isRunning(dom) {
+		const GIB = 1 << 30
+		cost += 1 + float64(dom.GetConfig().GetResourceConfig().GetMemory())/GIB
+	}
+	return relief / cost
+}
+
+// isRunning checks if the domain is requested to run (or is paused with its state in memory).
+func isRunning(dom *domain.Domain) bool {
+	state := dom.GetConfig().GetState()
+	return state == domain.DomainState_DOMAIN_STATE_UP || state == domain.DomainState_DOMAIN_STATE_PAUSE
+}
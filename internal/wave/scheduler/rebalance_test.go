@@ -0,0 +1,205 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"cthul.io/cthul/pkg/api/wave/v1/domain"
+	"cthul.io/cthul/pkg/api/wave/v1/node"
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/db/memdb"
+	"cthul.io/cthul/pkg/keyspace"
+	domctrl "cthul.io/cthul/pkg/wave/domain"
+	nodectrl "cthul.io/cthul/pkg/wave/node"
+)
+
+func TestRebalanceCycle(t *testing.T) {
+	const GIB = 1 << 30
+	rejectingFence := func() (db.Condition, bool) {
+		return db.Condition{Key: "/fence", Compare: db.COMPARE_EQUAL, Revision: 42}, true
+	}
+
+	tests := []struct {
+		name          string
+		opts          []Option
+		cooldowns     []string
+		wantReqnodes  map[string]string
+		wantCooldowns []string
+	}{
+		{
+			name:          "moves the cheapest domain off the hot node",
+			wantReqnodes:  map[string]string{"stopped": "cold", "running": "hot"},
+			wantCooldowns: []string{"stopped"},
+		},
+		{
+			name:          "dry-run only plans the move",
+			opts:          []Option{WithRebalanceDryRun(true)},
+			wantReqnodes:  map[string]string{"stopped": "hot", "running": "hot"},
+			wantCooldowns: []string{},
+		},
+		{
+			name:          "skips domains in cooldown and running domains",
+			cooldowns:     []string{"stopped"},
+			wantReqnodes:  map[string]string{"stopped": "hot", "running": "hot"},
+			wantCooldowns: []string{"stopped"},
+		},
+		{
+			name:          "moves running domains if allowed",
+			opts:          []Option{WithRebalanceRunning(true)},
+			cooldowns:     []string{"stopped"},
+			wantReqnodes:  map[string]string{"stopped": "hot", "running": "cold"},
+			wantCooldowns: []string{"stopped", "running"},
+		},
+		{
+			name:          "moves multiple domains up to the limit",
+			opts:          []Option{WithRebalanceRunning(true), WithRebalanceLimits(2, 600)},
+			wantReqnodes:  map[string]string{"stopped": "cold", "running": "cold"},
+			wantCooldowns: []string{"stopped", "running"},
+		},
+		{
+			name:          "rejected fence writes neither request nor cooldown",
+			opts:          []Option{WithFence(rejectingFence)},
+			wantReqnodes:  map[string]string{"stopped": "hot", "running": "hot"},
+			wantCooldowns: []string{},
+		},
+		{
+			name:          "leaves nodes below the thresholds",
+			opts:          []Option{WithRebalanceThresholds(95, 90)},
+			wantReqnodes:  map[string]string{"stopped": "hot", "running": "hot"},
+			wantCooldowns: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := memdb.New()
+			defer client.Terminate(ctx)
+
+			domainController := domctrl.New("hot", client, nil)
+			nodeController := nodectrl.New("hot", client)
+
+			lease, err := client.GrantLease(ctx, 60)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the hot node has a load of 90%, the cold node of 12.5%.
+			nodes := map[string]struct {
+				availableCpu float64
+				availableMem int64
+			}{
+				"hot":  {0.8, 8 * GIB},
+				"cold": {7, 14 * GIB},
+			}
+			for nodeId, resources := range nodes {
+				nod := testNode(8, resources.availableCpu, 16*GIB, resources.availableMem, nil)
+				if err := nodeController.Register(ctx, nodeId, nod, lease); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			states := map[string]domain.DomainState{
+				"stopped": domain.DomainState_DOMAIN_STATE_DOWN,
+				"running": domain.DomainState_DOMAIN_STATE_UP,
+			}
+			for domainId, state := range states {
+				dom := testDomain("hot", 2, 2*GIB)
+				dom.Config.State = state
+				if err := domainController.Create(ctx, domainId, dom.Config); err != nil {
+					t.Fatal(err)
+				}
+				mustSet(t, client, keyspace.WaveDomain.Reqnode.Key(domainId), "hot")
+				mustSet(t, client, keyspace.WaveDomain.Node.Key(domainId), "hot")
+			}
+			for _, domainId := range tt.cooldowns {
+				mustSet(t, client, keyspace.WaveRebalanceCooldown.Key(domainId), serializeTime(time.Now()))
+			}
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			scheduler := New(logger, client, domainController, nodeController, tt.opts...)
+			defer scheduler.Terminate(ctx)
+
+			if err := scheduler.rebalanceCycle(scheduler.rebalanceSettings()); err != nil {
+				t.Fatalf("rebalance cycle failed: %v", err)
+			}
+
+			for domainId, want := range tt.wantReqnodes {
+				got, err := client.Get(ctx, keyspace.WaveDomain.Reqnode.Key(domainId))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("reqnode of '%s' = '%s', want '%s'", domainId, got, want)
+				}
+			}
+
+			cooldowns, err := client.GetRange(ctx, keyspace.WaveRebalanceCooldown.Prefix())
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotCooldowns := []string{}
+			for key := range cooldowns {
+				gotCooldowns = append(gotCooldowns, keyspace.WaveRebalanceCooldown.Id(key))
+			}
+			slices.Sort(gotCooldowns)
+			wantCooldowns := slices.Sorted(slices.Values(tt.wantCooldowns))
+			if !slices.Equal(gotCooldowns, wantCooldowns) {
+				t.Errorf("cooldowns = %v, want %v", gotCooldowns, wantCooldowns)
+			}
+		})
+	}
+}
+
+func TestRebalanceFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		nodeId     string
+		sourceLoad float64
+		wantErr    string
+	}{
+		{"source node", "source", 1, "source node"},
+		{"reduces the imbalance", "target", 0.8, ""},
+		{"shifts the hotspot", "target", 0.5, "does not reduce"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dom := testDomain("source", 2, 1024)
+			// the target node has a load of 50% once the domain is placed on it.
+			target := testNode(4, 3, 4096, 4096, nil)
+			nodes := map[string]*node.Node{"source": testNode(4, 0, 4096, 0, nil), "target": target}
+
+			p := newPlacement(dom, map[string]*domain.Domain{"d": dom}, nodes, testSettings())
+			filter := rebalanceFilter{sourceId: "source", sourceLoad: tt.sourceLoad}
+			checkErr(t, filter.filter(p, tt.nodeId, nodes[tt.nodeId]), tt.wantErr)
+		})
+	}
+}
+
+func mustSet(t *testing.T, client db.Client, key, value string) {
+	t.Helper()
+	if _, err := client.Set(context.Background(), key, value, 0); err != nil {
+		t.Fatal(err)
+	}
+}
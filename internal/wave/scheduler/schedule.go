@@ -28,12 +28,14 @@ import (
 
   "cthul.io/cthul/pkg/api/wave/v1/domain"
   "cthul.io/cthul/pkg/api/wave/v1/node"
+  "cthul.io/cthul/pkg/db"
+  "cthul.io/cthul/pkg/keyspace"
 )
 
 // startSchedulerCycle starts a scheduler cycle. This cycle executes periodically based on the next schedule stored
 // in the database. When starting a cycle, the scheduler updates this schedule with the current time + cycleTTL,
-// if multiple schedulers run at the same time (usually not the case) the first one updating the schedule key
-// will executes the cycle, all others wait till the next cycle.
+// if multiple schedulers run at the same time (usually not the case) only the scheduler that successfully swaps
+// the schedule key (compare-and-swap on the key revision) executes the cycle, all others wait till the next cycle.
 // One cycle captures all domains that use nodes that are not registered in the scheduler. If those domains
 // are captured in the subsequent request as well, the scheduler assigns them to one of the active nodes
 // based on their current capacity.
@@ -43,7 +45,7 @@ func (s *Scheduler) startSchedulerCycle(schedulerCtx context.Context) {
 	// it is used to avoid immediate rescheduling of unmanagedDomains.
 	unmanagedDomains := map[string]int{}
 	
-	next, err := s.client.Get(schedulerCtx, "/WAVE/SCHEDULER/NEXT")
+	next, err := s.client.Get(schedulerCtx, keyspace.WaveSchedulerNext)
 	if err!=nil {
 		s.logger.Error("failed to fetch next scheduler cycle initially; initiating schedule...")
 	}
@@ -59,16 +61,40 @@ func (s *Scheduler) startSchedulerCycle(schedulerCtx context.Context) {
 			break
 		}
 
-		nextSchedule = time.Now().Add(time.Second * time.Duration(s.cycleTTL))
-		prevNext, err := s.client.Set(s.workCtx, "/WAVE/SCHEDULER/NEXT",
-			serializeTime(nextSchedule), 0,
-		)
+		cycleTTL, rescheduleCycles := s.cycles()
+		prevNext, prevRevision, err := s.client.GetRevision(s.workCtx, keyspace.WaveSchedulerNext)
 		if err!=nil {
-			s.logger.Error("failed to update scheduler cycle; waiting for next cycle...")
+			s.logger.Error("failed to fetch scheduler cycle; waiting for next cycle...")
+			nextSchedule = time.Now().Add(time.Second * time.Duration(cycleTTL))
 			continue
 		}
 		if parseTime(prevNext).After(time.Now()) {
-			s.logger.Debug("scheduler possibly double contested; waiting for next cycle...")
+			s.logger.Debug("scheduler cycle already claimed; waiting for next cycle...")
+			nextSchedule = parseTime(prevNext)
+			continue
+		}
+
+		nextSchedule = time.Now().Add(time.Second * time.Duration(cycleTTL))
+		conditions := []db.Condition{{
+			Key: keyspace.WaveSchedulerNext, Compare: db.COMPARE_EQUAL, Revision: prevRevision,
+		}}
+		if s.fence != nil {
+			fence, ok := s.fence()
+			if !ok {
+				s.logger.Debug("scheduler leader term ended; waiting for next cycle...")
+				continue
+			}
+			conditions = append(conditions, fence)
+		}
+		claimed, err := s.client.Txn(s.workCtx, conditions, []db.Operation{{
+			Type: db.OPERATION_SET, Key: keyspace.WaveSchedulerNext, Value: serializeTime(nextSchedule),
+		}})
+		if err!=nil {
+			s.logger.Error("failed to update scheduler cycle; waiting for next cycle...")
+			continue
+		}
+		if !claimed {
+			s.logger.Debug("scheduler double contested; waiting for next cycle...")
 			continue
 		}
 
@@ -100,7 +126,7 @@ func (s *Scheduler) startSchedulerCycle(schedulerCtx context.Context) {
 				unmanagedDomains[domainId] = 0
 			}
 
-			if unmanagedDomains[domainId] < int(s.rescheduleCycles) {
+			if unmanagedDomains[domainId] < int(rescheduleCycles) {
 				continue
 			}
 			
@@ -113,7 +139,7 @@ func (s *Scheduler) startSchedulerCycle(schedulerCtx context.Context) {
 			}
 			domain.Node = targetNodeId
 
-			err = s.domainController.Attach(s.workCtx, domainId, domain.Node, false)
+			err = s.attach(domainId, domain.Node)
 			if err!=nil {
 				s.logger.Error(fmt.Sprintf(
 					"failed to reschedule '%s': %s", domainId, err.Error(),
@@ -128,97 +154,39 @@ func (s *Scheduler) startSchedulerCycle(schedulerCtx context.Context) {
 	}
 }
 
-// findNode evaluates the optimal node to move the domain to. Returns the new target node id and its associated
-// node information. The assumed resource impact of the new domain is already factored in.
+// attach requests the domain on the target node. If the scheduler is fenced, the request is only written
+// as long as the leader term of the local node is active. Additional operations are written in the same
+// transaction as the request (and are therefore fenced too).
+func (s *Scheduler) attach(domainId, nodeId string, operations ...db.Operation) error {
+	if s.fence == nil {
+		return s.domainController.AttachTxn(s.workCtx, domainId, nodeId, nil, operations)
+	}
+	fence, ok := s.fence()
+	if !ok {
+		return fmt.Errorf("scheduler leader term ended")
+	}
+	return s.domainController.AttachTxn(s.workCtx, domainId, nodeId, []db.Condition{fence}, operations)
+}
+
+// findNode evaluates the optimal node to move the domain to by running the placement pipeline (filter & score
+// plugins). Additional filters are evaluated after the filters of the scheduler.
+// Returns the new target node id and its associated node information.
+// The assumed resource impact of the new domain is already factored in.
 func (s *Scheduler) findNode(
 	domain *domain.Domain,
 	domains map[string]*domain.Domain,
 	nodes map[string]*node.Node,
+	filters ...filterPlugin,
 ) (string, *node.Node, error) {
-
-	eligibleNodes := map[string]*node.Node{}
-	for id, nod := range nodes {
-		if nod.Error != "" {
-			continue
-		}
-    if nod.Config.State != node.NodeState_NODE_STATE_HEALTHY {
-			continue
-		}
-		if !checkAffinity(domain.Config.Affinity, nod.Config.Affinity) {
-			continue
-		}
-		eligibleNodes[id] = nod
-	}
-	if len(eligibleNodes) < 1 {
-		return "", nil, fmt.Errorf("no healthy cluster node with matching affinity tags available")
-	}
-	
-	// constants define the usage factor that a domain is assumed to consume.
-	// this is a heuristic to "guess" how much cpu/mem the domain will actually consume on the cluster node.
-	// defaulting to 100% is a pretty dumb idea because most domains don't use 100% of their provisioned capacity.
-	const DOMAIN_CPU_USAGE_FACTOR_HEURISTIC = 0.3
-	const DOMAIN_MEM_USAGE_FACTOR_HEURISTIC = 0.6
-	
-	assumedCpuUsage := float64(domain.Config.ResourceConfig.Vcpus) * DOMAIN_CPU_USAGE_FACTOR_HEURISTIC
-	assumedMemUsage := int64(float64(domain.Config.ResourceConfig.Memory) * DOMAIN_MEM_USAGE_FACTOR_HEURISTIC)
-
-	// filter out nodes that currently do not provide enough available resources.
-	// This is done to prevent moving a domain to a node that has currently not sufficient capacity.
-	// For example if a high load cluster node failsover, instead of moving all domains at once to another
-	// available node, every cycle just moves the amount of nodes the currently fit within the current capacity.
-	availableNodes := map[string]*node.Node{}
-	for nodeId, node := range nodes {
-		if node.Config.AvailableCpu > assumedCpuUsage && node.Config.AvailableMemory > assumedMemUsage {
-			availableNodes[nodeId] = node
-		}
-	}
-	if len(availableNodes) < 1 {
-		return "", nil, fmt.Errorf("no cluster node provides sufficient available resources for this domain")
-	}
-
-	// constants define what factor is applied to the resources to normalize them as rating points.
-	// this defines the relation between cpu cores and memory bytes which is set to 1 core = 1 gb = 1 point
-	const (
-		CPU_POINT_FACTOR = 1.0
-		MEM_POINT_FACTOR = 0.000000001
-	)
-
-	// variables define a weight for cpu/mem that is multiplied with the cpu/mem points.
-	// This influences the algorithm to weight either cpu or mem stronger, therefore it is set to the resource
-	// requirements of the domain (e.g. if domain has 8 cores and 2GB memory, the cpu weight is 8 and the mem 2)
-	var (
-		CPU_POINT_WEIGHT = float64(domain.Config.ResourceConfig.Vcpus) * CPU_POINT_FACTOR
-		MEM_POINT_WEIGHT = float64(domain.Config.ResourceConfig.Memory) * MEM_POINT_FACTOR
-	)
-
-	// search the node with the highest rating, rating points are calculated based on a simple formula:
-	// ((nodeTotalCpu - nodeAllocatedCpu) * CPU_WEIGHT) + ((nodeTotalMem - nodeAllocatedMem) * MEM_WEIGHT)
-	// This finds the node that best fits the capacity of the domain in question.
-	chosenNodeId, chosenRating := "", 0.0
-	for nodeId, node := range availableNodes {
-		availableCpu := node.Config.AllocatedCpu
-		availableMem := node.Config.AllocatedMemory
-		for _, dom := range domains {
-			if dom.Reqnode == nodeId {
-				availableCpu -= float64(dom.Config.ResourceConfig.Vcpus)
-				availableMem -= dom.Config.ResourceConfig.Memory
-			}
-		}
-
-		cpuRating := availableCpu * CPU_POINT_FACTOR * CPU_POINT_WEIGHT
-		memRating := float64(availableMem) * MEM_POINT_FACTOR * MEM_POINT_WEIGHT
-		nodeRating := cpuRating + memRating
-		
-		if chosenNodeId == "" {
-			chosenNodeId, chosenRating = nodeId, nodeRating
-		} else if chosenRating < nodeRating {
-			chosenNodeId, chosenRating = nodeId, nodeRating
-		}
+	placement := newPlacement(domain, domains, nodes, s.placementSettings())
+	chosenNodeId, err := runPipeline(placement, append(slices.Clip(s.filters), filters...))
+	if err!=nil {
+		return "", nil, err
 	}
 
-	chosenNode := availableNodes[chosenNodeId]
-	chosenNode.Config.AvailableCpu -= assumedCpuUsage
-	chosenNode.Config.AvailableMemory -= assumedMemUsage
+	chosenNode := nodes[chosenNodeId]
+	chosenNode.Config.AvailableCpu -= placement.assumedCpu
+	chosenNode.Config.AvailableMemory -= placement.assumedMem
 	return chosenNodeId, chosenNode, nil
 }
 
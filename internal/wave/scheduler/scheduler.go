@@ -30,8 +30,9 @@ import (
 )
 
 // Scheduler provides a component responsible for advertising the local node and its resources to the cluster.
-// If the scheduler is set as the leader scheduler, it indexes the advertised nodes and moves unmanaged domains
+// While the scheduler leads (see Lead()), it indexes the advertised nodes and moves unmanaged domains
 // (domains located on nodes that are NOT advertised) to advertised nodes based on available resources.
+// While the rebalancer runs (see Rebalance()), domains are moved from hot or unevenly loaded nodes to other nodes.
 type Scheduler struct {
 	// root context runs until the scheduler is fully terminated.
 	rootCtx       context.Context
@@ -45,20 +46,30 @@ type Scheduler struct {
 	// finChan is used to send the absolute exist signal
 	// if the channel emits, this indicates that the operator is fully cleaned up.
 	finChan chan struct{}
+	// leadWg tracks the running leader cycles, leadLock ensures no cycle is added after termination started.
+	leadLock sync.Mutex
+	leadWg   sync.WaitGroup
 
 	client db.Client
   logger *slog.Logger
 	domainController *domain.Controller
 	nodeController *node.Controller
 
-	// leaderStateChan is used to emit the state of the leader.
-	leaderStateChan chan bool
-
+	// configLock protects the settings that can be reconfigured while the scheduler runs.
+	configLock sync.RWMutex
 	// cycleTTL specifies the interval for scheduler cycles.
 	cycleTTL int64
 	// rescheduleCycles specifies the number of cycles that a domain must be unmanaged
 	// in a row until it is rescheduled.
 	rescheduleCycles int64
+	// placement specifies the settings applied to domain placements (usage factors, commit ratios & weights).
+	placement placementSettings
+	// rebalance specifies the settings of the rebalancer (see Rebalance()).
+	rebalance rebalanceSettings
+	// filters holds the filter plugins a node must pass to host a domain (evaluated in order).
+	filters []filterPlugin
+	// fence returns the fencing condition of the current leader term (nil = scheduler writes are not fenced).
+	fence func() (db.Condition, bool)
 }
 
 type Option func(*Scheduler)
@@ -73,13 +84,37 @@ func New(logger *slog.Logger, client db.Client, domain *domain.Controller, node
 		workCtx:         workCtx,
 		workCtxCancel:   workCtxCancel,
 		finChan:         make(chan struct{}),
+		leadLock:        sync.Mutex{},
+		leadWg:          sync.WaitGroup{},
 		client:          client,
     logger: logger.WithGroup("scheduler"),
 		domainController: domain,
 		nodeController: node,
-		leaderStateChan: make(chan bool),
+		configLock: sync.RWMutex{},
 		cycleTTL: 5,
 		rescheduleCycles: 2,
+		placement: placementSettings{
+			cpuUsageFactor: 0.3,
+			memUsageFactor: 0.6,
+			cpuCommitRatio: 1,
+			memCommitRatio: 1,
+			scoreWeights: map[SCORE_PLUGIN]float64{
+				SCORE_SPREAD:   1,
+				SCORE_BALANCED: 1,
+				SCORE_AFFINITY: 2,
+			},
+		},
+		rebalance: rebalanceSettings{
+			cycleTTL:      60,
+			hotThreshold:  0.8,
+			skewThreshold: 0.2,
+			maxMoves:      1,
+			cooldown:      600,
+			moveRunning:   false,
+			dryRun:        false,
+		},
+		filters: []filterPlugin{eligibleFilter{}, commitFilter{}, availableFilter{}, constraintFilter{}},
+		fence: nil,
 	}
 
 	for _, opt := range opts {
@@ -105,65 +140,220 @@ func WithRescheduleCycles(cycles int64) Option {
 	}
 }
 
+// WithUsageFactors sets the share of its provisioned cpu/memory a domain is assumed to consume (0 - 1).
+// Nodes must currently provide the assumed resources to be considered for a placement.
+func WithUsageFactors(cpu, mem float64) Option {
+	return func(s *Scheduler) {
+		s.placement.cpuUsageFactor = cpu
+		s.placement.memUsageFactor = mem
+	}
+}
 
-// SetLeaderState changes the state of the schedule leader. Local set to true enables the scheduler leader mode.
-// Setting it to false disables the scheduler leader mode gracefully. This operation is idempotent.
-// Currently the leader id (first param) is unused, it is in place for future expansion.
-func (s *Scheduler) SetLeaderState(_ string, local bool) {
-	select {
-	case <-s.workCtx.Done():
-	case s.leaderStateChan <- local:
+// WithCommitThresholds sets the share of the committable node cpu/memory (in percent) that can be committed
+// by domains. Nodes report their committable capacity including reserved capacity and overcommit ratios,
+// the thresholds limit (< 100) or extend (> 100) it cluster wide. Defaults to 100.
+func WithCommitThresholds(cpu, mem int64) Option {
+	return func(s *Scheduler) {
+		s.placement.cpuCommitRatio = float64(cpu) / 100
+		s.placement.memCommitRatio = float64(mem) / 100
 	}
 }
 
-// ServeAndDetach starts the scheduler registration and the leader scheduler in a detached goroutine.
-func (s *Scheduler) ServeAndDetach() {
-	wg := sync.WaitGroup{}
+// WithScoreWeights sets custom weights for the score plugins. Plugins without weight (or weight 0) are disabled.
+// Defaults to spread (1), balanced (1) and affinity (2).
+func WithScoreWeights(weights map[SCORE_PLUGIN]float64) Option {
+	return func(s *Scheduler) {
+		s.placement.scoreWeights = weights
+	}
+}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		for {
-			select {
-			case <-s.workCtx.Done():
-				return
-			case leaderState := <-s.leaderStateChan:
-				if leaderState {
-					s.runSchedulerCycle()
-				}
-			}
-		}
-	}()
+// WithRebalanceCycleTTL defines a custom rebalancer cycle interval.
+func WithRebalanceCycleTTL(ttl int64) Option {
+	return func(s *Scheduler) {
+		s.rebalance.cycleTTL = ttl
+	}
+}
 
-	go func() {
-		wg.Wait()
-		s.finChan <- struct{}{}
-	}()
+// WithRebalanceThresholds sets the load (in percent) that triggers the rebalancer. Nodes are rebalanced if their
+// load exceeds the hot threshold or if it exceeds the average cluster load by more than the skew threshold.
+// Defaults to 80 (hot) and 20 (skew).
+func WithRebalanceThresholds(hot, skew int64) Option {
+	return func(s *Scheduler) {
+		s.rebalance.hotThreshold = float64(hot) / 100
+		s.rebalance.skewThreshold = float64(skew) / 100
+	}
 }
 
-// runSchedulerCycle starts a scheduler leader cycle and blocks until the leaderStateChan emits false.
-func (s *Scheduler) runSchedulerCycle() {
-	cycleCtx, cycleCtxCancel := context.WithCancel(s.workCtx)
-	defer cycleCtxCancel()
-	go s.startSchedulerCycle(cycleCtx)
-	for {
-		select {
-		case <-cycleCtx.Done():
-			return
-		case leaderState := <-s.leaderStateChan:
-			if !leaderState {
-				return
-			}
-		}
+// WithRebalanceLimits sets the maximum number of domains moved per rebalancer cycle and the cooldown (seconds)
+// before a moved domain can be moved again. Defaults to 1 move and 600 seconds.
+func WithRebalanceLimits(moves, cooldown int64) Option {
+	return func(s *Scheduler) {
+		s.rebalance.maxMoves = moves
+		s.rebalance.cooldown = cooldown
 	}
 }
 
+// WithRebalanceRunning allows the rebalancer to move running domains. Domains are moved cold, therefore
+// running domains are restarted on the target node.
+func WithRebalanceRunning(allowed bool) Option {
+	return func(s *Scheduler) {
+		s.rebalance.moveRunning = allowed
+	}
+}
+
+// WithRebalanceDryRun enables the rebalancer dry-run. Planned moves are only logged and not executed.
+func WithRebalanceDryRun(dryRun bool) Option {
+	return func(s *Scheduler) {
+		s.rebalance.dryRun = dryRun
+	}
+}
+
+// WithFence attaches the fencing token of the leader term to all scheduler writes.
+// The fence function returns the condition of the current term or false if the local node is not the leader
+// (see elect.Campaign.Fence()). Writes of a scheduler whose leader term ended are rejected by the database.
+func WithFence(fence func() (db.Condition, bool)) Option {
+	return func(s *Scheduler) {
+		s.fence = fence
+	}
+}
+
+// SetCycleTTL updates the scheduler cycle interval of the running scheduler (applied on the next cycle).
+func (s *Scheduler) SetCycleTTL(ttl int64) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+	s.cycleTTL = ttl
+}
+
+// SetRescheduleCycles updates the number of cycles a domain must be unmanaged before being rescheduled
+// (applied on the next cycle).
+func (s *Scheduler) SetRescheduleCycles(cycles int64) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+	s.rescheduleCycles = cycles
+}
+
+// SetUsageFactors updates the share of its provisioned cpu/memory a domain is assumed to consume
+// (applied on the next placement).
+func (s *Scheduler) SetUsageFactors(cpu, mem float64) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+	s.placement.cpuUsageFactor = cpu
+	s.placement.memUsageFactor = mem
+}
+
+// SetCommitThresholds updates the share of the committable node cpu/memory (in percent) that can be committed
+// by domains (applied on the next placement).
+func (s *Scheduler) SetCommitThresholds(cpu, mem int64) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+	s.placement.cpuCommitRatio = float64(cpu) / 100
+	s.placement.memCommitRatio = float64(mem) / 100
+}
+
+// SetScoreWeights updates the weights of the score plugins (applied on the next placement).
+func (s *Scheduler) SetScoreWeights(weights map[SCORE_PLUGIN]float64) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+	s.placement.scoreWeights = weights
+}
+
+// SetRebalanceCycleTTL updates the rebalancer cycle interval (applied on the next cycle).
+func (s *Scheduler) SetRebalanceCycleTTL(ttl int64) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+	s.rebalance.cycleTTL = ttl
+}
+
+// SetRebalanceThresholds updates the hot and skew load thresholds (in percent) of the rebalancer
+// (applied on the next cycle).
+func (s *Scheduler) SetRebalanceThresholds(hot, skew int64) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+	s.rebalance.hotThreshold = float64(hot) / 100
+	s.rebalance.skewThreshold = float64(skew) / 100
+}
+
+// SetRebalanceLimits updates the moves per cycle and the domain cooldown (seconds) of the rebalancer
+// (applied on the next cycle).
+func (s *Scheduler) SetRebalanceLimits(moves, cooldown int64) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+	s.rebalance.maxMoves = moves
+	s.rebalance.cooldown = cooldown
+}
+
+// SetRebalanceRunning updates whether the rebalancer moves running domains (applied on the next cycle).
+func (s *Scheduler) SetRebalanceRunning(allowed bool) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+	s.rebalance.moveRunning = allowed
+}
+
+// SetRebalanceDryRun enables or disables the rebalancer dry-run (applied on the next cycle).
+func (s *Scheduler) SetRebalanceDryRun(dryRun bool) {
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+	s.rebalance.dryRun = dryRun
+}
+
+// cycles returns the current cycle interval and reschedule cycles.
+func (s *Scheduler) cycles() (int64, int64) {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+	return s.cycleTTL, s.rescheduleCycles
+}
+
+// rebalanceSettings returns the current rebalancer settings.
+func (s *Scheduler) rebalanceSettings() rebalanceSettings {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+	return s.rebalance
+}
+
+// placementSettings returns the current placement settings.
+func (s *Scheduler) placementSettings() placementSettings {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+	return s.placement
+}
+
+// Lead runs the leader scheduler cycles and blocks until the context is cancelled or the scheduler terminates.
+// It is registered as leader-only component (see elect.Registry) and therefore only runs on the leader node.
+func (s *Scheduler) Lead(ctx context.Context) {
+	s.lead(ctx, s.startSchedulerCycle)
+}
+
+// Rebalance runs the leader rebalancer cycles and blocks until the context is cancelled or the scheduler
+// terminates. Like Lead() it is registered as leader-only component (see elect.Registry).
+func (s *Scheduler) Rebalance(ctx context.Context) {
+	s.lead(ctx, s.startRebalanceCycle)
+}
+
+// lead runs the leader cycle and tracks it until it returns. No cycle is started after termination started.
+func (s *Scheduler) lead(ctx context.Context, cycle func(context.Context)) {
+	s.leadLock.Lock()
+	if s.workCtx.Err() != nil {
+		s.leadLock.Unlock()
+		return
+	}
+	s.leadWg.Add(1)
+	s.leadLock.Unlock()
+	defer s.leadWg.Done()
+
+	cycle(ctx)
+}
+
 // Terminate shuts down the scheduler gracefully, if shutdown did not complete in the provided context window
 // the scheduler is terminated forcefully. Never returns an error (just there to match termination pattern).
 func (s *Scheduler) Terminate(ctx context.Context) error {
 	s.workCtxCancel()
 	defer s.rootCtxCancel()
+	s.leadLock.Lock()
+	s.leadLock.Unlock()
+	go func() {
+		s.leadWg.Wait()
+		s.finChan <- struct{}{}
+	}()
 	select {
 	case <-s.finChan:
 		return nil
@@ -0,0 +1,58 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scheduler
+
+import (
+	"math"
+
+	"cthul.io/cthul/pkg/api/wave/v1/node"
+)
+
+// spreadScore rates nodes by their unallocated resources after the placement (least allocated).
+type spreadScore struct{}
+
+func (spreadScore) score(p *placement, nodeId string, nod *node.Node) float64 {
+	cpu, mem := p.allocation(nodeId, nod)
+	return (1 - (cpu+mem)/2) * 100
+}
+
+// binpackScore rates nodes by their allocated resources after the placement (most allocated).
+type binpackScore struct{}
+
+func (binpackScore) score(p *placement, nodeId string, nod *node.Node) float64 {
+	cpu, mem := p.allocation(nodeId, nod)
+	return (cpu + mem) / 2 * 100
+}
+
+// balancedScore rates nodes by the difference between their cpu and memory allocation after the placement.
+// This avoids nodes that run out of one resource while the other one is mostly unused.
+type balancedScore struct{}
+
+func (balancedScore) score(p *placement, nodeId string, nod *node.Node) float64 {
+	cpu, mem := p.allocation(nodeId, nod)
+	return (1 - math.Abs(cpu-mem)) * 100
+}
+
+// affinityScore rates nodes by the weight of the matching preferred node affinity selectors of the domain.
+type affinityScore struct{}
+
+func (affinityScore) score(p *placement, _ string, nod *node.Node) float64 {
+	return preferenceScore(p.domain.Config.GetNodeAffinity(), nod.Config.GetLabels()) * 100
+}
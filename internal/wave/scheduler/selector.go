@@ -0,0 +1,68 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scheduler
+
+import (
+	"slices"
+
+	"cthul.io/cthul/pkg/api/wave/v1/domain"
+)
+
+// matchRequirements checks if the node labels match all selector requirements.
+func matchRequirements(requirements []*domain.SelectorRequirement, labels map[string]string) bool {
+	for _, requirement := range requirements {
+		if !matchRequirement(requirement, labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchRequirement checks if the node labels match the selector requirement.
+func matchRequirement(requirement *domain.SelectorRequirement, labels map[string]string) bool {
+	value, ok := labels[requirement.GetKey()]
+	switch requirement.GetOperator() {
+	case domain.SelectorOperator_SELECTOR_OPERATOR_IN:
+		return ok && slices.Contains(requirement.GetValues(), value)
+	case domain.SelectorOperator_SELECTOR_OPERATOR_NOT_IN:
+		return !ok || !slices.Contains(requirement.GetValues(), value)
+	case domain.SelectorOperator_SELECTOR_OPERATOR_EXISTS:
+		return ok
+	default:
+		return false
+	}
+}
+
+// preferenceScore rates how well the node labels match the preferred selectors of the node affinity.
+// Returns the weight of all matching selectors relative to the weight of all selectors (0 - 1).
+func preferenceScore(affinity *domain.NodeAffinity, labels map[string]string) float64 {
+	matchedWeight, totalWeight := int64(0), int64(0)
+	for _, preferred := range affinity.GetPreferred() {
+		weight := min(max(preferred.GetWeight(), 1), 100)
+		totalWeight += weight
+		if matchRequirements(preferred.GetRequirements(), labels) {
+			matchedWeight += weight
+		}
+	}
+	if totalWeight == 0 {
+		return 0
+	}
+	return float64(matchedWeight) / float64(totalWeight)
+}
@@ -0,0 +1,97 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scheduler
+
+import (
+	"testing"
+
+	"cthul.io/cthul/pkg/api/wave/v1/domain"
+	"cthul.io/cthul/pkg/api/wave/v1/node"
+)
+
+func TestMatchRequirement(t *testing.T) {
+	labels := map[string]string{"zone": "a", "disk": "ssd"}
+	tests := []struct {
+		name        string
+		requirement *domain.SelectorRequirement
+		want        bool
+	}{
+		{"in matches", requirement("zone", domain.SelectorOperator_SELECTOR_OPERATOR_IN, "a", "b"), true},
+		{"in mismatches", requirement("zone", domain.SelectorOperator_SELECTOR_OPERATOR_IN, "b"), false},
+		{"in requires label", requirement("rack", domain.SelectorOperator_SELECTOR_OPERATOR_IN, "a"), false},
+		{"not in matches", requirement("zone", domain.SelectorOperator_SELECTOR_OPERATOR_NOT_IN, "b"), true},
+		{"not in mismatches", requirement("zone", domain.SelectorOperator_SELECTOR_OPERATOR_NOT_IN, "a"), false},
+		{"not in without label", requirement("rack", domain.SelectorOperator_SELECTOR_OPERATOR_NOT_IN, "a"), true},
+		{"exists matches", requirement("disk", domain.SelectorOperator_SELECTOR_OPERATOR_EXISTS), true},
+		{"exists mismatches", requirement("rack", domain.SelectorOperator_SELECTOR_OPERATOR_EXISTS), false},
+		{"unspecified operator", requirement("zone", domain.SelectorOperator(0), "a"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchRequirement(tt.requirement, labels); got != tt.want {
+				t.Errorf("matchRequirement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEligibleFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*domain.Domain, *node.Node)
+		wantErr string
+	}{
+		{"healthy node", func(*domain.Domain, *node.Node) {}, ""},
+		{"malformed node", func(_ *domain.Domain, n *node.Node) { n.Error = "broken" }, "malformed"},
+		{"unhealthy node", func(_ *domain.Domain, n *node.Node) {
+			n.Config.State = node.NodeState_NODE_STATE_DEGRADED
+		}, "not healthy"},
+		{"affinity tag matches", func(d *domain.Domain, n *node.Node) {
+			d.Config.Affinity, n.Config.Affinity = []string{"ssd"}, []string{"hdd", "ssd"}
+		}, ""},
+		{"affinity tag mismatches", func(d *domain.Domain, n *node.Node) {
+			d.Config.Affinity, n.Config.Affinity = []string{"ssd"}, []string{"hdd"}
+		}, "affinity tag"},
+		{"affinity tags without node affinity", func(d *domain.Domain, _ *node.Node) {
+			d.Config.NodeAffinity = nil
+		}, "affinity tag"},
+		{"required selector matches", func(d *domain.Domain, _ *node.Node) {
+			d.Config.NodeAffinity.Required = []*domain.SelectorRequirement{
+				requirement("zone", domain.SelectorOperator_SELECTOR_OPERATOR_IN, "a"),
+			}
+		}, ""},
+		{"required selector mismatches", func(d *domain.Domain, _ *node.Node) {
+			d.Config.NodeAffinity.Required = []*domain.SelectorRequirement{
+				requirement("zone", domain.SelectorOperator_SELECTOR_OPERATOR_IN, "a"),
+				requirement("disk", domain.SelectorOperator_SELECTOR_OPERATOR_EXISTS),
+			}
+		}, "required node affinity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dom := testDomain("", 1, 1024)
+			nod := testNode(4, 4, 4096, 4096, map[string]string{"zone": "a"})
+			tt.modify(dom, nod)
+
+			p := newPlacement(dom, map[string]*domain.Domain{"d": dom}, map[string]*node.Node{"n": nod}, testSettings())
+			checkErr(t, eligibleFilter{}.filter(p, "n", nod), tt.wantErr)
+		})
+	}
+}
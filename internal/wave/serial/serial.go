@@ -55,7 +55,7 @@ func New(logger *slog.Logger, client db.Client, opts ...Option) *Operator {
 	operator := &Operator{
 		client:         client,
 		logger:         logger.WithGroup("serial-operator"),
-		syncer:         syncer.New(logger.WithGroup("serial-operator"), client),
+		syncer:         nil,
 		runRoot:        "/run/cthul/wave/",
 		nodeId:         "undefined",
 		updateCycleTTL: 30,
@@ -65,6 +65,8 @@ func New(logger *slog.Logger, client db.Client, opts ...Option) *Operator {
 	for _, opt := range opts {
 		opt(operator)
 	}
+	// the syncer is created after the options are applied, as it reports the key status of the local node.
+	operator.syncer = syncer.New(logger.WithGroup("serial-operator"), client, syncer.WithNodeId(operator.nodeId))
 
 	return operator
 }
@@ -105,6 +107,11 @@ func (o *Operator) ServeAndDetach() {
 	o.synchronize()
 }
 
+// Health returns an error if the serial states are currently not watched incrementally.
+func (o *Operator) Health() error {
+	return o.syncer.Health()
+}
+
 func (o *Operator) Terminate(ctx context.Context) error {
 	o.syncer.Shutdown()
 	return nil
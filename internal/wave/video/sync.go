@@ -24,23 +24,27 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"cthul.io/cthul/pkg/api/wave/v1/video"
+	"cthul.io/cthul/pkg/keyspace"
 	"google.golang.org/protobuf/proto"
 )
 
 func (o *Operator) synchronize() {
-  o.syncer.Add("/WAVE/VIDEO/REQNODE/", o.updateCycleTTL, func(ctx context.Context, k, reqnode string) error {
-    id := strings.TrimPrefix(k, "/WAVE/VIDEO/REQNODE/")
-    configKey := fmt.Sprintf("/WAVE/VIDEO/CONFIG/%s", id)
-    if reqnode == o.nodeId {
-      o.syncer.Add(configKey, o.syncCycleTTL, func(ctx context.Context, k, v string) error {
+  o.syncer.Add(keyspace.WaveVideo.Reqnode.Prefix(), o.updateCycleTTL, func(ctx context.Context, k, reqnode string, deleted bool) error {
+    id := keyspace.WaveVideo.Reqnode.Id(k)
+    configKey := keyspace.WaveVideo.Config.Key(id)
+    if !deleted && reqnode == o.nodeId {
+      o.syncer.Add(configKey, o.syncCycleTTL, func(ctx context.Context, k, v string, deleted bool) error {
+        if deleted {
+          // device removal is handled by the reqnode deletion, there is nothing to apply.
+          return nil
+        }
         err := o.applyConfig(id, v)
         if err!=nil {
           return err
         }
-        _, err = o.client.Set(ctx, fmt.Sprintf("/WAVE/VIDEO/NODE/%s", id), reqnode, 0)
+        _, err = o.client.Set(ctx, keyspace.WaveVideo.Node.Key(id), reqnode, 0)
         if err!=nil {
           return err
         }
@@ -73,6 +73,18 @@ func (l *Adapter) initClient() error {
 	return nil
 }
 
+// Health checks the connection to the libvirt daemon (the connection is established if not initialized yet).
+func (l *Adapter) Health() error {
+	err := l.initClient()
+	if err!=nil {
+		return fmt.Errorf("failed to connect to libvirt: %w", err)
+	}
+	if !l.client.IsConnected() {
+		return fmt.Errorf("libvirt connection lost")
+	}
+	return nil
+}
+
 // parseUUID tries to convert a uuid string (either with or without hyphens) into a libvirt.UUID.
 func (l *Adapter) parseUUID(id string) (libvirt.UUID, error) {
 	rawStr := strings.ReplaceAll(id, "-", "")
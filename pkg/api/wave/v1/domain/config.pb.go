@@ -583,6 +583,116 @@ func (NetworkBus) EnumDescriptor() ([]byte, []int) {
 	return file_wave_v1_domain_config_proto_rawDescGZIP(), []int{10}
 }
 
+// ConstraintMode specifies how the scheduler treats a placement constraint.
+type ConstraintMode int32
+
+const (
+	// unspecified constraints are treated as hard constraints.
+	ConstraintMode_CONSTRAINT_MODE_UNSPECIFIED ConstraintMode = 0
+	// hard constraints are never violated, the domain is not placed if no node satisfies them.
+	ConstraintMode_CONSTRAINT_MODE_HARD ConstraintMode = 1
+	// soft constraints are satisfied if possible, otherwise the domain is placed anyway.
+	ConstraintMode_CONSTRAINT_MODE_SOFT ConstraintMode = 2
+)
+
+// Enum value maps for ConstraintMode.
+var (
+	ConstraintMode_name = map[int32]string{
+		0: "CONSTRAINT_MODE_UNSPECIFIED",
+		1: "CONSTRAINT_MODE_HARD",
+		2: "CONSTRAINT_MODE_SOFT",
+	}
+	ConstraintMode_value = map[string]int32{
+		"CONSTRAINT_MODE_UNSPECIFIED": 0,
+		"CONSTRAINT_MODE_HARD":        1,
+		"CONSTRAINT_MODE_SOFT":        2,
+	}
+)
+
+func (x ConstraintMode) Enum() *ConstraintMode {
+	p := new(ConstraintMode)
+	*p = x
+	return p
+}
+
+func (x ConstraintMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConstraintMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_wave_v1_domain_config_proto_enumTypes[11].Descriptor()
+}
+
+func (ConstraintMode) Type() protoreflect.EnumType {
+	return &file_wave_v1_domain_config_proto_enumTypes[11]
+}
+
+func (x ConstraintMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConstraintMode.Descriptor instead.
+func (ConstraintMode) EnumDescriptor() ([]byte, []int) {
+	return file_wave_v1_domain_config_proto_rawDescGZIP(), []int{11}
+}
+
+// SelectorOperator specifies how a selector requirement matches the node labels.
+type SelectorOperator int32
+
+const (
+	// unspecified operators never match.
+	SelectorOperator_SELECTOR_OPERATOR_UNSPECIFIED SelectorOperator = 0
+	// the label exists and its value is one of the values.
+	SelectorOperator_SELECTOR_OPERATOR_IN SelectorOperator = 1
+	// the label does not exist or its value is none of the values.
+	SelectorOperator_SELECTOR_OPERATOR_NOT_IN SelectorOperator = 2
+	// the label exists (values are ignored).
+	SelectorOperator_SELECTOR_OPERATOR_EXISTS SelectorOperator = 3
+)
+
+// Enum value maps for SelectorOperator.
+var (
+	SelectorOperator_name = map[int32]string{
+		0: "SELECTOR_OPERATOR_UNSPECIFIED",
+		1: "SELECTOR_OPERATOR_IN",
+		2: "SELECTOR_OPERATOR_NOT_IN",
+		3: "SELECTOR_OPERATOR_EXISTS",
+	}
+	SelectorOperator_value = map[string]int32{
+		"SELECTOR_OPERATOR_UNSPECIFIED": 0,
+		"SELECTOR_OPERATOR_IN":          1,
+		"SELECTOR_OPERATOR_NOT_IN":      2,
+		"SELECTOR_OPERATOR_EXISTS":      3,
+	}
+)
+
+func (x SelectorOperator) Enum() *SelectorOperator {
+	p := new(SelectorOperator)
+	*p = x
+	return p
+}
+
+func (x SelectorOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SelectorOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_wave_v1_domain_config_proto_enumTypes[12].Descriptor()
+}
+
+func (SelectorOperator) Type() protoreflect.EnumType {
+	return &file_wave_v1_domain_config_proto_enumTypes[12]
+}
+
+func (x SelectorOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SelectorOperator.Descriptor instead.
+func (SelectorOperator) EnumDescriptor() ([]byte, []int) {
+	return file_wave_v1_domain_config_proto_rawDescGZIP(), []int{12}
+}
+
 type SystemConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1142,6 +1252,313 @@ func (x *NetworkDevice) GetBootPriority() int64 {
 	return 0
 }
 
+// AntiAffinity keeps the domains of the same group on different nodes (e.g. database replicas).
+type AntiAffinity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group string         `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Mode  ConstraintMode `protobuf:"varint,2,opt,name=mode,proto3,enum=wave.v1.domain.ConstraintMode" json:"mode,omitempty"`
+}
+
+func (x *AntiAffinity) Reset() {
+	*x = AntiAffinity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wave_v1_domain_config_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AntiAffinity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AntiAffinity) ProtoMessage() {}
+
+func (x *AntiAffinity) ProtoReflect() protoreflect.Message {
+	mi := &file_wave_v1_domain_config_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AntiAffinity.ProtoReflect.Descriptor instead.
+func (*AntiAffinity) Descriptor() ([]byte, []int) {
+	return file_wave_v1_domain_config_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AntiAffinity) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *AntiAffinity) GetMode() ConstraintMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ConstraintMode_CONSTRAINT_MODE_UNSPECIFIED
+}
+
+// SpreadConstraint distributes the domains of the same group evenly across the topology domains defined by
+// a node label (e.g. "rack"). The number of group domains in one topology domain must not exceed the number
+// of group domains in the least populated topology domain by more than max_skew.
+type SpreadConstraint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group       string         `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	TopologyKey string         `protobuf:"bytes,2,opt,name=topology_key,json=topologyKey,proto3" json:"topology_key,omitempty"`
+	MaxSkew     int64          `protobuf:"varint,3,opt,name=max_skew,json=maxSkew,proto3" json:"max_skew,omitempty"`
+	Mode        ConstraintMode `protobuf:"varint,4,opt,name=mode,proto3,enum=wave.v1.domain.ConstraintMode" json:"mode,omitempty"`
+}
+
+func (x *SpreadConstraint) Reset() {
+	*x = SpreadConstraint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wave_v1_domain_config_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpreadConstraint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpreadConstraint) ProtoMessage() {}
+
+func (x *SpreadConstraint) ProtoReflect() protoreflect.Message {
+	mi := &file_wave_v1_domain_config_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpreadConstraint.ProtoReflect.Descriptor instead.
+func (*SpreadConstraint) Descriptor() ([]byte, []int) {
+	return file_wave_v1_domain_config_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SpreadConstraint) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *SpreadConstraint) GetTopologyKey() string {
+	if x != nil {
+		return x.TopologyKey
+	}
+	return ""
+}
+
+func (x *SpreadConstraint) GetMaxSkew() int64 {
+	if x != nil {
+		return x.MaxSkew
+	}
+	return 0
+}
+
+func (x *SpreadConstraint) GetMode() ConstraintMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ConstraintMode_CONSTRAINT_MODE_UNSPECIFIED
+}
+
+// SelectorRequirement matches a node label (e.g. "ssd" IN ["true"]).
+type SelectorRequirement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string           `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Operator SelectorOperator `protobuf:"varint,2,opt,name=operator,proto3,enum=wave.v1.domain.SelectorOperator" json:"operator,omitempty"`
+	Values   []string         `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *SelectorRequirement) Reset() {
+	*x = SelectorRequirement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wave_v1_domain_config_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SelectorRequirement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectorRequirement) ProtoMessage() {}
+
+func (x *SelectorRequirement) ProtoReflect() protoreflect.Message {
+	mi := &file_wave_v1_domain_config_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectorRequirement.ProtoReflect.Descriptor instead.
+func (*SelectorRequirement) Descriptor() ([]byte, []int) {
+	return file_wave_v1_domain_config_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SelectorRequirement) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SelectorRequirement) GetOperator() SelectorOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return SelectorOperator_SELECTOR_OPERATOR_UNSPECIFIED
+}
+
+func (x *SelectorRequirement) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// PreferredSelector adds its weight (1 - 100) to the rating of nodes that match all requirements.
+type PreferredSelector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Weight       int64                  `protobuf:"varint,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	Requirements []*SelectorRequirement `protobuf:"bytes,2,rep,name=requirements,proto3" json:"requirements,omitempty"`
+}
+
+func (x *PreferredSelector) Reset() {
+	*x = PreferredSelector{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wave_v1_domain_config_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreferredSelector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreferredSelector) ProtoMessage() {}
+
+func (x *PreferredSelector) ProtoReflect() protoreflect.Message {
+	mi := &file_wave_v1_domain_config_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreferredSelector.ProtoReflect.Descriptor instead.
+func (*PreferredSelector) Descriptor() ([]byte, []int) {
+	return file_wave_v1_domain_config_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PreferredSelector) GetWeight() int64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *PreferredSelector) GetRequirements() []*SelectorRequirement {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+// NodeAffinity selects the nodes of a domain by their labels. The domain is only placed on nodes that match
+// all required requirements, nodes that match preferred selectors are rated higher.
+type NodeAffinity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Required  []*SelectorRequirement `protobuf:"bytes,1,rep,name=required,proto3" json:"required,omitempty"`
+	Preferred []*PreferredSelector   `protobuf:"bytes,2,rep,name=preferred,proto3" json:"preferred,omitempty"`
+}
+
+func (x *NodeAffinity) Reset() {
+	*x = NodeAffinity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wave_v1_domain_config_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeAffinity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeAffinity) ProtoMessage() {}
+
+func (x *NodeAffinity) ProtoReflect() protoreflect.Message {
+	mi := &file_wave_v1_domain_config_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeAffinity.ProtoReflect.Descriptor instead.
+func (*NodeAffinity) Descriptor() ([]byte, []int) {
+	return file_wave_v1_domain_config_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NodeAffinity) GetRequired() []*SelectorRequirement {
+	if x != nil {
+		return x.Required
+	}
+	return nil
+}
+
+func (x *NodeAffinity) GetPreferred() []*PreferredSelector {
+	if x != nil {
+		return x.Preferred
+	}
+	return nil
+}
+
 // DomainConfig represents a cthul domain. This format is used by the underlying domain controller
 // to build up the vendor specific config (e.g. libvirt xml).
 type DomainConfig struct {
@@ -1149,26 +1566,29 @@ type DomainConfig struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name           string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Title          string           `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description    string           `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	State          DomainState      `protobuf:"varint,4,opt,name=state,proto3,enum=wave.v1.domain.DomainState" json:"state,omitempty"`
-	Affinity       []string         `protobuf:"bytes,5,rep,name=affinity,proto3" json:"affinity,omitempty"`
-	SystemConfig   *SystemConfig    `protobuf:"bytes,6,opt,name=system_config,json=systemConfig,proto3" json:"system_config,omitempty"`
-	FirmwareConfig *FirmwareConfig  `protobuf:"bytes,7,opt,name=firmware_config,json=firmwareConfig,proto3" json:"firmware_config,omitempty"`
-	ResourceConfig *ResourceConfig  `protobuf:"bytes,8,opt,name=resource_config,json=resourceConfig,proto3" json:"resource_config,omitempty"`
-	VideoDevices   []*VideoDevice   `protobuf:"bytes,9,rep,name=video_devices,json=videoDevices,proto3" json:"video_devices,omitempty"`
-	VideoAdapters  []*VideoAdapter  `protobuf:"bytes,10,rep,name=video_adapters,json=videoAdapters,proto3" json:"video_adapters,omitempty"`
-	InputDevices   []*InputDevice   `protobuf:"bytes,11,rep,name=input_devices,json=inputDevices,proto3" json:"input_devices,omitempty"`
-	SerialDevices  []*SerialDevice  `protobuf:"bytes,12,rep,name=serial_devices,json=serialDevices,proto3" json:"serial_devices,omitempty"`
-	StorageDevices []*StorageDevice `protobuf:"bytes,13,rep,name=storage_devices,json=storageDevices,proto3" json:"storage_devices,omitempty"`
-	NetworkDevices []*NetworkDevice `protobuf:"bytes,14,rep,name=network_devices,json=networkDevices,proto3" json:"network_devices,omitempty"`
+	Name              string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title             string              `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description       string              `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	State             DomainState         `protobuf:"varint,4,opt,name=state,proto3,enum=wave.v1.domain.DomainState" json:"state,omitempty"`
+	Affinity          []string            `protobuf:"bytes,5,rep,name=affinity,proto3" json:"affinity,omitempty"`
+	SystemConfig      *SystemConfig       `protobuf:"bytes,6,opt,name=system_config,json=systemConfig,proto3" json:"system_config,omitempty"`
+	FirmwareConfig    *FirmwareConfig     `protobuf:"bytes,7,opt,name=firmware_config,json=firmwareConfig,proto3" json:"firmware_config,omitempty"`
+	ResourceConfig    *ResourceConfig     `protobuf:"bytes,8,opt,name=resource_config,json=resourceConfig,proto3" json:"resource_config,omitempty"`
+	VideoDevices      []*VideoDevice      `protobuf:"bytes,9,rep,name=video_devices,json=videoDevices,proto3" json:"video_devices,omitempty"`
+	VideoAdapters     []*VideoAdapter     `protobuf:"bytes,10,rep,name=video_adapters,json=videoAdapters,proto3" json:"video_adapters,omitempty"`
+	InputDevices      []*InputDevice      `protobuf:"bytes,11,rep,name=input_devices,json=inputDevices,proto3" json:"input_devices,omitempty"`
+	SerialDevices     []*SerialDevice     `protobuf:"bytes,12,rep,name=serial_devices,json=serialDevices,proto3" json:"serial_devices,omitempty"`
+	StorageDevices    []*StorageDevice    `protobuf:"bytes,13,rep,name=storage_devices,json=storageDevices,proto3" json:"storage_devices,omitempty"`
+	NetworkDevices    []*NetworkDevice    `protobuf:"bytes,14,rep,name=network_devices,json=networkDevices,proto3" json:"network_devices,omitempty"`
+	AntiAffinity      []*AntiAffinity     `protobuf:"bytes,15,rep,name=anti_affinity,json=antiAffinity,proto3" json:"anti_affinity,omitempty"`
+	SpreadConstraints []*SpreadConstraint `protobuf:"bytes,16,rep,name=spread_constraints,json=spreadConstraints,proto3" json:"spread_constraints,omitempty"`
+	NodeAffinity      *NodeAffinity       `protobuf:"bytes,17,opt,name=node_affinity,json=nodeAffinity,proto3" json:"node_affinity,omitempty"`
 }
 
 func (x *DomainConfig) Reset() {
 	*x = DomainConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_wave_v1_domain_config_proto_msgTypes[9]
+		mi := &file_wave_v1_domain_config_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1181,7 +1601,7 @@ func (x *DomainConfig) String() string {
 func (*DomainConfig) ProtoMessage() {}
 
 func (x *DomainConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_wave_v1_domain_config_proto_msgTypes[9]
+	mi := &file_wave_v1_domain_config_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1194,7 +1614,7 @@ func (x *DomainConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DomainConfig.ProtoReflect.Descriptor instead.
 func (*DomainConfig) Descriptor() ([]byte, []int) {
-	return file_wave_v1_domain_config_proto_rawDescGZIP(), []int{9}
+	return file_wave_v1_domain_config_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DomainConfig) GetName() string {
@@ -1295,6 +1715,27 @@ func (x *DomainConfig) GetNetworkDevices() []*NetworkDevice {
 	return nil
 }
 
+func (x *DomainConfig) GetAntiAffinity() []*AntiAffinity {
+	if x != nil {
+		return x.AntiAffinity
+	}
+	return nil
+}
+
+func (x *DomainConfig) GetSpreadConstraints() []*SpreadConstraint {
+	if x != nil {
+		return x.SpreadConstraints
+	}
+	return nil
+}
+
+func (x *DomainConfig) GetNodeAffinity() *NodeAffinity {
+	if x != nil {
+		return x.NodeAffinity
+	}
+	return nil
+}
+
 var File_wave_v1_domain_config_proto protoreflect.FileDescriptor
 
 var file_wave_v1_domain_config_proto_rawDesc = []byte{
@@ -1379,126 +1820,195 @@ var file_wave_v1_domain_config_proto_rawDesc = []byte{
 	0x77, 0x6f, 0x72, 0x6b, 0x42, 0x75, 0x73, 0x52, 0x0a, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
 	0x42, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x6f,
 	0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x62, 0x6f, 0x6f, 0x74,
-	0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x9c, 0x06, 0x0a, 0x0c, 0x44, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69,
-	0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74,
-	0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x66, 0x66, 0x69,
-	0x6e, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x61, 0x66, 0x66, 0x69,
-	0x6e, 0x69, 0x74, 0x79, 0x12, 0x41, 0x0a, 0x0d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x61,
-	0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x79, 0x73,
-	0x74, 0x65, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x73, 0x79, 0x73, 0x74, 0x65,
-	0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x47, 0x0a, 0x0f, 0x66, 0x69, 0x72, 0x6d, 0x77,
-	0x61, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1e, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x52, 0x0e, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x12, 0x47, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x77, 0x61, 0x76, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x40, 0x0a, 0x0d, 0x76, 0x69, 0x64,
-	0x65, 0x6f, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1b, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x2e, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0c, 0x76,
-	0x69, 0x64, 0x65, 0x6f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x43, 0x0a, 0x0e, 0x76,
-	0x69, 0x64, 0x65, 0x6f, 0x5f, 0x61, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x18, 0x0a, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f,
-	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x41, 0x64, 0x61, 0x70, 0x74, 0x65,
-	0x72, 0x52, 0x0d, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x41, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73,
-	0x12, 0x40, 0x0a, 0x0d, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x65,
-	0x76, 0x69, 0x63, 0x65, 0x52, 0x0c, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x73, 0x12, 0x43, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x61, 0x76,
-	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x72, 0x69,
-	0x61, 0x6c, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61,
-	0x67, 0x65, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52,
-	0x0e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12,
-	0x46, 0x0a, 0x0f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2a, 0x8d, 0x01, 0x0a, 0x0b, 0x44, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x44, 0x4f, 0x4d, 0x41, 0x49,
-	0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x4f, 0x4d, 0x41, 0x49, 0x4e, 0x5f,
-	0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x50, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x44, 0x4f,
-	0x4d, 0x41, 0x49, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x41, 0x55, 0x53, 0x45,
-	0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x4f, 0x4d, 0x41, 0x49, 0x4e, 0x5f, 0x53, 0x54, 0x41,
-	0x54, 0x45, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x44, 0x4f, 0x4d,
-	0x41, 0x49, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x46, 0x4f, 0x52, 0x43, 0x45, 0x44,
-	0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x04, 0x2a, 0x3e, 0x0a, 0x04, 0x41, 0x72, 0x63, 0x68, 0x12,
-	0x14, 0x0a, 0x10, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x41, 0x4d,
-	0x44, 0x36, 0x34, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x41, 0x41,
-	0x52, 0x43, 0x48, 0x36, 0x34, 0x10, 0x02, 0x2a, 0x59, 0x0a, 0x07, 0x43, 0x68, 0x69, 0x70, 0x73,
-	0x65, 0x74, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x48, 0x49, 0x50, 0x53, 0x45, 0x54, 0x5f, 0x55, 0x4e,
-	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x43,
-	0x48, 0x49, 0x50, 0x53, 0x45, 0x54, 0x5f, 0x49, 0x34, 0x34, 0x30, 0x46, 0x58, 0x10, 0x01, 0x12,
-	0x0f, 0x0a, 0x0b, 0x43, 0x48, 0x49, 0x50, 0x53, 0x45, 0x54, 0x5f, 0x51, 0x33, 0x35, 0x10, 0x02,
-	0x12, 0x10, 0x0a, 0x0c, 0x43, 0x48, 0x49, 0x50, 0x53, 0x45, 0x54, 0x5f, 0x56, 0x49, 0x52, 0x54,
-	0x10, 0x03, 0x2a, 0x4d, 0x0a, 0x08, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x12, 0x18,
-	0x0a, 0x14, 0x46, 0x49, 0x52, 0x4d, 0x57, 0x41, 0x52, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
-	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x46, 0x49, 0x52, 0x4d,
-	0x57, 0x41, 0x52, 0x45, 0x5f, 0x4f, 0x56, 0x4d, 0x46, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x46,
-	0x49, 0x52, 0x4d, 0x57, 0x41, 0x52, 0x45, 0x5f, 0x53, 0x45, 0x41, 0x42, 0x49, 0x4f, 0x53, 0x10,
-	0x02, 0x2a, 0x5c, 0x0a, 0x05, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x12, 0x15, 0x0a, 0x11, 0x56, 0x49,
-	0x44, 0x45, 0x4f, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
-	0x00, 0x12, 0x0d, 0x0a, 0x09, 0x56, 0x49, 0x44, 0x45, 0x4f, 0x5f, 0x56, 0x47, 0x41, 0x10, 0x01,
-	0x12, 0x0d, 0x0a, 0x09, 0x56, 0x49, 0x44, 0x45, 0x4f, 0x5f, 0x51, 0x58, 0x4c, 0x10, 0x02, 0x12,
-	0x0e, 0x0a, 0x0a, 0x56, 0x49, 0x44, 0x45, 0x4f, 0x5f, 0x48, 0x4f, 0x53, 0x54, 0x10, 0x03, 0x12,
-	0x0e, 0x0a, 0x0a, 0x56, 0x49, 0x44, 0x45, 0x4f, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x04, 0x2a,
-	0x52, 0x0a, 0x09, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x42, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x16,
-	0x53, 0x45, 0x52, 0x49, 0x41, 0x4c, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
-	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x52, 0x49,
-	0x41, 0x4c, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x49, 0x53, 0x41, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11,
-	0x53, 0x45, 0x52, 0x49, 0x41, 0x4c, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x56, 0x49, 0x52, 0x54, 0x49,
-	0x4f, 0x10, 0x02, 0x2a, 0x6d, 0x0a, 0x09, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x1a, 0x0a, 0x16, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55,
-	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10,
-	0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x4f, 0x55, 0x53, 0x45,
-	0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x54, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x49, 0x4e, 0x50,
-	0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4b, 0x45, 0x59, 0x42, 0x4f, 0x41, 0x52, 0x44,
-	0x10, 0x03, 0x2a, 0x61, 0x0a, 0x08, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x42, 0x75, 0x73, 0x12, 0x19,
-	0x0a, 0x15, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50,
-	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x4e, 0x50,
-	0x55, 0x54, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x50, 0x53, 0x32, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d,
-	0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x55, 0x53, 0x42, 0x10, 0x02, 0x12,
-	0x14, 0x0a, 0x10, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x56, 0x49, 0x52,
-	0x54, 0x49, 0x4f, 0x10, 0x03, 0x2a, 0x5a, 0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f,
+	0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x58, 0x0a, 0x0c, 0x41, 0x6e, 0x74, 0x69,
+	0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x32,
+	0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x77,
+	0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x43, 0x6f,
+	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x22, 0x9a, 0x01, 0x0a, 0x10, 0x53, 0x70, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x21, 0x0a,
+	0x0c, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x4b, 0x65, 0x79,
+	0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x6b, 0x65, 0x77, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x53, 0x6b, 0x65, 0x77, 0x12, 0x32, 0x0a, 0x04, 0x6d,
+	0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x77, 0x61, 0x76, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x6e, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22,
+	0x7d, 0x0a, 0x13, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3c, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x77, 0x61, 0x76,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x74,
+	0x0a, 0x11, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x47, 0x0a, 0x0c, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x22, 0x90, 0x01, 0x0a, 0x0c, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x66, 0x66,
+	0x69, 0x6e, 0x69, 0x74, 0x79, 0x12, 0x3f, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x72, 0x65,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x3f, 0x0a, 0x09, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x77, 0x61, 0x76, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x72, 0x65, 0x64, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x09, 0x70, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x22, 0xf3, 0x07, 0x0a, 0x0c, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x66, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x61, 0x66, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x79, 0x12, 0x41, 0x0a, 0x0d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x61, 0x76,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x47, 0x0a, 0x0f, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61,
+	0x72, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x0e, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x47, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x40, 0x0a, 0x0d, 0x76, 0x69, 0x64, 0x65,
+	0x6f, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x2e, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0c, 0x76, 0x69,
+	0x64, 0x65, 0x6f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x43, 0x0a, 0x0e, 0x76, 0x69,
+	0x64, 0x65, 0x6f, 0x5f, 0x61, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x2e, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x41, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72,
+	0x52, 0x0d, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x41, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x40, 0x0a, 0x0d, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x0c, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x12, 0x43, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x61, 0x76, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x46,
+	0x0a, 0x0f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0e, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x0d, 0x61, 0x6e, 0x74, 0x69, 0x5f, 0x61,
+	0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x41,
+	0x6e, 0x74, 0x69, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x52, 0x0c, 0x61, 0x6e, 0x74,
+	0x69, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x12, 0x4f, 0x0a, 0x12, 0x73, 0x70, 0x72,
+	0x65, 0x61, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x18,
+	0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x70, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x52, 0x11, 0x73, 0x70, 0x72, 0x65, 0x61, 0x64, 0x43,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x41, 0x0a, 0x0d, 0x6e, 0x6f,
+	0x64, 0x65, 0x5f, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x52,
+	0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x2a, 0x8d, 0x01,
+	0x0a, 0x0b, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a,
+	0x18, 0x44, 0x4f, 0x4d, 0x41, 0x49, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x44,
+	0x4f, 0x4d, 0x41, 0x49, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x50, 0x10, 0x01,
+	0x12, 0x16, 0x0a, 0x12, 0x44, 0x4f, 0x4d, 0x41, 0x49, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
+	0x5f, 0x50, 0x41, 0x55, 0x53, 0x45, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x4f, 0x4d, 0x41,
+	0x49, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x03, 0x12,
+	0x1c, 0x0a, 0x18, 0x44, 0x4f, 0x4d, 0x41, 0x49, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
+	0x46, 0x4f, 0x52, 0x43, 0x45, 0x44, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x04, 0x2a, 0x3e, 0x0a,
+	0x04, 0x41, 0x72, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x41,
+	0x52, 0x43, 0x48, 0x5f, 0x41, 0x4d, 0x44, 0x36, 0x34, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x41,
+	0x52, 0x43, 0x48, 0x5f, 0x41, 0x41, 0x52, 0x43, 0x48, 0x36, 0x34, 0x10, 0x02, 0x2a, 0x59, 0x0a,
+	0x07, 0x43, 0x68, 0x69, 0x70, 0x73, 0x65, 0x74, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x48, 0x49, 0x50,
+	0x53, 0x45, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x48, 0x49, 0x50, 0x53, 0x45, 0x54, 0x5f, 0x49, 0x34, 0x34,
+	0x30, 0x46, 0x58, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x48, 0x49, 0x50, 0x53, 0x45, 0x54,
+	0x5f, 0x51, 0x33, 0x35, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x48, 0x49, 0x50, 0x53, 0x45,
+	0x54, 0x5f, 0x56, 0x49, 0x52, 0x54, 0x10, 0x03, 0x2a, 0x4d, 0x0a, 0x08, 0x46, 0x69, 0x72, 0x6d,
+	0x77, 0x61, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x14, 0x46, 0x49, 0x52, 0x4d, 0x57, 0x41, 0x52, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11,
+	0x0a, 0x0d, 0x46, 0x49, 0x52, 0x4d, 0x57, 0x41, 0x52, 0x45, 0x5f, 0x4f, 0x56, 0x4d, 0x46, 0x10,
+	0x01, 0x12, 0x14, 0x0a, 0x10, 0x46, 0x49, 0x52, 0x4d, 0x57, 0x41, 0x52, 0x45, 0x5f, 0x53, 0x45,
+	0x41, 0x42, 0x49, 0x4f, 0x53, 0x10, 0x02, 0x2a, 0x5c, 0x0a, 0x05, 0x56, 0x69, 0x64, 0x65, 0x6f,
+	0x12, 0x15, 0x0a, 0x11, 0x56, 0x49, 0x44, 0x45, 0x4f, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x56, 0x49, 0x44, 0x45, 0x4f,
+	0x5f, 0x56, 0x47, 0x41, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x56, 0x49, 0x44, 0x45, 0x4f, 0x5f,
+	0x51, 0x58, 0x4c, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x56, 0x49, 0x44, 0x45, 0x4f, 0x5f, 0x48,
+	0x4f, 0x53, 0x54, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x56, 0x49, 0x44, 0x45, 0x4f, 0x5f, 0x4e,
+	0x4f, 0x4e, 0x45, 0x10, 0x04, 0x2a, 0x52, 0x0a, 0x09, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x42,
+	0x75, 0x73, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x52, 0x49, 0x41, 0x4c, 0x5f, 0x42, 0x55, 0x53,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12,
+	0x0a, 0x0e, 0x53, 0x45, 0x52, 0x49, 0x41, 0x4c, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x49, 0x53, 0x41,
+	0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x45, 0x52, 0x49, 0x41, 0x4c, 0x5f, 0x42, 0x55, 0x53,
+	0x5f, 0x56, 0x49, 0x52, 0x54, 0x49, 0x4f, 0x10, 0x02, 0x2a, 0x6d, 0x0a, 0x09, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f,
 	0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
-	0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x59,
-	0x50, 0x45, 0x5f, 0x43, 0x44, 0x52, 0x4f, 0x4d, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x54,
-	0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x10,
-	0x02, 0x2a, 0x6c, 0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x42, 0x75, 0x73, 0x12,
-	0x1b, 0x0a, 0x17, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x55,
-	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f,
-	0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x49, 0x44, 0x45, 0x10,
-	0x01, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x42, 0x55, 0x53,
-	0x5f, 0x53, 0x41, 0x54, 0x41, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x54, 0x4f, 0x52, 0x41,
-	0x47, 0x45, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x56, 0x49, 0x52, 0x54, 0x49, 0x4f, 0x10, 0x03, 0x2a,
-	0x58, 0x0a, 0x0a, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x42, 0x75, 0x73, 0x12, 0x1b, 0x0a,
-	0x17, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53,
-	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x4e, 0x45,
-	0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x45, 0x31, 0x30, 0x30, 0x30, 0x10,
-	0x01, 0x12, 0x16, 0x0a, 0x12, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x42, 0x55, 0x53,
-	0x5f, 0x56, 0x49, 0x52, 0x54, 0x49, 0x4f, 0x10, 0x02, 0x42, 0x27, 0x5a, 0x25, 0x63, 0x74, 0x68,
-	0x75, 0x6c, 0x2e, 0x69, 0x6f, 0x2f, 0x63, 0x74, 0x68, 0x75, 0x6c, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x77, 0x61, 0x76, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x4d, 0x4f, 0x55, 0x53, 0x45, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x49, 0x4e, 0x50, 0x55,
+	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x54, 0x10, 0x02, 0x12,
+	0x17, 0x0a, 0x13, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4b, 0x45,
+	0x59, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x10, 0x03, 0x2a, 0x61, 0x0a, 0x08, 0x49, 0x6e, 0x70, 0x75,
+	0x74, 0x42, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x15, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x42, 0x55,
+	0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x11, 0x0a, 0x0d, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x50, 0x53, 0x32,
+	0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x42, 0x55, 0x53, 0x5f,
+	0x55, 0x53, 0x42, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x42,
+	0x55, 0x53, 0x5f, 0x56, 0x49, 0x52, 0x54, 0x49, 0x4f, 0x10, 0x03, 0x2a, 0x5a, 0x0a, 0x0b, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x54,
+	0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x54, 0x4f, 0x52,
+	0x41, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x44, 0x52, 0x4f, 0x4d, 0x10, 0x01,
+	0x12, 0x15, 0x0a, 0x11, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x44, 0x49, 0x53, 0x4b, 0x10, 0x02, 0x2a, 0x6c, 0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x42, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45,
+	0x5f, 0x42, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x42, 0x55,
+	0x53, 0x5f, 0x49, 0x44, 0x45, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x54, 0x4f, 0x52, 0x41,
+	0x47, 0x45, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x53, 0x41, 0x54, 0x41, 0x10, 0x02, 0x12, 0x16, 0x0a,
+	0x12, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x56, 0x49, 0x52,
+	0x54, 0x49, 0x4f, 0x10, 0x03, 0x2a, 0x58, 0x0a, 0x0a, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x42, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x17, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x42,
+	0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x15, 0x0a, 0x11, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x42, 0x55, 0x53, 0x5f,
+	0x45, 0x31, 0x30, 0x30, 0x30, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x4e, 0x45, 0x54, 0x57, 0x4f,
+	0x52, 0x4b, 0x5f, 0x42, 0x55, 0x53, 0x5f, 0x56, 0x49, 0x52, 0x54, 0x49, 0x4f, 0x10, 0x02, 0x2a,
+	0x65, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x4e, 0x53, 0x54, 0x52, 0x41, 0x49, 0x4e, 0x54, 0x5f,
+	0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x4f, 0x4e, 0x53, 0x54, 0x52, 0x41, 0x49, 0x4e, 0x54,
+	0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x48, 0x41, 0x52, 0x44, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14,
+	0x43, 0x4f, 0x4e, 0x53, 0x54, 0x52, 0x41, 0x49, 0x4e, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f,
+	0x53, 0x4f, 0x46, 0x54, 0x10, 0x02, 0x2a, 0x8b, 0x01, 0x0a, 0x10, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x1d, 0x53,
+	0x45, 0x4c, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18,
+	0x0a, 0x14, 0x53, 0x45, 0x4c, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41,
+	0x54, 0x4f, 0x52, 0x5f, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x45, 0x4c, 0x45,
+	0x43, 0x54, 0x4f, 0x52, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x4e, 0x4f,
+	0x54, 0x5f, 0x49, 0x4e, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x45, 0x4c, 0x45, 0x43, 0x54,
+	0x4f, 0x52, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x45, 0x58, 0x49, 0x53,
+	0x54, 0x53, 0x10, 0x03, 0x42, 0x27, 0x5a, 0x25, 0x63, 0x74, 0x68, 0x75, 0x6c, 0x2e, 0x69, 0x6f,
+	0x2f, 0x63, 0x74, 0x68, 0x75, 0x6c, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x77,
+	0x61, 0x76, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1513,30 +2023,37 @@ func file_wave_v1_domain_config_proto_rawDescGZIP() []byte {
 	return file_wave_v1_domain_config_proto_rawDescData
 }
 
-var file_wave_v1_domain_config_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
-var file_wave_v1_domain_config_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_wave_v1_domain_config_proto_enumTypes = make([]protoimpl.EnumInfo, 13)
+var file_wave_v1_domain_config_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_wave_v1_domain_config_proto_goTypes = []any{
-	(DomainState)(0),       // 0: wave.v1.domain.DomainState
-	(Arch)(0),              // 1: wave.v1.domain.Arch
-	(Chipset)(0),           // 2: wave.v1.domain.Chipset
-	(Firmware)(0),          // 3: wave.v1.domain.Firmware
-	(Video)(0),             // 4: wave.v1.domain.Video
-	(SerialBus)(0),         // 5: wave.v1.domain.SerialBus
-	(InputType)(0),         // 6: wave.v1.domain.InputType
-	(InputBus)(0),          // 7: wave.v1.domain.InputBus
-	(StorageType)(0),       // 8: wave.v1.domain.StorageType
-	(StorageBus)(0),        // 9: wave.v1.domain.StorageBus
-	(NetworkBus)(0),        // 10: wave.v1.domain.NetworkBus
-	(*SystemConfig)(nil),   // 11: wave.v1.domain.SystemConfig
-	(*FirmwareConfig)(nil), // 12: wave.v1.domain.FirmwareConfig
-	(*ResourceConfig)(nil), // 13: wave.v1.domain.ResourceConfig
-	(*VideoDevice)(nil),    // 14: wave.v1.domain.VideoDevice
-	(*VideoAdapter)(nil),   // 15: wave.v1.domain.VideoAdapter
-	(*SerialDevice)(nil),   // 16: wave.v1.domain.SerialDevice
-	(*InputDevice)(nil),    // 17: wave.v1.domain.InputDevice
-	(*StorageDevice)(nil),  // 18: wave.v1.domain.StorageDevice
-	(*NetworkDevice)(nil),  // 19: wave.v1.domain.NetworkDevice
-	(*DomainConfig)(nil),   // 20: wave.v1.domain.DomainConfig
+	(DomainState)(0),            // 0: wave.v1.domain.DomainState
+	(Arch)(0),                   // 1: wave.v1.domain.Arch
+	(Chipset)(0),                // 2: wave.v1.domain.Chipset
+	(Firmware)(0),               // 3: wave.v1.domain.Firmware
+	(Video)(0),                  // 4: wave.v1.domain.Video
+	(SerialBus)(0),              // 5: wave.v1.domain.SerialBus
+	(InputType)(0),              // 6: wave.v1.domain.InputType
+	(InputBus)(0),               // 7: wave.v1.domain.InputBus
+	(StorageType)(0),            // 8: wave.v1.domain.StorageType
+	(StorageBus)(0),             // 9: wave.v1.domain.StorageBus
+	(NetworkBus)(0),             // 10: wave.v1.domain.NetworkBus
+	(ConstraintMode)(0),         // 11: wave.v1.domain.ConstraintMode
+	(SelectorOperator)(0),       // 12: wave.v1.domain.SelectorOperator
+	(*SystemConfig)(nil),        // 13: wave.v1.domain.SystemConfig
+	(*FirmwareConfig)(nil),      // 14: wave.v1.domain.FirmwareConfig
+	(*ResourceConfig)(nil),      // 15: wave.v1.domain.ResourceConfig
+	(*VideoDevice)(nil),         // 16: wave.v1.domain.VideoDevice
+	(*VideoAdapter)(nil),        // 17: wave.v1.domain.VideoAdapter
+	(*SerialDevice)(nil),        // 18: wave.v1.domain.SerialDevice
+	(*InputDevice)(nil),         // 19: wave.v1.domain.InputDevice
+	(*StorageDevice)(nil),       // 20: wave.v1.domain.StorageDevice
+	(*NetworkDevice)(nil),       // 21: wave.v1.domain.NetworkDevice
+	(*AntiAffinity)(nil),        // 22: wave.v1.domain.AntiAffinity
+	(*SpreadConstraint)(nil),    // 23: wave.v1.domain.SpreadConstraint
+	(*SelectorRequirement)(nil), // 24: wave.v1.domain.SelectorRequirement
+	(*PreferredSelector)(nil),   // 25: wave.v1.domain.PreferredSelector
+	(*NodeAffinity)(nil),        // 26: wave.v1.domain.NodeAffinity
+	(*DomainConfig)(nil),        // 27: wave.v1.domain.DomainConfig
 }
 var file_wave_v1_domain_config_proto_depIdxs = []int32{
 	1,  // 0: wave.v1.domain.SystemConfig.architecture:type_name -> wave.v1.domain.Arch
@@ -1549,21 +2066,30 @@ var file_wave_v1_domain_config_proto_depIdxs = []int32{
 	8,  // 7: wave.v1.domain.StorageDevice.storage_type:type_name -> wave.v1.domain.StorageType
 	9,  // 8: wave.v1.domain.StorageDevice.storage_bus:type_name -> wave.v1.domain.StorageBus
 	10, // 9: wave.v1.domain.NetworkDevice.network_bus:type_name -> wave.v1.domain.NetworkBus
-	0,  // 10: wave.v1.domain.DomainConfig.state:type_name -> wave.v1.domain.DomainState
-	11, // 11: wave.v1.domain.DomainConfig.system_config:type_name -> wave.v1.domain.SystemConfig
-	12, // 12: wave.v1.domain.DomainConfig.firmware_config:type_name -> wave.v1.domain.FirmwareConfig
-	13, // 13: wave.v1.domain.DomainConfig.resource_config:type_name -> wave.v1.domain.ResourceConfig
-	14, // 14: wave.v1.domain.DomainConfig.video_devices:type_name -> wave.v1.domain.VideoDevice
-	15, // 15: wave.v1.domain.DomainConfig.video_adapters:type_name -> wave.v1.domain.VideoAdapter
-	17, // 16: wave.v1.domain.DomainConfig.input_devices:type_name -> wave.v1.domain.InputDevice
-	16, // 17: wave.v1.domain.DomainConfig.serial_devices:type_name -> wave.v1.domain.SerialDevice
-	18, // 18: wave.v1.domain.DomainConfig.storage_devices:type_name -> wave.v1.domain.StorageDevice
-	19, // 19: wave.v1.domain.DomainConfig.network_devices:type_name -> wave.v1.domain.NetworkDevice
-	20, // [20:20] is the sub-list for method output_type
-	20, // [20:20] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	11, // 10: wave.v1.domain.AntiAffinity.mode:type_name -> wave.v1.domain.ConstraintMode
+	11, // 11: wave.v1.domain.SpreadConstraint.mode:type_name -> wave.v1.domain.ConstraintMode
+	12, // 12: wave.v1.domain.SelectorRequirement.operator:type_name -> wave.v1.domain.SelectorOperator
+	24, // 13: wave.v1.domain.PreferredSelector.requirements:type_name -> wave.v1.domain.SelectorRequirement
+	24, // 14: wave.v1.domain.NodeAffinity.required:type_name -> wave.v1.domain.SelectorRequirement
+	25, // 15: wave.v1.domain.NodeAffinity.preferred:type_name -> wave.v1.domain.PreferredSelector
+	0,  // 16: wave.v1.domain.DomainConfig.state:type_name -> wave.v1.domain.DomainState
+	13, // 17: wave.v1.domain.DomainConfig.system_config:type_name -> wave.v1.domain.SystemConfig
+	14, // 18: wave.v1.domain.DomainConfig.firmware_config:type_name -> wave.v1.domain.FirmwareConfig
+	15, // 19: wave.v1.domain.DomainConfig.resource_config:type_name -> wave.v1.domain.ResourceConfig
+	16, // 20: wave.v1.domain.DomainConfig.video_devices:type_name -> wave.v1.domain.VideoDevice
+	17, // 21: wave.v1.domain.DomainConfig.video_adapters:type_name -> wave.v1.domain.VideoAdapter
+	19, // 22: wave.v1.domain.DomainConfig.input_devices:type_name -> wave.v1.domain.InputDevice
+	18, // 23: wave.v1.domain.DomainConfig.serial_devices:type_name -> wave.v1.domain.SerialDevice
+	20, // 24: wave.v1.domain.DomainConfig.storage_devices:type_name -> wave.v1.domain.StorageDevice
+	21, // 25: wave.v1.domain.DomainConfig.network_devices:type_name -> wave.v1.domain.NetworkDevice
+	22, // 26: wave.v1.domain.DomainConfig.anti_affinity:type_name -> wave.v1.domain.AntiAffinity
+	23, // 27: wave.v1.domain.DomainConfig.spread_constraints:type_name -> wave.v1.domain.SpreadConstraint
+	26, // 28: wave.v1.domain.DomainConfig.node_affinity:type_name -> wave.v1.domain.NodeAffinity
+	29, // [29:29] is the sub-list for method output_type
+	29, // [29:29] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_wave_v1_domain_config_proto_init() }
@@ -1681,6 +2207,66 @@ func file_wave_v1_domain_config_proto_init() {
 			}
 		}
 		file_wave_v1_domain_config_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*AntiAffinity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wave_v1_domain_config_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*SpreadConstraint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wave_v1_domain_config_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*SelectorRequirement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wave_v1_domain_config_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*PreferredSelector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wave_v1_domain_config_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*NodeAffinity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wave_v1_domain_config_proto_msgTypes[14].Exporter = func(v any, i int) any {
 			switch v := v.(*DomainConfig); i {
 			case 0:
 				return &v.state
@@ -1698,8 +2284,8 @@ func file_wave_v1_domain_config_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_wave_v1_domain_config_proto_rawDesc,
-			NumEnums:      11,
-			NumMessages:   10,
+			NumEnums:      13,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -39,6 +39,8 @@ const (
 	DomainServiceStatProcedure = "/wave.v1.domain.DomainService/Stat"
 	// DomainServiceListProcedure is the fully-qualified name of the DomainService's List RPC.
 	DomainServiceListProcedure = "/wave.v1.domain.DomainService/List"
+	// DomainServiceStatusProcedure is the fully-qualified name of the DomainService's Status RPC.
+	DomainServiceStatusProcedure = "/wave.v1.domain.DomainService/Status"
 	// DomainServiceCreateProcedure is the fully-qualified name of the DomainService's Create RPC.
 	DomainServiceCreateProcedure = "/wave.v1.domain.DomainService/Create"
 	// DomainServiceUpdateProcedure is the fully-qualified name of the DomainService's Update RPC.
@@ -57,6 +59,7 @@ var (
 	domainServiceGetMethodDescriptor    = domainServiceServiceDescriptor.Methods().ByName("Get")
 	domainServiceStatMethodDescriptor   = domainServiceServiceDescriptor.Methods().ByName("Stat")
 	domainServiceListMethodDescriptor   = domainServiceServiceDescriptor.Methods().ByName("List")
+	domainServiceStatusMethodDescriptor = domainServiceServiceDescriptor.Methods().ByName("Status")
 	domainServiceCreateMethodDescriptor = domainServiceServiceDescriptor.Methods().ByName("Create")
 	domainServiceUpdateMethodDescriptor = domainServiceServiceDescriptor.Methods().ByName("Update")
 	domainServiceAttachMethodDescriptor = domainServiceServiceDescriptor.Methods().ByName("Attach")
@@ -69,6 +72,7 @@ type DomainServiceClient interface {
 	Get(context.Context, *connect.Request[domain.GetRequest]) (*connect.Response[domain.GetResponse], error)
 	Stat(context.Context, *connect.Request[domain.StatRequest]) (*connect.Response[domain.StatResponse], error)
 	List(context.Context, *connect.Request[domain.ListRequest]) (*connect.Response[domain.ListResponse], error)
+	Status(context.Context, *connect.Request[domain.StatusRequest]) (*connect.Response[domain.StatusResponse], error)
 	Create(context.Context, *connect.Request[domain.CreateRequest]) (*connect.Response[domain.CreateResponse], error)
 	Update(context.Context, *connect.Request[domain.UpdateRequest]) (*connect.Response[domain.UpdateResponse], error)
 	Attach(context.Context, *connect.Request[domain.AttachRequest]) (*connect.Response[domain.AttachResponse], error)
@@ -104,6 +108,12 @@ func NewDomainServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(domainServiceListMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		status: connect.NewClient[domain.StatusRequest, domain.StatusResponse](
+			httpClient,
+			baseURL+DomainServiceStatusProcedure,
+			connect.WithSchema(domainServiceStatusMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		create: connect.NewClient[domain.CreateRequest, domain.CreateResponse](
 			httpClient,
 			baseURL+DomainServiceCreateProcedure,
@@ -142,6 +152,7 @@ type domainServiceClient struct {
 	get    *connect.Client[domain.GetRequest, domain.GetResponse]
 	stat   *connect.Client[domain.StatRequest, domain.StatResponse]
 	list   *connect.Client[domain.ListRequest, domain.ListResponse]
+	status *connect.Client[domain.StatusRequest, domain.StatusResponse]
 	create *connect.Client[domain.CreateRequest, domain.CreateResponse]
 	update *connect.Client[domain.UpdateRequest, domain.UpdateResponse]
 	attach *connect.Client[domain.AttachRequest, domain.AttachResponse]
@@ -164,6 +175,11 @@ func (c *domainServiceClient) List(ctx context.Context, req *connect.Request[dom
 	return c.list.CallUnary(ctx, req)
 }
 
+// Status calls wave.v1.domain.DomainService.Status.
+func (c *domainServiceClient) Status(ctx context.Context, req *connect.Request[domain.StatusRequest]) (*connect.Response[domain.StatusResponse], error) {
+	return c.status.CallUnary(ctx, req)
+}
+
 // Create calls wave.v1.domain.DomainService.Create.
 func (c *domainServiceClient) Create(ctx context.Context, req *connect.Request[domain.CreateRequest]) (*connect.Response[domain.CreateResponse], error) {
 	return c.create.CallUnary(ctx, req)
@@ -194,6 +210,7 @@ type DomainServiceHandler interface {
 	Get(context.Context, *connect.Request[domain.GetRequest]) (*connect.Response[domain.GetResponse], error)
 	Stat(context.Context, *connect.Request[domain.StatRequest]) (*connect.Response[domain.StatResponse], error)
 	List(context.Context, *connect.Request[domain.ListRequest]) (*connect.Response[domain.ListResponse], error)
+	Status(context.Context, *connect.Request[domain.StatusRequest]) (*connect.Response[domain.StatusResponse], error)
 	Create(context.Context, *connect.Request[domain.CreateRequest]) (*connect.Response[domain.CreateResponse], error)
 	Update(context.Context, *connect.Request[domain.UpdateRequest]) (*connect.Response[domain.UpdateResponse], error)
 	Attach(context.Context, *connect.Request[domain.AttachRequest]) (*connect.Response[domain.AttachResponse], error)
@@ -225,6 +242,12 @@ func NewDomainServiceHandler(svc DomainServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(domainServiceListMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	domainServiceStatusHandler := connect.NewUnaryHandler(
+		DomainServiceStatusProcedure,
+		svc.Status,
+		connect.WithSchema(domainServiceStatusMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	domainServiceCreateHandler := connect.NewUnaryHandler(
 		DomainServiceCreateProcedure,
 		svc.Create,
@@ -263,6 +286,8 @@ func NewDomainServiceHandler(svc DomainServiceHandler, opts ...connect.HandlerOp
 			domainServiceStatHandler.ServeHTTP(w, r)
 		case DomainServiceListProcedure:
 			domainServiceListHandler.ServeHTTP(w, r)
+		case DomainServiceStatusProcedure:
+			domainServiceStatusHandler.ServeHTTP(w, r)
 		case DomainServiceCreateProcedure:
 			domainServiceCreateHandler.ServeHTTP(w, r)
 		case DomainServiceUpdateProcedure:
@@ -294,6 +319,10 @@ func (UnimplementedDomainServiceHandler) List(context.Context, *connect.Request[
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.domain.DomainService.List is not implemented"))
 }
 
+func (UnimplementedDomainServiceHandler) Status(context.Context, *connect.Request[domain.StatusRequest]) (*connect.Response[domain.StatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.domain.DomainService.Status is not implemented"))
+}
+
 func (UnimplementedDomainServiceHandler) Create(context.Context, *connect.Request[domain.CreateRequest]) (*connect.Response[domain.CreateResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.domain.DomainService.Create is not implemented"))
 }
@@ -37,19 +37,32 @@ const (
 	NodeServiceGetProcedure = "/wave.v1.node.NodeService/Get"
 	// NodeServiceListProcedure is the fully-qualified name of the NodeService's List RPC.
 	NodeServiceListProcedure = "/wave.v1.node.NodeService/List"
+	// NodeServiceGetLeaderProcedure is the fully-qualified name of the NodeService's GetLeader RPC.
+	NodeServiceGetLeaderProcedure = "/wave.v1.node.NodeService/GetLeader"
+	// NodeServiceStepDownProcedure is the fully-qualified name of the NodeService's StepDown RPC.
+	NodeServiceStepDownProcedure = "/wave.v1.node.NodeService/StepDown"
+	// NodeServiceReloadConfigProcedure is the fully-qualified name of the NodeService's ReloadConfig
+	// RPC.
+	NodeServiceReloadConfigProcedure = "/wave.v1.node.NodeService/ReloadConfig"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
 var (
-	nodeServiceServiceDescriptor    = node.File_wave_v1_node_service_proto.Services().ByName("NodeService")
-	nodeServiceGetMethodDescriptor  = nodeServiceServiceDescriptor.Methods().ByName("Get")
-	nodeServiceListMethodDescriptor = nodeServiceServiceDescriptor.Methods().ByName("List")
+	nodeServiceServiceDescriptor            = node.File_wave_v1_node_service_proto.Services().ByName("NodeService")
+	nodeServiceGetMethodDescriptor          = nodeServiceServiceDescriptor.Methods().ByName("Get")
+	nodeServiceListMethodDescriptor         = nodeServiceServiceDescriptor.Methods().ByName("List")
+	nodeServiceGetLeaderMethodDescriptor    = nodeServiceServiceDescriptor.Methods().ByName("GetLeader")
+	nodeServiceStepDownMethodDescriptor     = nodeServiceServiceDescriptor.Methods().ByName("StepDown")
+	nodeServiceReloadConfigMethodDescriptor = nodeServiceServiceDescriptor.Methods().ByName("ReloadConfig")
 )
 
 // NodeServiceClient is a client for the wave.v1.node.NodeService service.
 type NodeServiceClient interface {
 	Get(context.Context, *connect.Request[node.GetRequest]) (*connect.Response[node.GetResponse], error)
 	List(context.Context, *connect.Request[node.ListRequest]) (*connect.Response[node.ListResponse], error)
+	GetLeader(context.Context, *connect.Request[node.GetLeaderRequest]) (*connect.Response[node.GetLeaderResponse], error)
+	StepDown(context.Context, *connect.Request[node.StepDownRequest]) (*connect.Response[node.StepDownResponse], error)
+	ReloadConfig(context.Context, *connect.Request[node.ReloadConfigRequest]) (*connect.Response[node.ReloadConfigResponse], error)
 }
 
 // NewNodeServiceClient constructs a client for the wave.v1.node.NodeService service. By default, it
@@ -74,13 +87,34 @@ func NewNodeServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(nodeServiceListMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		getLeader: connect.NewClient[node.GetLeaderRequest, node.GetLeaderResponse](
+			httpClient,
+			baseURL+NodeServiceGetLeaderProcedure,
+			connect.WithSchema(nodeServiceGetLeaderMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		stepDown: connect.NewClient[node.StepDownRequest, node.StepDownResponse](
+			httpClient,
+			baseURL+NodeServiceStepDownProcedure,
+			connect.WithSchema(nodeServiceStepDownMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		reloadConfig: connect.NewClient[node.ReloadConfigRequest, node.ReloadConfigResponse](
+			httpClient,
+			baseURL+NodeServiceReloadConfigProcedure,
+			connect.WithSchema(nodeServiceReloadConfigMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // nodeServiceClient implements NodeServiceClient.
 type nodeServiceClient struct {
-	get  *connect.Client[node.GetRequest, node.GetResponse]
-	list *connect.Client[node.ListRequest, node.ListResponse]
+	get          *connect.Client[node.GetRequest, node.GetResponse]
+	list         *connect.Client[node.ListRequest, node.ListResponse]
+	getLeader    *connect.Client[node.GetLeaderRequest, node.GetLeaderResponse]
+	stepDown     *connect.Client[node.StepDownRequest, node.StepDownResponse]
+	reloadConfig *connect.Client[node.ReloadConfigRequest, node.ReloadConfigResponse]
 }
 
 // Get calls wave.v1.node.NodeService.Get.
@@ -93,10 +127,28 @@ func (c *nodeServiceClient) List(ctx context.Context, req *connect.Request[node.
 	return c.list.CallUnary(ctx, req)
 }
 
+// GetLeader calls wave.v1.node.NodeService.GetLeader.
+func (c *nodeServiceClient) GetLeader(ctx context.Context, req *connect.Request[node.GetLeaderRequest]) (*connect.Response[node.GetLeaderResponse], error) {
+	return c.getLeader.CallUnary(ctx, req)
+}
+
+// StepDown calls wave.v1.node.NodeService.StepDown.
+func (c *nodeServiceClient) StepDown(ctx context.Context, req *connect.Request[node.StepDownRequest]) (*connect.Response[node.StepDownResponse], error) {
+	return c.stepDown.CallUnary(ctx, req)
+}
+
+// ReloadConfig calls wave.v1.node.NodeService.ReloadConfig.
+func (c *nodeServiceClient) ReloadConfig(ctx context.Context, req *connect.Request[node.ReloadConfigRequest]) (*connect.Response[node.ReloadConfigResponse], error) {
+	return c.reloadConfig.CallUnary(ctx, req)
+}
+
 // NodeServiceHandler is an implementation of the wave.v1.node.NodeService service.
 type NodeServiceHandler interface {
 	Get(context.Context, *connect.Request[node.GetRequest]) (*connect.Response[node.GetResponse], error)
 	List(context.Context, *connect.Request[node.ListRequest]) (*connect.Response[node.ListResponse], error)
+	GetLeader(context.Context, *connect.Request[node.GetLeaderRequest]) (*connect.Response[node.GetLeaderResponse], error)
+	StepDown(context.Context, *connect.Request[node.StepDownRequest]) (*connect.Response[node.StepDownResponse], error)
+	ReloadConfig(context.Context, *connect.Request[node.ReloadConfigRequest]) (*connect.Response[node.ReloadConfigResponse], error)
 }
 
 // NewNodeServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -117,12 +169,36 @@ func NewNodeServiceHandler(svc NodeServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(nodeServiceListMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	nodeServiceGetLeaderHandler := connect.NewUnaryHandler(
+		NodeServiceGetLeaderProcedure,
+		svc.GetLeader,
+		connect.WithSchema(nodeServiceGetLeaderMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceStepDownHandler := connect.NewUnaryHandler(
+		NodeServiceStepDownProcedure,
+		svc.StepDown,
+		connect.WithSchema(nodeServiceStepDownMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	nodeServiceReloadConfigHandler := connect.NewUnaryHandler(
+		NodeServiceReloadConfigProcedure,
+		svc.ReloadConfig,
+		connect.WithSchema(nodeServiceReloadConfigMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/wave.v1.node.NodeService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case NodeServiceGetProcedure:
 			nodeServiceGetHandler.ServeHTTP(w, r)
 		case NodeServiceListProcedure:
 			nodeServiceListHandler.ServeHTTP(w, r)
+		case NodeServiceGetLeaderProcedure:
+			nodeServiceGetLeaderHandler.ServeHTTP(w, r)
+		case NodeServiceStepDownProcedure:
+			nodeServiceStepDownHandler.ServeHTTP(w, r)
+		case NodeServiceReloadConfigProcedure:
+			nodeServiceReloadConfigHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -139,3 +215,15 @@ func (UnimplementedNodeServiceHandler) Get(context.Context, *connect.Request[nod
 func (UnimplementedNodeServiceHandler) List(context.Context, *connect.Request[node.ListRequest]) (*connect.Response[node.ListResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.node.NodeService.List is not implemented"))
 }
+
+func (UnimplementedNodeServiceHandler) GetLeader(context.Context, *connect.Request[node.GetLeaderRequest]) (*connect.Response[node.GetLeaderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.node.NodeService.GetLeader is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) StepDown(context.Context, *connect.Request[node.StepDownRequest]) (*connect.Response[node.StepDownResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.node.NodeService.StepDown is not implemented"))
+}
+
+func (UnimplementedNodeServiceHandler) ReloadConfig(context.Context, *connect.Request[node.ReloadConfigRequest]) (*connect.Response[node.ReloadConfigResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.node.NodeService.ReloadConfig is not implemented"))
+}
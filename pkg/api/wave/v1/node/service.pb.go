@@ -26,7 +26,7 @@ var file_wave_v1_node_service_proto_rawDesc = []byte{
 	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x77, 0x61,
 	0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x1a, 0x1a, 0x77, 0x61, 0x76, 0x65,
 	0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x8c, 0x01, 0x0a, 0x0b, 0x4e, 0x6f, 0x64, 0x65, 0x53,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x82, 0x03, 0x0a, 0x0b, 0x4e, 0x6f, 0x64, 0x65, 0x53,
 	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3c, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x18, 0x2e,
 	0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x47, 0x65, 0x74,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76,
@@ -35,25 +35,52 @@ var file_wave_v1_node_service_proto_rawDesc = []byte{
 	0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76,
 	0x31, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x25, 0x5a, 0x23, 0x63, 0x74, 0x68, 0x75, 0x6c, 0x2e, 0x69,
-	0x6f, 0x2f, 0x63, 0x74, 0x68, 0x75, 0x6c, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f,
-	0x77, 0x61, 0x76, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x12, 0x1e, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x6e, 0x6f, 0x64,
+	0x65, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x6e, 0x6f, 0x64,
+	0x65, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x08, 0x53, 0x74, 0x65, 0x70, 0x44, 0x6f, 0x77,
+	0x6e, 0x12, 0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x6e, 0x6f, 0x64, 0x65,
+	0x2e, 0x53, 0x74, 0x65, 0x70, 0x44, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1e, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+	0x53, 0x74, 0x65, 0x70, 0x44, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x57, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x21, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x6e, 0x6f, 0x64,
+	0x65, 0x2e, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x25, 0x5a, 0x23, 0x63,
+	0x74, 0x68, 0x75, 0x6c, 0x2e, 0x69, 0x6f, 0x2f, 0x63, 0x74, 0x68, 0x75, 0x6c, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x77, 0x61, 0x76, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x6f,
+	0x64, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_wave_v1_node_service_proto_goTypes = []any{
-	(*GetRequest)(nil),   // 0: wave.v1.node.GetRequest
-	(*ListRequest)(nil),  // 1: wave.v1.node.ListRequest
-	(*GetResponse)(nil),  // 2: wave.v1.node.GetResponse
-	(*ListResponse)(nil), // 3: wave.v1.node.ListResponse
+	(*GetRequest)(nil),           // 0: wave.v1.node.GetRequest
+	(*ListRequest)(nil),          // 1: wave.v1.node.ListRequest
+	(*GetLeaderRequest)(nil),     // 2: wave.v1.node.GetLeaderRequest
+	(*StepDownRequest)(nil),      // 3: wave.v1.node.StepDownRequest
+	(*ReloadConfigRequest)(nil),  // 4: wave.v1.node.ReloadConfigRequest
+	(*GetResponse)(nil),          // 5: wave.v1.node.GetResponse
+	(*ListResponse)(nil),         // 6: wave.v1.node.ListResponse
+	(*GetLeaderResponse)(nil),    // 7: wave.v1.node.GetLeaderResponse
+	(*StepDownResponse)(nil),     // 8: wave.v1.node.StepDownResponse
+	(*ReloadConfigResponse)(nil), // 9: wave.v1.node.ReloadConfigResponse
 }
 var file_wave_v1_node_service_proto_depIdxs = []int32{
 	0, // 0: wave.v1.node.NodeService.Get:input_type -> wave.v1.node.GetRequest
 	1, // 1: wave.v1.node.NodeService.List:input_type -> wave.v1.node.ListRequest
-	2, // 2: wave.v1.node.NodeService.Get:output_type -> wave.v1.node.GetResponse
-	3, // 3: wave.v1.node.NodeService.List:output_type -> wave.v1.node.ListResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
+	2, // 2: wave.v1.node.NodeService.GetLeader:input_type -> wave.v1.node.GetLeaderRequest
+	3, // 3: wave.v1.node.NodeService.StepDown:input_type -> wave.v1.node.StepDownRequest
+	4, // 4: wave.v1.node.NodeService.ReloadConfig:input_type -> wave.v1.node.ReloadConfigRequest
+	5, // 5: wave.v1.node.NodeService.Get:output_type -> wave.v1.node.GetResponse
+	6, // 6: wave.v1.node.NodeService.List:output_type -> wave.v1.node.ListResponse
+	7, // 7: wave.v1.node.NodeService.GetLeader:output_type -> wave.v1.node.GetLeaderResponse
+	8, // 8: wave.v1.node.NodeService.StepDown:output_type -> wave.v1.node.StepDownResponse
+	9, // 9: wave.v1.node.NodeService.ReloadConfig:output_type -> wave.v1.node.ReloadConfigResponse
+	5, // [5:10] is the sub-list for method output_type
+	0, // [0:5] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
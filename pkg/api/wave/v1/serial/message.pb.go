@@ -364,6 +364,187 @@ func (x *ListResponse) GetSerials() map[string]*Serial {
 	return nil
 }
 
+type SyncStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node        string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Key         string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Error       string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts    int64  `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError   int64  `protobuf:"varint,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastSuccess int64  `protobuf:"varint,6,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+}
+
+func (x *SyncStatus) Reset() {
+	*x = SyncStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wave_v1_serial_message_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncStatus) ProtoMessage() {}
+
+func (x *SyncStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_wave_v1_serial_message_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncStatus.ProtoReflect.Descriptor instead.
+func (*SyncStatus) Descriptor() ([]byte, []int) {
+	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SyncStatus) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *SyncStatus) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SyncStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SyncStatus) GetAttempts() int64 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *SyncStatus) GetLastError() int64 {
+	if x != nil {
+		return x.LastError
+	}
+	return 0
+}
+
+func (x *SyncStatus) GetLastSuccess() int64 {
+	if x != nil {
+		return x.LastSuccess
+	}
+	return 0
+}
+
+type StatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *StatusRequest) Reset() {
+	*x = StatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wave_v1_serial_message_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusRequest) ProtoMessage() {}
+
+func (x *StatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wave_v1_serial_message_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status []*SyncStatus `protobuf:"bytes,1,rep,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wave_v1_serial_message_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wave_v1_serial_message_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StatusResponse) GetStatus() []*SyncStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
 type CreateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -375,7 +556,7 @@ type CreateRequest struct {
 func (x *CreateRequest) Reset() {
 	*x = CreateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_wave_v1_serial_message_proto_msgTypes[7]
+		mi := &file_wave_v1_serial_message_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -388,7 +569,7 @@ func (x *CreateRequest) String() string {
 func (*CreateRequest) ProtoMessage() {}
 
 func (x *CreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_wave_v1_serial_message_proto_msgTypes[7]
+	mi := &file_wave_v1_serial_message_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -401,7 +582,7 @@ func (x *CreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
 func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{7}
+	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CreateRequest) GetConfig() *SerialConfig {
@@ -422,7 +603,7 @@ type CreateResponse struct {
 func (x *CreateResponse) Reset() {
 	*x = CreateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_wave_v1_serial_message_proto_msgTypes[8]
+		mi := &file_wave_v1_serial_message_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -435,7 +616,7 @@ func (x *CreateResponse) String() string {
 func (*CreateResponse) ProtoMessage() {}
 
 func (x *CreateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_wave_v1_serial_message_proto_msgTypes[8]
+	mi := &file_wave_v1_serial_message_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -448,7 +629,7 @@ func (x *CreateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
 func (*CreateResponse) Descriptor() ([]byte, []int) {
-	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{8}
+	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CreateResponse) GetId() string {
@@ -470,7 +651,7 @@ type UpdateRequest struct {
 func (x *UpdateRequest) Reset() {
 	*x = UpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_wave_v1_serial_message_proto_msgTypes[9]
+		mi := &file_wave_v1_serial_message_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -483,7 +664,7 @@ func (x *UpdateRequest) String() string {
 func (*UpdateRequest) ProtoMessage() {}
 
 func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_wave_v1_serial_message_proto_msgTypes[9]
+	mi := &file_wave_v1_serial_message_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -496,7 +677,7 @@ func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{9}
+	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UpdateRequest) GetId() string {
@@ -522,7 +703,7 @@ type UpdateResponse struct {
 func (x *UpdateResponse) Reset() {
 	*x = UpdateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_wave_v1_serial_message_proto_msgTypes[10]
+		mi := &file_wave_v1_serial_message_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -535,7 +716,7 @@ func (x *UpdateResponse) String() string {
 func (*UpdateResponse) ProtoMessage() {}
 
 func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_wave_v1_serial_message_proto_msgTypes[10]
+	mi := &file_wave_v1_serial_message_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -548,7 +729,7 @@ func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateResponse.ProtoReflect.Descriptor instead.
 func (*UpdateResponse) Descriptor() ([]byte, []int) {
-	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{10}
+	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{13}
 }
 
 type DeleteRequest struct {
@@ -562,7 +743,7 @@ type DeleteRequest struct {
 func (x *DeleteRequest) Reset() {
 	*x = DeleteRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_wave_v1_serial_message_proto_msgTypes[11]
+		mi := &file_wave_v1_serial_message_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -575,7 +756,7 @@ func (x *DeleteRequest) String() string {
 func (*DeleteRequest) ProtoMessage() {}
 
 func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_wave_v1_serial_message_proto_msgTypes[11]
+	mi := &file_wave_v1_serial_message_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -588,7 +769,7 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{11}
+	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DeleteRequest) GetId() string {
@@ -607,7 +788,7 @@ type DeleteResponse struct {
 func (x *DeleteResponse) Reset() {
 	*x = DeleteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_wave_v1_serial_message_proto_msgTypes[12]
+		mi := &file_wave_v1_serial_message_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -620,7 +801,7 @@ func (x *DeleteResponse) String() string {
 func (*DeleteResponse) ProtoMessage() {}
 
 func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_wave_v1_serial_message_proto_msgTypes[12]
+	mi := &file_wave_v1_serial_message_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -633,7 +814,7 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{12}
+	return file_wave_v1_serial_message_proto_rawDescGZIP(), []int{15}
 }
 
 var File_wave_v1_serial_message_proto protoreflect.FileDescriptor
@@ -674,7 +855,24 @@ var file_wave_v1_serial_message_proto_rawDesc = []byte{
 	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31,
 	0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x45, 0x0a, 0x0d, 0x43, 0x72, 0x65,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa6, 0x01, 0x0a, 0x0a, 0x53, 0x79,
+	0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x22, 0x1f, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x44, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x45, 0x0a, 0x0d, 0x43, 0x72, 0x65,
 	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x6f,
 	0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x61, 0x76,
 	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x69,
@@ -708,7 +906,7 @@ func file_wave_v1_serial_message_proto_rawDescGZIP() []byte {
 	return file_wave_v1_serial_message_proto_rawDescData
 }
 
-var file_wave_v1_serial_message_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_wave_v1_serial_message_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_wave_v1_serial_message_proto_goTypes = []any{
 	(*Serial)(nil),          // 0: wave.v1.serial.Serial
 	(*GetRequest)(nil),      // 1: wave.v1.serial.GetRequest
@@ -717,27 +915,31 @@ var file_wave_v1_serial_message_proto_goTypes = []any{
 	(*ConnectResponse)(nil), // 4: wave.v1.serial.ConnectResponse
 	(*ListRequest)(nil),     // 5: wave.v1.serial.ListRequest
 	(*ListResponse)(nil),    // 6: wave.v1.serial.ListResponse
-	(*CreateRequest)(nil),   // 7: wave.v1.serial.CreateRequest
-	(*CreateResponse)(nil),  // 8: wave.v1.serial.CreateResponse
-	(*UpdateRequest)(nil),   // 9: wave.v1.serial.UpdateRequest
-	(*UpdateResponse)(nil),  // 10: wave.v1.serial.UpdateResponse
-	(*DeleteRequest)(nil),   // 11: wave.v1.serial.DeleteRequest
-	(*DeleteResponse)(nil),  // 12: wave.v1.serial.DeleteResponse
-	nil,                     // 13: wave.v1.serial.ListResponse.SerialsEntry
-	(*SerialConfig)(nil),    // 14: wave.v1.serial.SerialConfig
+	(*SyncStatus)(nil),      // 7: wave.v1.serial.SyncStatus
+	(*StatusRequest)(nil),   // 8: wave.v1.serial.StatusRequest
+	(*StatusResponse)(nil),  // 9: wave.v1.serial.StatusResponse
+	(*CreateRequest)(nil),   // 10: wave.v1.serial.CreateRequest
+	(*CreateResponse)(nil),  // 11: wave.v1.serial.CreateResponse
+	(*UpdateRequest)(nil),   // 12: wave.v1.serial.UpdateRequest
+	(*UpdateResponse)(nil),  // 13: wave.v1.serial.UpdateResponse
+	(*DeleteRequest)(nil),   // 14: wave.v1.serial.DeleteRequest
+	(*DeleteResponse)(nil),  // 15: wave.v1.serial.DeleteResponse
+	nil,                     // 16: wave.v1.serial.ListResponse.SerialsEntry
+	(*SerialConfig)(nil),    // 17: wave.v1.serial.SerialConfig
 }
 var file_wave_v1_serial_message_proto_depIdxs = []int32{
-	14, // 0: wave.v1.serial.Serial.config:type_name -> wave.v1.serial.SerialConfig
+	17, // 0: wave.v1.serial.Serial.config:type_name -> wave.v1.serial.SerialConfig
 	0,  // 1: wave.v1.serial.GetResponse.serial:type_name -> wave.v1.serial.Serial
-	13, // 2: wave.v1.serial.ListResponse.serials:type_name -> wave.v1.serial.ListResponse.SerialsEntry
-	14, // 3: wave.v1.serial.CreateRequest.config:type_name -> wave.v1.serial.SerialConfig
-	14, // 4: wave.v1.serial.UpdateRequest.config:type_name -> wave.v1.serial.SerialConfig
-	0,  // 5: wave.v1.serial.ListResponse.SerialsEntry.value:type_name -> wave.v1.serial.Serial
-	6,  // [6:6] is the sub-list for method output_type
-	6,  // [6:6] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	16, // 2: wave.v1.serial.ListResponse.serials:type_name -> wave.v1.serial.ListResponse.SerialsEntry
+	7,  // 3: wave.v1.serial.StatusResponse.status:type_name -> wave.v1.serial.SyncStatus
+	17, // 4: wave.v1.serial.CreateRequest.config:type_name -> wave.v1.serial.SerialConfig
+	17, // 5: wave.v1.serial.UpdateRequest.config:type_name -> wave.v1.serial.SerialConfig
+	0,  // 6: wave.v1.serial.ListResponse.SerialsEntry.value:type_name -> wave.v1.serial.Serial
+	7,  // [7:7] is the sub-list for method output_type
+	7,  // [7:7] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_wave_v1_serial_message_proto_init() }
@@ -832,7 +1034,7 @@ func file_wave_v1_serial_message_proto_init() {
 			}
 		}
 		file_wave_v1_serial_message_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*CreateRequest); i {
+			switch v := v.(*SyncStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -844,7 +1046,7 @@ func file_wave_v1_serial_message_proto_init() {
 			}
 		}
 		file_wave_v1_serial_message_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*CreateResponse); i {
+			switch v := v.(*StatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -856,7 +1058,7 @@ func file_wave_v1_serial_message_proto_init() {
 			}
 		}
 		file_wave_v1_serial_message_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*UpdateRequest); i {
+			switch v := v.(*StatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -868,7 +1070,7 @@ func file_wave_v1_serial_message_proto_init() {
 			}
 		}
 		file_wave_v1_serial_message_proto_msgTypes[10].Exporter = func(v any, i int) any {
-			switch v := v.(*UpdateResponse); i {
+			switch v := v.(*CreateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -880,7 +1082,7 @@ func file_wave_v1_serial_message_proto_init() {
 			}
 		}
 		file_wave_v1_serial_message_proto_msgTypes[11].Exporter = func(v any, i int) any {
-			switch v := v.(*DeleteRequest); i {
+			switch v := v.(*CreateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -892,6 +1094,42 @@ func file_wave_v1_serial_message_proto_init() {
 			}
 		}
 		file_wave_v1_serial_message_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wave_v1_serial_message_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wave_v1_serial_message_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wave_v1_serial_message_proto_msgTypes[15].Exporter = func(v any, i int) any {
 			switch v := v.(*DeleteResponse); i {
 			case 0:
 				return &v.state
@@ -910,7 +1148,7 @@ func file_wave_v1_serial_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_wave_v1_serial_message_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
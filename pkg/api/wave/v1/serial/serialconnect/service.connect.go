@@ -39,6 +39,8 @@ const (
 	SerialServiceConnectProcedure = "/wave.v1.serial.SerialService/Connect"
 	// SerialServiceListProcedure is the fully-qualified name of the SerialService's List RPC.
 	SerialServiceListProcedure = "/wave.v1.serial.SerialService/List"
+	// SerialServiceStatusProcedure is the fully-qualified name of the SerialService's Status RPC.
+	SerialServiceStatusProcedure = "/wave.v1.serial.SerialService/Status"
 	// SerialServiceCreateProcedure is the fully-qualified name of the SerialService's Create RPC.
 	SerialServiceCreateProcedure = "/wave.v1.serial.SerialService/Create"
 	// SerialServiceUpdateProcedure is the fully-qualified name of the SerialService's Update RPC.
@@ -53,6 +55,7 @@ var (
 	serialServiceGetMethodDescriptor     = serialServiceServiceDescriptor.Methods().ByName("Get")
 	serialServiceConnectMethodDescriptor = serialServiceServiceDescriptor.Methods().ByName("Connect")
 	serialServiceListMethodDescriptor    = serialServiceServiceDescriptor.Methods().ByName("List")
+	serialServiceStatusMethodDescriptor  = serialServiceServiceDescriptor.Methods().ByName("Status")
 	serialServiceCreateMethodDescriptor  = serialServiceServiceDescriptor.Methods().ByName("Create")
 	serialServiceUpdateMethodDescriptor  = serialServiceServiceDescriptor.Methods().ByName("Update")
 	serialServiceDeleteMethodDescriptor  = serialServiceServiceDescriptor.Methods().ByName("Delete")
@@ -63,6 +66,7 @@ type SerialServiceClient interface {
 	Get(context.Context, *connect.Request[serial.GetRequest]) (*connect.Response[serial.GetResponse], error)
 	Connect(context.Context) *connect.BidiStreamForClient[serial.ConnectRequest, serial.ConnectResponse]
 	List(context.Context, *connect.Request[serial.ListRequest]) (*connect.Response[serial.ListResponse], error)
+	Status(context.Context, *connect.Request[serial.StatusRequest]) (*connect.Response[serial.StatusResponse], error)
 	Create(context.Context, *connect.Request[serial.CreateRequest]) (*connect.Response[serial.CreateResponse], error)
 	Update(context.Context, *connect.Request[serial.UpdateRequest]) (*connect.Response[serial.UpdateResponse], error)
 	Delete(context.Context, *connect.Request[serial.DeleteRequest]) (*connect.Response[serial.DeleteResponse], error)
@@ -96,6 +100,12 @@ func NewSerialServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(serialServiceListMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		status: connect.NewClient[serial.StatusRequest, serial.StatusResponse](
+			httpClient,
+			baseURL+SerialServiceStatusProcedure,
+			connect.WithSchema(serialServiceStatusMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		create: connect.NewClient[serial.CreateRequest, serial.CreateResponse](
 			httpClient,
 			baseURL+SerialServiceCreateProcedure,
@@ -122,6 +132,7 @@ type serialServiceClient struct {
 	get     *connect.Client[serial.GetRequest, serial.GetResponse]
 	connect *connect.Client[serial.ConnectRequest, serial.ConnectResponse]
 	list    *connect.Client[serial.ListRequest, serial.ListResponse]
+	status  *connect.Client[serial.StatusRequest, serial.StatusResponse]
 	create  *connect.Client[serial.CreateRequest, serial.CreateResponse]
 	update  *connect.Client[serial.UpdateRequest, serial.UpdateResponse]
 	delete  *connect.Client[serial.DeleteRequest, serial.DeleteResponse]
@@ -142,6 +153,11 @@ func (c *serialServiceClient) List(ctx context.Context, req *connect.Request[ser
 	return c.list.CallUnary(ctx, req)
 }
 
+// Status calls wave.v1.serial.SerialService.Status.
+func (c *serialServiceClient) Status(ctx context.Context, req *connect.Request[serial.StatusRequest]) (*connect.Response[serial.StatusResponse], error) {
+	return c.status.CallUnary(ctx, req)
+}
+
 // Create calls wave.v1.serial.SerialService.Create.
 func (c *serialServiceClient) Create(ctx context.Context, req *connect.Request[serial.CreateRequest]) (*connect.Response[serial.CreateResponse], error) {
 	return c.create.CallUnary(ctx, req)
@@ -162,6 +178,7 @@ type SerialServiceHandler interface {
 	Get(context.Context, *connect.Request[serial.GetRequest]) (*connect.Response[serial.GetResponse], error)
 	Connect(context.Context, *connect.BidiStream[serial.ConnectRequest, serial.ConnectResponse]) error
 	List(context.Context, *connect.Request[serial.ListRequest]) (*connect.Response[serial.ListResponse], error)
+	Status(context.Context, *connect.Request[serial.StatusRequest]) (*connect.Response[serial.StatusResponse], error)
 	Create(context.Context, *connect.Request[serial.CreateRequest]) (*connect.Response[serial.CreateResponse], error)
 	Update(context.Context, *connect.Request[serial.UpdateRequest]) (*connect.Response[serial.UpdateResponse], error)
 	Delete(context.Context, *connect.Request[serial.DeleteRequest]) (*connect.Response[serial.DeleteResponse], error)
@@ -191,6 +208,12 @@ func NewSerialServiceHandler(svc SerialServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(serialServiceListMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	serialServiceStatusHandler := connect.NewUnaryHandler(
+		SerialServiceStatusProcedure,
+		svc.Status,
+		connect.WithSchema(serialServiceStatusMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	serialServiceCreateHandler := connect.NewUnaryHandler(
 		SerialServiceCreateProcedure,
 		svc.Create,
@@ -217,6 +240,8 @@ func NewSerialServiceHandler(svc SerialServiceHandler, opts ...connect.HandlerOp
 			serialServiceConnectHandler.ServeHTTP(w, r)
 		case SerialServiceListProcedure:
 			serialServiceListHandler.ServeHTTP(w, r)
+		case SerialServiceStatusProcedure:
+			serialServiceStatusHandler.ServeHTTP(w, r)
 		case SerialServiceCreateProcedure:
 			serialServiceCreateHandler.ServeHTTP(w, r)
 		case SerialServiceUpdateProcedure:
@@ -244,6 +269,10 @@ func (UnimplementedSerialServiceHandler) List(context.Context, *connect.Request[
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.serial.SerialService.List is not implemented"))
 }
 
+func (UnimplementedSerialServiceHandler) Status(context.Context, *connect.Request[serial.StatusRequest]) (*connect.Response[serial.StatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.serial.SerialService.Status is not implemented"))
+}
+
 func (UnimplementedSerialServiceHandler) Create(context.Context, *connect.Request[serial.CreateRequest]) (*connect.Response[serial.CreateResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.serial.SerialService.Create is not implemented"))
 }
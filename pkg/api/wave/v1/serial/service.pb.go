@@ -26,7 +26,7 @@ var file_wave_v1_serial_service_proto_rawDesc = []byte{
 	0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e,
 	0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x1a, 0x1c,
 	0x77, 0x61, 0x76, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2f, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xc9, 0x03, 0x0a,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x94, 0x04, 0x0a,
 	0x0d, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x40,
 	0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x1a, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e,
 	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
@@ -41,55 +41,64 @@ var file_wave_v1_serial_service_proto_rawDesc = []byte{
 	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76,
 	0x31, 0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x12, 0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x72, 0x69,
-	0x61, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x1a, 0x1e, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x72, 0x69, 0x61,
-	0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x49, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x77,
-	0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x77, 0x61,
-	0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x55, 0x70, 0x64,
+	0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x49, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x77,
+	0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x77, 0x61,
+	0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x43, 0x72, 0x65,
 	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a,
-	0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x27, 0x5a, 0x25, 0x63, 0x74, 0x68, 0x75,
-	0x6c, 0x2e, 0x69, 0x6f, 0x2f, 0x63, 0x74, 0x68, 0x75, 0x6c, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x77, 0x61, 0x76, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x72, 0x69, 0x61,
-	0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x12, 0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x42, 0x27, 0x5a, 0x25, 0x63, 0x74, 0x68, 0x75, 0x6c, 0x2e, 0x69, 0x6f, 0x2f,
+	0x63, 0x74, 0x68, 0x75, 0x6c, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x77, 0x61,
+	0x76, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_wave_v1_serial_service_proto_goTypes = []any{
 	(*GetRequest)(nil),      // 0: wave.v1.serial.GetRequest
 	(*ConnectRequest)(nil),  // 1: wave.v1.serial.ConnectRequest
 	(*ListRequest)(nil),     // 2: wave.v1.serial.ListRequest
-	(*CreateRequest)(nil),   // 3: wave.v1.serial.CreateRequest
-	(*UpdateRequest)(nil),   // 4: wave.v1.serial.UpdateRequest
-	(*DeleteRequest)(nil),   // 5: wave.v1.serial.DeleteRequest
-	(*GetResponse)(nil),     // 6: wave.v1.serial.GetResponse
-	(*ConnectResponse)(nil), // 7: wave.v1.serial.ConnectResponse
-	(*ListResponse)(nil),    // 8: wave.v1.serial.ListResponse
-	(*CreateResponse)(nil),  // 9: wave.v1.serial.CreateResponse
-	(*UpdateResponse)(nil),  // 10: wave.v1.serial.UpdateResponse
-	(*DeleteResponse)(nil),  // 11: wave.v1.serial.DeleteResponse
+	(*StatusRequest)(nil),   // 3: wave.v1.serial.StatusRequest
+	(*CreateRequest)(nil),   // 4: wave.v1.serial.CreateRequest
+	(*UpdateRequest)(nil),   // 5: wave.v1.serial.UpdateRequest
+	(*DeleteRequest)(nil),   // 6: wave.v1.serial.DeleteRequest
+	(*GetResponse)(nil),     // 7: wave.v1.serial.GetResponse
+	(*ConnectResponse)(nil), // 8: wave.v1.serial.ConnectResponse
+	(*ListResponse)(nil),    // 9: wave.v1.serial.ListResponse
+	(*StatusResponse)(nil),  // 10: wave.v1.serial.StatusResponse
+	(*CreateResponse)(nil),  // 11: wave.v1.serial.CreateResponse
+	(*UpdateResponse)(nil),  // 12: wave.v1.serial.UpdateResponse
+	(*DeleteResponse)(nil),  // 13: wave.v1.serial.DeleteResponse
 }
 var file_wave_v1_serial_service_proto_depIdxs = []int32{
 	0,  // 0: wave.v1.serial.SerialService.Get:input_type -> wave.v1.serial.GetRequest
 	1,  // 1: wave.v1.serial.SerialService.Connect:input_type -> wave.v1.serial.ConnectRequest
 	2,  // 2: wave.v1.serial.SerialService.List:input_type -> wave.v1.serial.ListRequest
-	3,  // 3: wave.v1.serial.SerialService.Create:input_type -> wave.v1.serial.CreateRequest
-	4,  // 4: wave.v1.serial.SerialService.Update:input_type -> wave.v1.serial.UpdateRequest
-	5,  // 5: wave.v1.serial.SerialService.Delete:input_type -> wave.v1.serial.DeleteRequest
-	6,  // 6: wave.v1.serial.SerialService.Get:output_type -> wave.v1.serial.GetResponse
-	7,  // 7: wave.v1.serial.SerialService.Connect:output_type -> wave.v1.serial.ConnectResponse
-	8,  // 8: wave.v1.serial.SerialService.List:output_type -> wave.v1.serial.ListResponse
-	9,  // 9: wave.v1.serial.SerialService.Create:output_type -> wave.v1.serial.CreateResponse
-	10, // 10: wave.v1.serial.SerialService.Update:output_type -> wave.v1.serial.UpdateResponse
-	11, // 11: wave.v1.serial.SerialService.Delete:output_type -> wave.v1.serial.DeleteResponse
-	6,  // [6:12] is the sub-list for method output_type
-	0,  // [0:6] is the sub-list for method input_type
+	3,  // 3: wave.v1.serial.SerialService.Status:input_type -> wave.v1.serial.StatusRequest
+	4,  // 4: wave.v1.serial.SerialService.Create:input_type -> wave.v1.serial.CreateRequest
+	5,  // 5: wave.v1.serial.SerialService.Update:input_type -> wave.v1.serial.UpdateRequest
+	6,  // 6: wave.v1.serial.SerialService.Delete:input_type -> wave.v1.serial.DeleteRequest
+	7,  // 7: wave.v1.serial.SerialService.Get:output_type -> wave.v1.serial.GetResponse
+	8,  // 8: wave.v1.serial.SerialService.Connect:output_type -> wave.v1.serial.ConnectResponse
+	9,  // 9: wave.v1.serial.SerialService.List:output_type -> wave.v1.serial.ListResponse
+	10, // 10: wave.v1.serial.SerialService.Status:output_type -> wave.v1.serial.StatusResponse
+	11, // 11: wave.v1.serial.SerialService.Create:output_type -> wave.v1.serial.CreateResponse
+	12, // 12: wave.v1.serial.SerialService.Update:output_type -> wave.v1.serial.UpdateResponse
+	13, // 13: wave.v1.serial.SerialService.Delete:output_type -> wave.v1.serial.DeleteResponse
+	7,  // [7:14] is the sub-list for method output_type
+	0,  // [0:7] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
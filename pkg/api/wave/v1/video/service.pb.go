@@ -26,7 +26,7 @@ var file_wave_v1_video_service_proto_rawDesc = []byte{
 	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x77,
 	0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x1a, 0x1b, 0x77, 0x61,
 	0x76, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x2f, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xbc, 0x03, 0x0a, 0x0c, 0x56, 0x69,
+	0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x85, 0x04, 0x0a, 0x0c, 0x56, 0x69,
 	0x64, 0x65, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x03, 0x47, 0x65,
 	0x74, 0x12, 0x19, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x76, 0x69, 0x64, 0x65,
 	0x6f, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x77,
@@ -41,54 +41,63 @@ var file_wave_v1_video_service_proto_rawDesc = []byte{
 	0x65, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
 	0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x2e, 0x4c,
 	0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a,
-	0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x76, 0x69, 0x64, 0x65, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x76, 0x69, 0x64, 0x65, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
 	0x12, 0x1c, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x76, 0x69, 0x64, 0x65, 0x6f,
-	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
-	0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x2e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x47, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x77, 0x61, 0x76, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x47, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x77, 0x61, 0x76, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x26, 0x5a, 0x24, 0x63, 0x74, 0x68, 0x75,
-	0x6c, 0x2e, 0x69, 0x6f, 0x2f, 0x63, 0x74, 0x68, 0x75, 0x6c, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x77, 0x61, 0x76, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x69, 0x64, 0x65, 0x6f,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x31, 0x2e, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x12, 0x1c, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x76, 0x69, 0x64,
+	0x65, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x77, 0x61, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x76, 0x69, 0x64, 0x65, 0x6f,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x42, 0x26, 0x5a, 0x24, 0x63, 0x74, 0x68, 0x75, 0x6c, 0x2e, 0x69, 0x6f, 0x2f, 0x63, 0x74,
+	0x68, 0x75, 0x6c, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x77, 0x61, 0x76, 0x65,
+	0x2f, 0x76, 0x31, 0x2f, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var file_wave_v1_video_service_proto_goTypes = []any{
 	(*GetRequest)(nil),      // 0: wave.v1.video.GetRequest
 	(*ConnectRequest)(nil),  // 1: wave.v1.video.ConnectRequest
 	(*ListRequest)(nil),     // 2: wave.v1.video.ListRequest
-	(*CreateRequest)(nil),   // 3: wave.v1.video.CreateRequest
-	(*UpdateRequest)(nil),   // 4: wave.v1.video.UpdateRequest
-	(*DeleteRequest)(nil),   // 5: wave.v1.video.DeleteRequest
-	(*GetResponse)(nil),     // 6: wave.v1.video.GetResponse
-	(*ConnectResponse)(nil), // 7: wave.v1.video.ConnectResponse
-	(*ListResponse)(nil),    // 8: wave.v1.video.ListResponse
-	(*CreateResponse)(nil),  // 9: wave.v1.video.CreateResponse
-	(*UpdateResponse)(nil),  // 10: wave.v1.video.UpdateResponse
-	(*DeleteResponse)(nil),  // 11: wave.v1.video.DeleteResponse
+	(*StatusRequest)(nil),   // 3: wave.v1.video.StatusRequest
+	(*CreateRequest)(nil),   // 4: wave.v1.video.CreateRequest
+	(*UpdateRequest)(nil),   // 5: wave.v1.video.UpdateRequest
+	(*DeleteRequest)(nil),   // 6: wave.v1.video.DeleteRequest
+	(*GetResponse)(nil),     // 7: wave.v1.video.GetResponse
+	(*ConnectResponse)(nil), // 8: wave.v1.video.ConnectResponse
+	(*ListResponse)(nil),    // 9: wave.v1.video.ListResponse
+	(*StatusResponse)(nil),  // 10: wave.v1.video.StatusResponse
+	(*CreateResponse)(nil),  // 11: wave.v1.video.CreateResponse
+	(*UpdateResponse)(nil),  // 12: wave.v1.video.UpdateResponse
+	(*DeleteResponse)(nil),  // 13: wave.v1.video.DeleteResponse
 }
 var file_wave_v1_video_service_proto_depIdxs = []int32{
 	0,  // 0: wave.v1.video.VideoService.Get:input_type -> wave.v1.video.GetRequest
 	1,  // 1: wave.v1.video.VideoService.Connect:input_type -> wave.v1.video.ConnectRequest
 	2,  // 2: wave.v1.video.VideoService.List:input_type -> wave.v1.video.ListRequest
-	3,  // 3: wave.v1.video.VideoService.Create:input_type -> wave.v1.video.CreateRequest
-	4,  // 4: wave.v1.video.VideoService.Update:input_type -> wave.v1.video.UpdateRequest
-	5,  // 5: wave.v1.video.VideoService.Delete:input_type -> wave.v1.video.DeleteRequest
-	6,  // 6: wave.v1.video.VideoService.Get:output_type -> wave.v1.video.GetResponse
-	7,  // 7: wave.v1.video.VideoService.Connect:output_type -> wave.v1.video.ConnectResponse
-	8,  // 8: wave.v1.video.VideoService.List:output_type -> wave.v1.video.ListResponse
-	9,  // 9: wave.v1.video.VideoService.Create:output_type -> wave.v1.video.CreateResponse
-	10, // 10: wave.v1.video.VideoService.Update:output_type -> wave.v1.video.UpdateResponse
-	11, // 11: wave.v1.video.VideoService.Delete:output_type -> wave.v1.video.DeleteResponse
-	6,  // [6:12] is the sub-list for method output_type
-	0,  // [0:6] is the sub-list for method input_type
+	3,  // 3: wave.v1.video.VideoService.Status:input_type -> wave.v1.video.StatusRequest
+	4,  // 4: wave.v1.video.VideoService.Create:input_type -> wave.v1.video.CreateRequest
+	5,  // 5: wave.v1.video.VideoService.Update:input_type -> wave.v1.video.UpdateRequest
+	6,  // 6: wave.v1.video.VideoService.Delete:input_type -> wave.v1.video.DeleteRequest
+	7,  // 7: wave.v1.video.VideoService.Get:output_type -> wave.v1.video.GetResponse
+	8,  // 8: wave.v1.video.VideoService.Connect:output_type -> wave.v1.video.ConnectResponse
+	9,  // 9: wave.v1.video.VideoService.List:output_type -> wave.v1.video.ListResponse
+	10, // 10: wave.v1.video.VideoService.Status:output_type -> wave.v1.video.StatusResponse
+	11, // 11: wave.v1.video.VideoService.Create:output_type -> wave.v1.video.CreateResponse
+	12, // 12: wave.v1.video.VideoService.Update:output_type -> wave.v1.video.UpdateResponse
+	13, // 13: wave.v1.video.VideoService.Delete:output_type -> wave.v1.video.DeleteResponse
+	7,  // [7:14] is the sub-list for method output_type
+	0,  // [0:7] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
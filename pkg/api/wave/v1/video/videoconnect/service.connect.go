@@ -39,6 +39,8 @@ const (
 	VideoServiceConnectProcedure = "/wave.v1.video.VideoService/Connect"
 	// VideoServiceListProcedure is the fully-qualified name of the VideoService's List RPC.
 	VideoServiceListProcedure = "/wave.v1.video.VideoService/List"
+	// VideoServiceStatusProcedure is the fully-qualified name of the VideoService's Status RPC.
+	VideoServiceStatusProcedure = "/wave.v1.video.VideoService/Status"
 	// VideoServiceCreateProcedure is the fully-qualified name of the VideoService's Create RPC.
 	VideoServiceCreateProcedure = "/wave.v1.video.VideoService/Create"
 	// VideoServiceUpdateProcedure is the fully-qualified name of the VideoService's Update RPC.
@@ -53,6 +55,7 @@ var (
 	videoServiceGetMethodDescriptor     = videoServiceServiceDescriptor.Methods().ByName("Get")
 	videoServiceConnectMethodDescriptor = videoServiceServiceDescriptor.Methods().ByName("Connect")
 	videoServiceListMethodDescriptor    = videoServiceServiceDescriptor.Methods().ByName("List")
+	videoServiceStatusMethodDescriptor  = videoServiceServiceDescriptor.Methods().ByName("Status")
 	videoServiceCreateMethodDescriptor  = videoServiceServiceDescriptor.Methods().ByName("Create")
 	videoServiceUpdateMethodDescriptor  = videoServiceServiceDescriptor.Methods().ByName("Update")
 	videoServiceDeleteMethodDescriptor  = videoServiceServiceDescriptor.Methods().ByName("Delete")
@@ -63,6 +66,7 @@ type VideoServiceClient interface {
 	Get(context.Context, *connect.Request[video.GetRequest]) (*connect.Response[video.GetResponse], error)
 	Connect(context.Context) *connect.BidiStreamForClient[video.ConnectRequest, video.ConnectResponse]
 	List(context.Context, *connect.Request[video.ListRequest]) (*connect.Response[video.ListResponse], error)
+	Status(context.Context, *connect.Request[video.StatusRequest]) (*connect.Response[video.StatusResponse], error)
 	Create(context.Context, *connect.Request[video.CreateRequest]) (*connect.Response[video.CreateResponse], error)
 	Update(context.Context, *connect.Request[video.UpdateRequest]) (*connect.Response[video.UpdateResponse], error)
 	Delete(context.Context, *connect.Request[video.DeleteRequest]) (*connect.Response[video.DeleteResponse], error)
@@ -96,6 +100,12 @@ func NewVideoServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(videoServiceListMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		status: connect.NewClient[video.StatusRequest, video.StatusResponse](
+			httpClient,
+			baseURL+VideoServiceStatusProcedure,
+			connect.WithSchema(videoServiceStatusMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		create: connect.NewClient[video.CreateRequest, video.CreateResponse](
 			httpClient,
 			baseURL+VideoServiceCreateProcedure,
@@ -122,6 +132,7 @@ type videoServiceClient struct {
 	get     *connect.Client[video.GetRequest, video.GetResponse]
 	connect *connect.Client[video.ConnectRequest, video.ConnectResponse]
 	list    *connect.Client[video.ListRequest, video.ListResponse]
+	status  *connect.Client[video.StatusRequest, video.StatusResponse]
 	create  *connect.Client[video.CreateRequest, video.CreateResponse]
 	update  *connect.Client[video.UpdateRequest, video.UpdateResponse]
 	delete  *connect.Client[video.DeleteRequest, video.DeleteResponse]
@@ -142,6 +153,11 @@ func (c *videoServiceClient) List(ctx context.Context, req *connect.Request[vide
 	return c.list.CallUnary(ctx, req)
 }
 
+// Status calls wave.v1.video.VideoService.Status.
+func (c *videoServiceClient) Status(ctx context.Context, req *connect.Request[video.StatusRequest]) (*connect.Response[video.StatusResponse], error) {
+	return c.status.CallUnary(ctx, req)
+}
+
 // Create calls wave.v1.video.VideoService.Create.
 func (c *videoServiceClient) Create(ctx context.Context, req *connect.Request[video.CreateRequest]) (*connect.Response[video.CreateResponse], error) {
 	return c.create.CallUnary(ctx, req)
@@ -162,6 +178,7 @@ type VideoServiceHandler interface {
 	Get(context.Context, *connect.Request[video.GetRequest]) (*connect.Response[video.GetResponse], error)
 	Connect(context.Context, *connect.BidiStream[video.ConnectRequest, video.ConnectResponse]) error
 	List(context.Context, *connect.Request[video.ListRequest]) (*connect.Response[video.ListResponse], error)
+	Status(context.Context, *connect.Request[video.StatusRequest]) (*connect.Response[video.StatusResponse], error)
 	Create(context.Context, *connect.Request[video.CreateRequest]) (*connect.Response[video.CreateResponse], error)
 	Update(context.Context, *connect.Request[video.UpdateRequest]) (*connect.Response[video.UpdateResponse], error)
 	Delete(context.Context, *connect.Request[video.DeleteRequest]) (*connect.Response[video.DeleteResponse], error)
@@ -191,6 +208,12 @@ func NewVideoServiceHandler(svc VideoServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(videoServiceListMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	videoServiceStatusHandler := connect.NewUnaryHandler(
+		VideoServiceStatusProcedure,
+		svc.Status,
+		connect.WithSchema(videoServiceStatusMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	videoServiceCreateHandler := connect.NewUnaryHandler(
 		VideoServiceCreateProcedure,
 		svc.Create,
@@ -217,6 +240,8 @@ func NewVideoServiceHandler(svc VideoServiceHandler, opts ...connect.HandlerOpti
 			videoServiceConnectHandler.ServeHTTP(w, r)
 		case VideoServiceListProcedure:
 			videoServiceListHandler.ServeHTTP(w, r)
+		case VideoServiceStatusProcedure:
+			videoServiceStatusHandler.ServeHTTP(w, r)
 		case VideoServiceCreateProcedure:
 			videoServiceCreateHandler.ServeHTTP(w, r)
 		case VideoServiceUpdateProcedure:
@@ -244,6 +269,10 @@ func (UnimplementedVideoServiceHandler) List(context.Context, *connect.Request[v
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.video.VideoService.List is not implemented"))
 }
 
+func (UnimplementedVideoServiceHandler) Status(context.Context, *connect.Request[video.StatusRequest]) (*connect.Response[video.StatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.video.VideoService.Status is not implemented"))
+}
+
 func (UnimplementedVideoServiceHandler) Create(context.Context, *connect.Request[video.CreateRequest]) (*connect.Response[video.CreateResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("wave.v1.video.VideoService.Create is not implemented"))
 }
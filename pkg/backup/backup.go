@@ -0,0 +1,242 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// backup exports and imports the cthul resource definitions.
+// Resources are stored in a portable json archive, configs are decoded from protobuf into their json
+// representation, which keeps the archive readable and independent of the binary protobuf encoding.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"cthul.io/cthul/pkg/api/granit/v1/disk"
+	"cthul.io/cthul/pkg/api/proton/v1/inter"
+	"cthul.io/cthul/pkg/api/wave/v1/domain"
+	"cthul.io/cthul/pkg/api/wave/v1/serial"
+	"cthul.io/cthul/pkg/api/wave/v1/video"
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/keyspace"
+	"cthul.io/cthul/pkg/migration"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ARCHIVE_VERSION is the version of the archive format. It is increased on incompatible format changes.
+const ARCHIVE_VERSION = 1
+
+// Archive holds a snapshot of all cthul resources.
+type Archive struct {
+	// Version holds the archive format version.
+	Version int64 `json:"version"`
+	// Schema holds the database layout version of the cluster the archive was created on.
+	Schema int64 `json:"schema"`
+	// Created holds the creation time of the archive.
+	Created time.Time `json:"created"`
+	// Resources maps the resource kind (e.g. "wave/domain") to the resources of this kind by id.
+	Resources map[string]map[string]*Entry `json:"resources"`
+}
+
+// Entry holds a single resource. The node field (the node the resource currently runs on) is runtime state
+// and therefore not part of the archive, the resource is relocated to its reqnode by the operators after restore.
+type Entry struct {
+	Reqnode string          `json:"reqnode,omitempty"`
+	Config  json.RawMessage `json:"config"`
+	Cluster json.RawMessage `json:"cluster,omitempty"`
+}
+
+// kind describes a resource type that is part of the archive.
+type kind struct {
+	layout keyspace.Resource
+	// cluster holds the cluster field of replicated resources (nil if the resource has no cluster).
+	cluster *keyspace.Field
+	// newConfig and newCluster create empty messages used to decode the stored values.
+	newConfig  func() proto.Message
+	newCluster func() proto.Message
+}
+
+// kinds holds all resource types that are part of the archive.
+var kinds = map[string]kind{
+	"wave/domain": {
+		layout:    keyspace.WaveDomain,
+		newConfig: func() proto.Message { return &domain.DomainConfig{} },
+	},
+	"wave/serial": {
+		layout:    keyspace.WaveSerial,
+		newConfig: func() proto.Message { return &serial.SerialConfig{} },
+	},
+	"wave/video": {
+		layout:    keyspace.WaveVideo,
+		newConfig: func() proto.Message { return &video.VideoConfig{} },
+	},
+	"granit/disk": {
+		layout:     keyspace.GranitDisk.Resource,
+		cluster:    &keyspace.GranitDisk.Cluster,
+		newConfig:  func() proto.Message { return &disk.DiskConfig{} },
+		newCluster: func() proto.Message { return &disk.DiskCluster{} },
+	},
+	"proton/inter": {
+		layout:     keyspace.ProtonInter.Resource,
+		cluster:    &keyspace.ProtonInter.Cluster,
+		newConfig:  func() proto.Message { return &inter.InterConfig{} },
+		newCluster: func() proto.Message { return &inter.InterCluster{} },
+	},
+}
+
+// Kinds returns the sorted names of all resource kinds that are part of the archive.
+func Kinds() []string {
+	names := []string{}
+	for name := range kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// record holds the decoded state of a single resource.
+type record struct {
+	reqnode string
+	config  proto.Message
+	cluster proto.Message
+}
+
+// load reads all resources of the kind from the database.
+func (k *kind) load(ctx context.Context, client db.Client) (map[string]*record, error) {
+	records := map[string]*record{}
+
+	reqnodes, err := client.GetRange(ctx, k.layout.Reqnode.Prefix())
+	if err != nil {
+		return nil, fmt.Errorf("fetching reqnode: %w", err)
+	}
+	clusters := map[string]string{}
+	if k.cluster != nil {
+		clusters, err = client.GetRange(ctx, k.cluster.Prefix())
+		if err != nil {
+			return nil, fmt.Errorf("fetching cluster: %w", err)
+		}
+	}
+	configs, err := client.GetRange(ctx, k.layout.Config.Prefix())
+	if err != nil {
+		return nil, fmt.Errorf("fetching config: %w", err)
+	}
+
+	for key, rawConfig := range configs {
+		id := k.layout.Config.Id(key)
+		r := &record{reqnode: reqnodes[k.layout.Reqnode.Key(id)], config: k.newConfig()}
+		if err := proto.Unmarshal([]byte(rawConfig), r.config); err != nil {
+			return nil, fmt.Errorf("parsing config of '%s': %w", id, err)
+		}
+		if k.cluster != nil {
+			r.cluster = k.newCluster()
+			if err := proto.Unmarshal([]byte(clusters[k.cluster.Key(id)]), r.cluster); err != nil {
+				return nil, fmt.Errorf("parsing cluster of '%s': %w", id, err)
+			}
+		}
+		records[id] = r
+	}
+	return records, nil
+}
+
+// encode converts the record to its archive representation.
+func (k *kind) encode(r *record) (*Entry, error) {
+	entry := &Entry{Reqnode: r.reqnode}
+	var err error
+	entry.Config, err = protojson.Marshal(r.config)
+	if err != nil {
+		return nil, fmt.Errorf("encoding config: %w", err)
+	}
+	if r.cluster != nil {
+		entry.Cluster, err = protojson.Marshal(r.cluster)
+		if err != nil {
+			return nil, fmt.Errorf("encoding cluster: %w", err)
+		}
+	}
+	return entry, nil
+}
+
+// decode converts the archive representation to a record.
+func (k *kind) decode(entry *Entry) (*record, error) {
+	r := &record{reqnode: entry.Reqnode, config: k.newConfig()}
+	if err := protojson.Unmarshal(entry.Config, r.config); err != nil {
+		return nil, fmt.Errorf("decoding config: %w", err)
+	}
+	if k.cluster != nil {
+		r.cluster = k.newCluster()
+		if len(entry.Cluster) > 0 {
+			if err := protojson.Unmarshal(entry.Cluster, r.cluster); err != nil {
+				return nil, fmt.Errorf("decoding cluster: %w", err)
+			}
+		}
+	}
+	return r, nil
+}
+
+// Snapshot reads all cthul resources from the database and returns them as archive.
+// The snapshot is not taken atomically, resources modified while taking the snapshot may be partially captured.
+func Snapshot(ctx context.Context, client db.Client) (*Archive, error) {
+	schema, err := migration.Version(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &Archive{
+		Version:   ARCHIVE_VERSION,
+		Schema:    schema,
+		Created:   time.Now().UTC(),
+		Resources: map[string]map[string]*Entry{},
+	}
+	for name, k := range kinds {
+		records, err := k.load(ctx, client)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		entries := map[string]*Entry{}
+		for id, r := range records {
+			entries[id], err = k.encode(r)
+			if err != nil {
+				return nil, fmt.Errorf("encoding %s '%s': %w", name, id, err)
+			}
+		}
+		archive.Resources[name] = entries
+	}
+	return archive, nil
+}
+
+// Write encodes the archive to the writer.
+func Write(w io.Writer, archive *Archive) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(archive)
+}
+
+// Read decodes an archive from the reader.
+func Read(r io.Reader) (*Archive, error) {
+	archive := &Archive{}
+	if err := json.NewDecoder(r).Decode(archive); err != nil {
+		return nil, fmt.Errorf("decoding archive: %w", err)
+	}
+	if archive.Version != ARCHIVE_VERSION {
+		return nil, fmt.Errorf("unsupported archive version %d (expected %d)", archive.Version, ARCHIVE_VERSION)
+	}
+	return archive, nil
+}
@@ -0,0 +1,253 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"cthul.io/cthul/pkg/api/wave/v1/domain"
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/db/memdb"
+	"cthul.io/cthul/pkg/keyspace"
+	"google.golang.org/protobuf/proto"
+)
+
+// setDomain writes a domain config and its reqnode to the database.
+func setDomain(t *testing.T, client db.Client, id, reqnode string, config *domain.DomainConfig) {
+	t.Helper()
+	ctx := context.Background()
+	rawConfig, err := proto.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Set(ctx, keyspace.WaveDomain.Config.Key(id), string(rawConfig), 0)
+	if reqnode != "" {
+		client.Set(ctx, keyspace.WaveDomain.Reqnode.Key(id), reqnode, 0)
+	}
+}
+
+// getDomain reads a domain config from the database (nil if it does not exist).
+func getDomain(t *testing.T, client db.Client, id string) *domain.DomainConfig {
+	t.Helper()
+	rawConfig, err := client.Get(context.Background(), keyspace.WaveDomain.Config.Key(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rawConfig == "" {
+		return nil
+	}
+	config := &domain.DomainConfig{}
+	if err := proto.Unmarshal([]byte(rawConfig), config); err != nil {
+		t.Fatal(err)
+	}
+	return config
+}
+
+// snapshot creates an archive of the client and passes it through the archive encoding.
+func snapshot(t *testing.T, client db.Client) *Archive {
+	t.Helper()
+	archive, err := Snapshot(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffer := &bytes.Buffer{}
+	if err := Write(buffer, archive); err != nil {
+		t.Fatal(err)
+	}
+	archive, err = Read(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return archive
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	source := memdb.New()
+	defer source.Terminate(ctx)
+	setDomain(t, source, "a", "node-1", &domain.DomainConfig{Name: "a", State: domain.DomainState_DOMAIN_STATE_UP})
+	setDomain(t, source, "b", "", &domain.DomainConfig{Name: "b", Affinity: []string{"x"}})
+	archive := snapshot(t, source)
+
+	target := memdb.New()
+	defer target.Terminate(ctx)
+	changes, err := Restore(ctx, target, archive, Filter{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 || changes[0].Type != CHANGE_CREATE || changes[1].Type != CHANGE_CREATE {
+		t.Fatalf("expected two creations, got %v", changes)
+	}
+	for _, id := range []string{"a", "b"} {
+		if !proto.Equal(getDomain(t, source, id), getDomain(t, target, id)) {
+			t.Errorf("expected domain '%s' to be restored", id)
+		}
+	}
+	if reqnode, _ := target.Get(ctx, keyspace.WaveDomain.Reqnode.Key("a")); reqnode != "node-1" {
+		t.Errorf("expected reqnode 'node-1', got '%s'", reqnode)
+	}
+
+	// restoring the same archive again is a no-op.
+	changes, err = Restore(ctx, target, archive, Filter{}, false)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("expected no changes on repeated restore, got %v (err: %v)", changes, err)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      Filter
+		dryRun      bool
+		wantErr     string
+		wantChanges []string
+		// wantName holds the expected name of domain 'a' after the restore.
+		wantName string
+	}{
+		{
+			name:        "apply",
+			wantChanges: []string{"~ wave/domain/a", "+ wave/domain/b"},
+			wantName:    "archived",
+		},
+		{
+			name:        "dry run",
+			dryRun:      true,
+			wantChanges: []string{"~ wave/domain/a", "+ wave/domain/b"},
+			wantName:    "current",
+		},
+		{
+			name:        "filter by id",
+			filter:      Filter{Ids: []string{"b"}},
+			wantChanges: []string{"+ wave/domain/b"},
+			wantName:    "current",
+		},
+		{
+			name:        "filter by kind",
+			filter:      Filter{Kinds: []string{"wave/video"}},
+			wantChanges: []string{},
+			wantName:    "current",
+		},
+		{
+			name:     "unknown kind",
+			filter:   Filter{Kinds: []string{"wave/unknown"}},
+			wantErr:  "unknown resource kind",
+			wantName: "current",
+		},
+		{
+			name:     "id not in archive",
+			filter:   Filter{Ids: []string{"a", "missing"}},
+			wantErr:  "'missing' not found in archive",
+			wantName: "current",
+		},
+		{
+			name:     "id not in selected kind",
+			filter:   Filter{Kinds: []string{"wave/video"}, Ids: []string{"a"}},
+			wantErr:  "'a' not found in archive",
+			wantName: "current",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			source := memdb.New()
+			defer source.Terminate(ctx)
+			setDomain(t, source, "a", "node-1", &domain.DomainConfig{Name: "archived"})
+			setDomain(t, source, "b", "node-1", &domain.DomainConfig{Name: "b"})
+			archive := snapshot(t, source)
+
+			target := memdb.New()
+			defer target.Terminate(ctx)
+			setDomain(t, target, "a", "node-1", &domain.DomainConfig{Name: "current"})
+
+			changes, err := Restore(ctx, target, archive, tt.filter, tt.dryRun)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error '%s', got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []string{}
+			for _, change := range changes {
+				got = append(got, strings.Split(change.String(), "\n")[0])
+			}
+			if tt.wantErr == "" && !slices.Equal(got, tt.wantChanges) {
+				t.Errorf("expected changes %v, got %v", tt.wantChanges, got)
+			}
+			if name := getDomain(t, target, "a").GetName(); name != tt.wantName {
+				t.Errorf("expected domain name '%s', got '%s'", tt.wantName, name)
+			}
+		})
+	}
+}
+
+func TestRestoreDiff(t *testing.T) {
+	ctx := context.Background()
+	source := memdb.New()
+	defer source.Terminate(ctx)
+	setDomain(t, source, "a", "node-2", &domain.DomainConfig{
+		Name: "new", Title: "title", State: domain.DomainState_DOMAIN_STATE_UP,
+	})
+	archive := snapshot(t, source)
+
+	target := memdb.New()
+	defer target.Terminate(ctx)
+	setDomain(t, target, "a", "node-1", &domain.DomainConfig{Name: "old", Affinity: []string{"x"}})
+
+	changes, err := Restore(ctx, target, archive, Filter{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected one change, got %v", changes)
+	}
+
+	want := []FieldDiff{
+		{Field: "config.affinity", Before: `["x"]`, After: ""},
+		{Field: "config.name", Before: `"old"`, After: `"new"`},
+		{Field: "config.state", Before: "", After: `"DOMAIN_STATE_UP"`},
+		{Field: "config.title", Before: "", After: `"title"`},
+		{Field: "reqnode", Before: `"node-1"`, After: `"node-2"`},
+	}
+	if !slices.Equal(changes[0].Diffs, want) {
+		t.Errorf("expected diffs %+v, got %+v", want, changes[0].Diffs)
+	}
+	if !strings.Contains(changes[0].String(), `~ config.name: "old" -> "new"`) ||
+		!strings.Contains(changes[0].String(), `~ config.title: <unset> -> "title"`) {
+		t.Errorf("expected before and after values in the change output, got:\n%s", changes[0].String())
+	}
+}
+
+func TestRestoreNewerSchema(t *testing.T) {
+	ctx := context.Background()
+	client := memdb.New()
+	defer client.Terminate(ctx)
+
+	archive := &Archive{Version: ARCHIVE_VERSION, Schema: 2, Resources: map[string]map[string]*Entry{}}
+	if _, err := Restore(ctx, client, archive, Filter{}, true); err == nil {
+		t.Error("expected archive of a newer schema to be rejected")
+	}
+}
@@ -0,0 +1,335 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/migration"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+type CHANGE_TYPE string
+
+const (
+	CHANGE_CREATE CHANGE_TYPE = "create"
+	CHANGE_UPDATE CHANGE_TYPE = "update"
+)
+
+// Change describes the modification of a single resource performed by a restore.
+type Change struct {
+	Type CHANGE_TYPE
+	Kind string
+	Id   string
+	// Fields holds the names of the modified fields (e.g. "config.name", "reqnode").
+	Fields []string
+	// Diffs holds the before and after state of every modified field.
+	Diffs []FieldDiff
+}
+
+// FieldDiff describes the modification of a single top-level field of a resource.
+// The values are encoded as json (protojson for config and cluster fields), unset values are empty.
+type FieldDiff struct {
+	Field  string
+	Before string
+	After  string
+}
+
+func (c *Change) String() string {
+	builder := strings.Builder{}
+	switch c.Type {
+	case CHANGE_CREATE:
+		fmt.Fprintf(&builder, "+ %s/%s", c.Kind, c.Id)
+		for _, diff := range c.Diffs {
+			fmt.Fprintf(&builder, "\n    + %s: %s", diff.Field, diff.After)
+		}
+	default:
+		fmt.Fprintf(&builder, "~ %s/%s", c.Kind, c.Id)
+		for _, diff := range c.Diffs {
+			fmt.Fprintf(&builder, "\n    ~ %s: %s -> %s", diff.Field, unsetOr(diff.Before), unsetOr(diff.After))
+		}
+	}
+	return builder.String()
+}
+
+// unsetOr returns the value or a placeholder for unset values.
+func unsetOr(value string) string {
+	if value == "" {
+		return "<unset>"
+	}
+	return value
+}
+
+// Filter selects the resources that are restored. Empty lists select everything.
+type Filter struct {
+	Kinds []string
+	Ids   []string
+}
+
+func (f *Filter) match(kind, id string) bool {
+	if len(f.Kinds) > 0 && !slices.Contains(f.Kinds, kind) {
+		return false
+	}
+	if len(f.Ids) > 0 && !slices.Contains(f.Ids, id) {
+		return false
+	}
+	return true
+}
+
+// validate checks that every selected id is part of the archive (in one of the selected kinds).
+// This prevents that a mistyped id silently restores nothing.
+func (f *Filter) validate(archive *Archive) error {
+	errs := []error{}
+	for _, id := range f.Ids {
+		found := false
+		for name, resources := range archive.Resources {
+			if _, ok := resources[id]; ok && f.match(name, id) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("resource '%s' not found in archive", id))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Restore imports the resources of the archive that match the filter and returns the applied changes.
+// Resources that are identical to the database state are skipped, resources that exist in the database
+// but not in the archive are left untouched. If dryRun is set, the changes are only evaluated.
+func Restore(ctx context.Context, client db.Client, archive *Archive, filter Filter, dryRun bool) ([]*Change, error) {
+	for _, name := range filter.Kinds {
+		if _, ok := kinds[name]; !ok {
+			return nil, fmt.Errorf("unknown resource kind '%s'", name)
+		}
+	}
+	if err := filter.validate(archive); err != nil {
+		return nil, err
+	}
+
+	schema, err := migration.Version(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if archive.Schema > schema {
+		return nil, fmt.Errorf(
+			"archive was created on schema version %d, the database is on version %d; migrate the database first",
+			archive.Schema, schema,
+		)
+	}
+
+	names := []string{}
+	for name := range archive.Resources {
+		if _, ok := kinds[name]; !ok {
+			return nil, fmt.Errorf("unknown resource kind '%s' in archive", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	changes := []*Change{}
+	for _, name := range names {
+		k := kinds[name]
+		current, err := k.load(ctx, client)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+
+		ids := []string{}
+		for id := range archive.Resources[name] {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			if !filter.match(name, id) {
+				continue
+			}
+			desired, err := k.decode(archive.Resources[name][id])
+			if err != nil {
+				return nil, fmt.Errorf("decoding %s '%s': %w", name, id, err)
+			}
+			change, err := diff(name, id, current[id], desired)
+			if err != nil {
+				return changes, fmt.Errorf("comparing %s '%s': %w", name, id, err)
+			}
+			if change == nil {
+				continue
+			}
+			changes = append(changes, change)
+			if dryRun {
+				continue
+			}
+			if err := k.apply(ctx, client, id, desired); err != nil {
+				return changes, fmt.Errorf("restoring %s '%s': %w", name, id, err)
+			}
+		}
+	}
+	return changes, nil
+}
+
+// diff compares the current and the desired state of a resource, returns nil if they are identical.
+// Config and cluster are compared per top-level field of their protojson representation.
+func diff(kind, id string, current, desired *record) (*Change, error) {
+	change := &Change{Type: CHANGE_UPDATE, Kind: kind, Id: id, Fields: []string{}, Diffs: []FieldDiff{}}
+	if current == nil {
+		change.Type = CHANGE_CREATE
+		current = &record{}
+	}
+
+	configDiffs, err := diffMessage("config", current.config, desired.config)
+	if err != nil {
+		return nil, err
+	}
+	change.Diffs = append(change.Diffs, configDiffs...)
+	if current.reqnode != desired.reqnode {
+		change.Diffs = append(change.Diffs, FieldDiff{
+			Field: "reqnode", Before: quoteOrUnset(current.reqnode), After: quoteOrUnset(desired.reqnode),
+		})
+	}
+	if change.Type == CHANGE_CREATE || current.cluster != nil {
+		clusterDiffs, err := diffMessage("cluster", current.cluster, desired.cluster)
+		if err != nil {
+			return nil, err
+		}
+		change.Diffs = append(change.Diffs, clusterDiffs...)
+	}
+
+	if change.Type == CHANGE_UPDATE && len(change.Diffs) < 1 {
+		return nil, nil
+	}
+	for _, diff := range change.Diffs {
+		change.Fields = append(change.Fields, diff.Field)
+	}
+	return change, nil
+}
+
+// diffMessage compares the top-level fields of two messages and returns the differing fields
+// (named "<prefix>.<json field name>"). Nil messages are treated as empty messages.
+func diffMessage(prefix string, current, desired proto.Message) ([]FieldDiff, error) {
+	if current != nil && desired != nil && proto.Equal(current, desired) {
+		return nil, nil
+	}
+	currentFields, err := jsonFields(current)
+	if err != nil {
+		return nil, err
+	}
+	desiredFields, err := jsonFields(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for name := range currentFields {
+		names = append(names, name)
+	}
+	for name := range desiredFields {
+		if _, ok := currentFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	diffs := []FieldDiff{}
+	for _, name := range names {
+		if currentFields[name] != desiredFields[name] {
+			diffs = append(diffs, FieldDiff{
+				Field: prefix + "." + name, Before: currentFields[name], After: desiredFields[name],
+			})
+		}
+	}
+	return diffs, nil
+}
+
+// jsonFields encodes the message with protojson and returns the compact json value of every populated
+// top-level field. The output of protojson is not stable, the values are therefore compacted.
+func jsonFields(message proto.Message) (map[string]string, error) {
+	fields := map[string]string{}
+	if message == nil {
+		return fields, nil
+	}
+	rawMessage, err := protojson.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("encoding message: %w", err)
+	}
+	rawFields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(rawMessage, &rawFields); err != nil {
+		return nil, fmt.Errorf("decoding message: %w", err)
+	}
+	for name, rawField := range rawFields {
+		buffer := bytes.Buffer{}
+		if err := json.Compact(&buffer, rawField); err != nil {
+			return nil, fmt.Errorf("compacting field '%s': %w", name, err)
+		}
+		fields[name] = buffer.String()
+	}
+	return fields, nil
+}
+
+// quoteOrUnset returns the value as json string, unset values stay empty.
+func quoteOrUnset(value string) string {
+	if value == "" {
+		return ""
+	}
+	return strconv.Quote(value)
+}
+
+// apply atomically writes the record to the database.
+func (k *kind) apply(ctx context.Context, client db.Client, id string, r *record) error {
+	rawConfig, err := proto.Marshal(r.config)
+	if err != nil {
+		return fmt.Errorf("cannot serialize config: %w", err)
+	}
+	operations := []db.Operation{
+		{Type: db.OPERATION_SET, Key: k.layout.Config.Key(id), Value: string(rawConfig)},
+	}
+	if r.reqnode != "" {
+		operations = append(operations, db.Operation{
+			Type: db.OPERATION_SET, Key: k.layout.Reqnode.Key(id), Value: r.reqnode,
+		})
+	} else {
+		operations = append(operations, db.Operation{
+			Type: db.OPERATION_DELETE, Key: k.layout.Reqnode.Key(id),
+		})
+	}
+	if k.cluster != nil {
+		rawCluster, err := proto.Marshal(r.cluster)
+		if err != nil {
+			return fmt.Errorf("cannot serialize cluster: %w", err)
+		}
+		operations = append(operations, db.Operation{
+			Type: db.OPERATION_SET, Key: k.cluster.Key(id), Value: string(rawCluster),
+		})
+	}
+
+	_, err = client.Txn(ctx, nil, operations)
+	return err
+}
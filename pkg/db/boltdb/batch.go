@@ -0,0 +1,354 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package boltdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	// kvBucket holds all kvs, every kv is stored as entry.
+	kvBucket = []byte("kv")
+	// leaseBucket holds all leases, every lease is stored as lease.
+	leaseBucket = []byte("lease")
+	// leaseKvBucket indexes the kvs attached to a lease (key = lease id + kv key).
+	leaseKvBucket = []byte("lease_kv")
+	// metaBucket holds the revision and lease id counters.
+	metaBucket = []byte("meta")
+
+	revisionKey = []byte("revision")
+	leaseIdKey  = []byte("lease_id")
+)
+
+// entry holds a single kv in the store.
+type entry struct {
+	value       string
+	modRevision int64
+	// lease holds the id of the lease the kv is attached to. Zero means the kv never expires.
+	lease int64
+}
+
+// encode serializes the entry as modRevision (8 bytes) + lease (8 bytes) + value.
+func (e *entry) encode() []byte {
+	raw := make([]byte, 16+len(e.value))
+	binary.BigEndian.PutUint64(raw[0:8], uint64(e.modRevision))
+	binary.BigEndian.PutUint64(raw[8:16], uint64(e.lease))
+	copy(raw[16:], e.value)
+	return raw
+}
+
+func decodeEntry(raw []byte) (*entry, error) {
+	if len(raw) < 16 {
+		return nil, fmt.Errorf("malformed entry")
+	}
+	return &entry{
+		modRevision: int64(binary.BigEndian.Uint64(raw[0:8])),
+		lease:       int64(binary.BigEndian.Uint64(raw[8:16])),
+		value:       string(raw[16:]),
+	}, nil
+}
+
+// lease holds a single lease. Like in etcd, kvs written with a ttl are attached to an implicit lease.
+type lease struct {
+	ttl int64
+	// expiry holds the point in time the lease and all attached kvs expire.
+	expiry time.Time
+}
+
+// encode serializes the lease as ttl (8 bytes) + expiry in unix nanoseconds (8 bytes).
+func (l *lease) encode() []byte {
+	raw := make([]byte, 16)
+	binary.BigEndian.PutUint64(raw[0:8], uint64(l.ttl))
+	binary.BigEndian.PutUint64(raw[8:16], uint64(l.expiry.UnixNano()))
+	return raw
+}
+
+func decodeLease(raw []byte) (*lease, error) {
+	if len(raw) != 16 {
+		return nil, fmt.Errorf("malformed lease")
+	}
+	return &lease{
+		ttl:    int64(binary.BigEndian.Uint64(raw[0:8])),
+		expiry: time.Unix(0, int64(binary.BigEndian.Uint64(raw[8:16]))),
+	}, nil
+}
+
+func encodeId(id int64) []byte {
+	raw := make([]byte, 8)
+	binary.BigEndian.PutUint64(raw, uint64(id))
+	return raw
+}
+
+// batch wraps a single bbolt transaction and provides the kv primitives used by the client.
+// Modifications record their events on the batch, they are emitted by the client after the transaction committed.
+type batch struct {
+	tx  *bbolt.Tx
+	now time.Time
+
+	revision int64
+	leaseId  int64
+	events   []db.Event
+}
+
+// newBatch creates a batch on the transaction and loads the counters.
+// The buckets are expected to exist (they are created when the store is opened).
+func newBatch(tx *bbolt.Tx, now time.Time) *batch {
+	b := &batch{tx: tx, now: now, events: []db.Event{}}
+	meta := tx.Bucket(metaBucket)
+	if raw := meta.Get(revisionKey); raw != nil {
+		b.revision = int64(binary.BigEndian.Uint64(raw))
+	}
+	if raw := meta.Get(leaseIdKey); raw != nil {
+		b.leaseId = int64(binary.BigEndian.Uint64(raw))
+	}
+	return b
+}
+
+// flush persists the counters, it must be called before a writable transaction commits.
+func (b *batch) flush() error {
+	meta := b.tx.Bucket(metaBucket)
+	if err := meta.Put(revisionKey, encodeId(b.revision)); err != nil {
+		return err
+	}
+	return meta.Put(leaseIdKey, encodeId(b.leaseId))
+}
+
+// get returns the entry of the key. Kvs attached to an expired lease are treated as not existent,
+// this allows read-only transactions to hide expired kvs before they are actually removed.
+func (b *batch) get(key string) (*entry, bool, error) {
+	raw := b.tx.Bucket(kvBucket).Get([]byte(key))
+	if raw == nil {
+		return nil, false, nil
+	}
+	e, err := decodeEntry(raw)
+	if err != nil {
+		return nil, false, err
+	}
+	if e.lease != 0 {
+		if _, ok, err := b.getLease(e.lease); err != nil || !ok {
+			return nil, false, err
+		}
+	}
+	return e, true, nil
+}
+
+// getRange returns all (non expired) entries that match the prefix in lexical key order.
+func (b *batch) getRange(prefix string) (map[string]*entry, error) {
+	entries := map[string]*entry{}
+	cursor := b.tx.Bucket(kvBucket).Cursor()
+	for k, _ := cursor.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, _ = cursor.Next() {
+		e, ok, err := b.get(string(k))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			entries[string(k)] = e
+		}
+	}
+	return entries, nil
+}
+
+// getLease returns the lease. Expired leases are treated as not existent.
+func (b *batch) getLease(leaseId int64) (*lease, bool, error) {
+	raw := b.tx.Bucket(leaseBucket).Get(encodeId(leaseId))
+	if raw == nil {
+		return nil, false, nil
+	}
+	l, err := decodeLease(raw)
+	if err != nil {
+		return nil, false, err
+	}
+	if !l.expiry.After(b.now) {
+		return nil, false, nil
+	}
+	return l, true, nil
+}
+
+// put writes the kv with the current revision and records its event.
+func (b *batch) put(key, value string, leaseId int64) error {
+	prevValue := ""
+	prev, ok, err := b.get(key)
+	if err != nil {
+		return err
+	}
+	if ok {
+		prevValue = prev.value
+		if err := b.tx.Bucket(leaseKvBucket).Delete(append(encodeId(prev.lease), key...)); err != nil {
+			return err
+		}
+	}
+
+	newEntry := &entry{value: value, modRevision: b.revision, lease: leaseId}
+	if err := b.tx.Bucket(kvBucket).Put([]byte(key), newEntry.encode()); err != nil {
+		return err
+	}
+	if leaseId != 0 {
+		if err := b.tx.Bucket(leaseKvBucket).Put(append(encodeId(leaseId), key...), []byte{}); err != nil {
+			return err
+		}
+	}
+	b.events = append(b.events, db.Event{
+		Type: db.EVENT_PUT, Key: key, Value: value, PrevValue: prevValue, Revision: b.revision,
+	})
+	return nil
+}
+
+// remove deletes the kv and records its event. Returns false if the key did not exist.
+func (b *batch) remove(key string) (bool, error) {
+	prev, ok, err := b.get(key)
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := b.tx.Bucket(kvBucket).Delete([]byte(key)); err != nil {
+		return false, err
+	}
+	if err := b.tx.Bucket(leaseKvBucket).Delete(append(encodeId(prev.lease), key...)); err != nil {
+		return false, err
+	}
+	b.events = append(b.events, db.Event{
+		Type: db.EVENT_DELETE, Key: key, Value: "", PrevValue: prev.value, Revision: b.revision,
+	})
+	return true, nil
+}
+
+// removeRange deletes all kvs matching the prefix in lexical key order. Returns false if no key matched.
+func (b *batch) removeRange(prefix string) (bool, error) {
+	entries, err := b.getRange(prefix)
+	if err != nil {
+		return false, err
+	}
+	keys := []string{}
+	cursor := b.tx.Bucket(kvBucket).Cursor()
+	for k, _ := cursor.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, _ = cursor.Next() {
+		if _, ok := entries[string(k)]; ok {
+			keys = append(keys, string(k))
+		}
+	}
+
+	for _, key := range keys {
+		if _, err := b.remove(key); err != nil {
+			return false, err
+		}
+	}
+	return len(keys) > 0, nil
+}
+
+// grant creates a new lease and returns its id.
+func (b *batch) grant(ttl int64) (int64, error) {
+	b.leaseId++
+	l := &lease{ttl: ttl, expiry: b.now.Add(time.Second * time.Duration(ttl))}
+	if err := b.tx.Bucket(leaseBucket).Put(encodeId(b.leaseId), l.encode()); err != nil {
+		return 0, err
+	}
+	return b.leaseId, nil
+}
+
+// renew resets the expiry of the lease. Returns false if the lease does not exist (anymore).
+func (b *batch) renew(leaseId int64) (*lease, bool, error) {
+	l, ok, err := b.getLease(leaseId)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	l.expiry = b.now.Add(time.Second * time.Duration(l.ttl))
+	if err := b.tx.Bucket(leaseBucket).Put(encodeId(leaseId), l.encode()); err != nil {
+		return nil, false, err
+	}
+	return l, true, nil
+}
+
+// revoke removes the lease and all kvs attached to it in lexical key order.
+// If kvs are removed, the revoke gets a dedicated revision.
+func (b *batch) revoke(leaseId int64) error {
+	keys := []string{}
+	prefix := encodeId(leaseId)
+	cursor := b.tx.Bucket(leaseKvBucket).Cursor()
+	for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+		keys = append(keys, string(k[len(prefix):]))
+	}
+
+	// the kvs are removed manually, as b.remove() hides kvs attached to expired leases.
+	if len(keys) > 0 {
+		b.revision++
+		for _, key := range keys {
+			raw := b.tx.Bucket(kvBucket).Get([]byte(key))
+			if raw == nil {
+				continue
+			}
+			prev, err := decodeEntry(raw)
+			if err != nil {
+				return err
+			}
+			if err := b.tx.Bucket(kvBucket).Delete([]byte(key)); err != nil {
+				return err
+			}
+			if err := b.tx.Bucket(leaseKvBucket).Delete(append(encodeId(leaseId), key...)); err != nil {
+				return err
+			}
+			b.events = append(b.events, db.Event{
+				Type: db.EVENT_DELETE, Key: key, Value: "", PrevValue: prev.value, Revision: b.revision,
+			})
+		}
+	}
+	return b.tx.Bucket(leaseBucket).Delete(encodeId(leaseId))
+}
+
+// expired returns the ids of all expired leases in order of their expiry time.
+func (b *batch) expired() ([]int64, error) {
+	expired := []int64{}
+	expiries := map[int64]time.Time{}
+	cursor := b.tx.Bucket(leaseBucket).Cursor()
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		l, err := decodeLease(v)
+		if err != nil {
+			return nil, err
+		}
+		if !l.expiry.After(b.now) {
+			id := int64(binary.BigEndian.Uint64(k))
+			expired = append(expired, id)
+			expiries[id] = l.expiry
+		}
+	}
+	// leases are iterated in id order, a stable sort therefore orders leases with equal expiry by id.
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expiries[expired[i]].Before(expiries[expired[j]])
+	})
+	return expired, nil
+}
+
+// expire revokes all expired leases in order of their expiry time.
+func (b *batch) expire() error {
+	expired, err := b.expired()
+	if err != nil {
+		return err
+	}
+	for _, id := range expired {
+		if err := b.revoke(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,618 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package boltdb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/db/internal/watch"
+	"go.etcd.io/bbolt"
+)
+
+// Client provides a Client implementation backed by an embedded bbolt store.
+// It mimics the etcd semantics (prefix ranges, leases, ttl expiry and ordered watch events) on a single
+// local file, which allows running the cthul services standalone without an external database.
+// Kvs and leases are persisted, the watch history is held in memory and starts empty on every launch.
+type Client struct {
+	// rootCtx is active for the full lifetime of the client.
+	// closing it stops the expiry routine and all active watchers.
+	rootCtx       context.Context
+	rootCtxCancel context.CancelFunc
+
+	// finChan is used to send the absolute exist signal
+	// if the channel emits, this indicates that the expiry routine is fully cleaned up.
+	finChan chan struct{}
+
+	// path specifies the location of the database file.
+	path string
+	// openTimeout specifies how long to wait for the file lock when opening the database.
+	openTimeout time.Duration
+	// expiryInterval specifies the interval in which expired keys are removed in the background.
+	expiryInterval time.Duration
+	// historyLimit specifies the number of events retained to resume watchers from older revisions.
+	historyLimit int
+	// clock is used to determine the current time for ttl evaluation.
+	clock func() time.Time
+
+	// storeLock protects the store handle and the watch hub.
+	// Every modification emits its events while holding the lock, this ensures all watchers receive
+	// the events in the exact same order as they were applied to the store.
+	storeLock sync.Mutex
+	store     *bbolt.DB
+	// startRevision holds the revision of the store when it was opened,
+	// older revisions are not part of the history and are therefore treated as compacted.
+	startRevision int64
+	watchHub      *watch.Hub
+}
+
+type Option func(*Client)
+
+// New creates a new bbolt client and starts its background expiry routine.
+// The database file is opened lazily on the first operation (or CheckEndpointHealth).
+func New(path string, opts ...Option) *Client {
+	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
+	client := &Client{
+		rootCtx:        rootCtx,
+		rootCtxCancel:  rootCtxCancel,
+		finChan:        make(chan struct{}),
+		path:           path,
+		openTimeout:    time.Second * 2,
+		expiryInterval: time.Second,
+		historyLimit:   1000,
+		clock:          time.Now,
+		storeLock:      sync.Mutex{},
+		store:          nil,
+		startRevision:  0,
+		watchHub:       nil,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+	client.watchHub = watch.NewHub(client.historyLimit)
+
+	go func() {
+		defer close(client.finChan)
+		for {
+			select {
+			case <-client.rootCtx.Done():
+				return
+			case <-time.After(client.expiryInterval):
+				// errors are not handled here, they are reported by the next regular operation.
+				client.Expire()
+			}
+		}
+	}()
+
+	return client
+}
+
+// WithOpenTimeout defines a custom timeout for acquiring the database file lock.
+// The file is exclusively locked, therefore only one process can use it at a time.
+func WithOpenTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.openTimeout = timeout
+	}
+}
+
+// WithExpiryInterval defines a custom interval for the background routine that removes expired keys.
+func WithExpiryInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.expiryInterval = interval
+	}
+}
+
+// WithHistoryLimit defines a custom number of events that are retained to resume watchers.
+// Watchers requesting a revision older than the retained history fail with a compaction error.
+func WithHistoryLimit(limit int) Option {
+	return func(c *Client) {
+		c.historyLimit = limit
+	}
+}
+
+// WithClock defines a custom clock used to evaluate ttls. This allows tests to control the expiry of keys
+// by advancing the clock and calling Expire() (or any other operation) afterwards.
+func WithClock(clock func() time.Time) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// initStore opens the underlying database file if not already opened, the caller must hold the storeLock.
+func (c *Client) initStore() error {
+	if c.store != nil {
+		return nil
+	}
+	select {
+	case <-c.rootCtx.Done():
+		return fmt.Errorf("client is terminated")
+	default:
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return fmt.Errorf("failed to create database directory: %w", err)
+	}
+	store, err := bbolt.Open(c.path, 0600, &bbolt.Options{Timeout: c.openTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	err = store.Update(func(tx *bbolt.Tx) error {
+		for _, bucket := range [][]byte{kvBucket, leaseBucket, leaseKvBucket, metaBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		c.startRevision = newBatch(tx, c.clock()).revision
+		return nil
+	})
+	if err != nil {
+		store.Close()
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	c.store = store
+	return nil
+}
+
+// view executes the function on a read-only batch.
+func (c *Client) view(fn func(*batch) error) error {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	if err := c.initStore(); err != nil {
+		return err
+	}
+
+	return c.store.View(func(tx *bbolt.Tx) error {
+		return fn(newBatch(tx, c.clock()))
+	})
+}
+
+// update executes the function on a writable batch after revoking all expired leases.
+// The events recorded on the batch are only emitted if the transaction is committed successfully.
+func (c *Client) update(fn func(*batch) error) error {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	if err := c.initStore(); err != nil {
+		return err
+	}
+
+	var b *batch
+	err := c.store.Update(func(tx *bbolt.Tx) error {
+		b = newBatch(tx, c.clock())
+		if err := b.expire(); err != nil {
+			return err
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
+		return b.flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, e := range b.events {
+		c.watchHub.Emit(e)
+	}
+	return nil
+}
+
+// CheckEndpointHealth opens the database file and checks if the client is still operational.
+func (c *Client) CheckEndpointHealth(ctx context.Context) error {
+	return c.view(func(b *batch) error {
+		return nil
+	})
+}
+
+// Health reports whether the client is operational. It matches the health reporting of the etcd client,
+// the check is cheap enough to be evaluated synchronously.
+func (c *Client) Health() error {
+	return c.CheckEndpointHealth(c.rootCtx)
+}
+
+// Get returns a single key. If the key is empty or not existent, an empty string is returned.
+func (c *Client) Get(ctx context.Context, key string) (string, error) {
+	value, _, err := c.GetRevision(ctx, key)
+	return value, err
+}
+
+// GetRange returns a kv map with all keys that match the prefix.
+func (c *Client) GetRange(ctx context.Context, prefix string) (map[string]string, error) {
+	kvMap := map[string]string{}
+	err := c.view(func(b *batch) error {
+		entries, err := b.getRange(prefix)
+		if err != nil {
+			return err
+		}
+		for key, entry := range entries {
+			kvMap[key] = entry.value
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return kvMap, nil
+}
+
+// GetRangeRevision returns a kv map with all keys that match the prefix and the current store revision.
+func (c *Client) GetRangeRevision(ctx context.Context, prefix string) (map[string]string, int64, error) {
+	kvMap, revision := map[string]string{}, int64(0)
+	err := c.view(func(b *batch) error {
+		entries, err := b.getRange(prefix)
+		if err != nil {
+			return err
+		}
+		for key, entry := range entries {
+			kvMap[key] = entry.value
+		}
+		revision = b.revision
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return kvMap, revision, nil
+}
+
+// GetRevision returns a single key and its modification revision.
+// If the key is not existent, an empty string and revision 0 is returned.
+func (c *Client) GetRevision(ctx context.Context, key string) (string, int64, error) {
+	value, revision := "", int64(0)
+	err := c.view(func(b *batch) error {
+		entry, ok, err := b.get(key)
+		if err != nil || !ok {
+			return err
+		}
+		value, revision = entry.value, entry.modRevision
+		return nil
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	return value, revision, nil
+}
+
+// Set upserts a kv to the store and returns the previous value. If ttl is set to 0 the kv never expires.
+func (c *Client) Set(ctx context.Context, key, value string, ttl int64) (string, error) {
+	prevValue := ""
+	err := c.update(func(b *batch) error {
+		prev, ok, err := b.get(key)
+		if err != nil {
+			return err
+		}
+		if ok {
+			prevValue = prev.value
+		}
+
+		leaseId := int64(0)
+		if ttl != 0 {
+			leaseId, err = b.grant(ttl)
+			if err != nil {
+				return err
+			}
+		}
+		b.revision++
+		return b.put(key, value, leaseId)
+	})
+	if err != nil {
+		return "", err
+	}
+	return prevValue, nil
+}
+
+// SetLease upserts a kv attached to the lease and returns the previous value.
+func (c *Client) SetLease(ctx context.Context, key, value string, leaseId int64) (string, error) {
+	prevValue := ""
+	err := c.update(func(b *batch) error {
+		if _, ok, err := b.getLease(leaseId); err != nil {
+			return err
+		} else if !ok {
+			return fmt.Errorf("lease %d not found", leaseId)
+		}
+
+		prev, ok, err := b.get(key)
+		if err != nil {
+			return err
+		}
+		if ok {
+			prevValue = prev.value
+		}
+
+		b.revision++
+		return b.put(key, value, leaseId)
+	})
+	if err != nil {
+		return "", err
+	}
+	return prevValue, nil
+}
+
+// GrantLease creates a new lease with the specified ttl (seconds) and returns the lease id.
+// The ttl must be at least one second, shorter leases could not be renewed by KeepAliveLease().
+func (c *Client) GrantLease(ctx context.Context, ttl int64) (int64, error) {
+	if ttl < 1 {
+		return 0, fmt.Errorf("lease ttl must be at least 1 second")
+	}
+	leaseId := int64(0)
+	err := c.update(func(b *batch) error {
+		var err error
+		leaseId, err = b.grant(ttl)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return leaseId, nil
+}
+
+// KeepAliveLease starts a blocking routine that renews the lease until the context is cancelled.
+// The lease is renewed in a third of its ttl, if it cannot be renewed anymore (expired or revoked),
+// an error is returned.
+func (c *Client) KeepAliveLease(ctx context.Context, leaseId int64) error {
+	for {
+		interval := time.Duration(0)
+		err := c.update(func(b *batch) error {
+			l, ok, err := b.renew(leaseId)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("lease %d cannot be renewed anymore", leaseId)
+			}
+			interval = time.Second * time.Duration(l.ttl) / 3
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.rootCtx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// RevokeLease revokes the lease, all kvs attached to it are removed.
+func (c *Client) RevokeLease(ctx context.Context, leaseId int64) error {
+	return c.update(func(b *batch) error {
+		if _, ok, err := b.getLease(leaseId); err != nil {
+			return err
+		} else if !ok {
+			return fmt.Errorf("lease %d not found", leaseId)
+		}
+		return b.revoke(leaseId)
+	})
+}
+
+// CompareAndSet upserts a kv to the store if the modification revision of the key matches the revision.
+// Revision 0 requires the key to not exist. Returns false if the revision did not match.
+func (c *Client) CompareAndSet(ctx context.Context, key, value string, revision, ttl int64) (bool, error) {
+	return c.Txn(ctx, []db.Condition{{
+		Key: key, Compare: db.COMPARE_EQUAL, Revision: revision,
+	}}, []db.Operation{{
+		Type: db.OPERATION_SET, Key: key, Value: value, TTL: ttl,
+	}})
+}
+
+// Txn executes all operations atomically if every condition holds. Returns false if a condition did not hold.
+// Like etcd, all modifications of the transaction share one revision.
+func (c *Client) Txn(ctx context.Context, conditions []db.Condition, operations []db.Operation) (bool, error) {
+	succeeded := false
+	err := c.update(func(b *batch) error {
+		for _, operation := range operations {
+			switch operation.Type {
+			case db.OPERATION_SET:
+				if operation.Lease == 0 {
+					continue
+				}
+				if _, ok, err := b.getLease(operation.Lease); err != nil {
+					return err
+				} else if !ok {
+					return fmt.Errorf("lease %d not found", operation.Lease)
+				}
+			case db.OPERATION_DELETE, db.OPERATION_DELETE_RANGE:
+			default:
+				return fmt.Errorf("unknown operation type '%s'", operation.Type)
+			}
+		}
+
+		for _, condition := range conditions {
+			revision := int64(0)
+			entry, ok, err := b.get(condition.Key)
+			if err != nil {
+				return err
+			}
+			if ok {
+				revision = entry.modRevision
+			}
+			ok, err = watch.Compare(revision, condition.Compare, condition.Revision)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+		}
+
+		// the revision is only increased if the transaction actually modifies the store.
+		b.revision++
+		modified := false
+		for _, operation := range operations {
+			switch operation.Type {
+			case db.OPERATION_SET:
+				leaseId := operation.Lease
+				if leaseId == 0 && operation.TTL != 0 {
+					var err error
+					leaseId, err = b.grant(operation.TTL)
+					if err != nil {
+						return err
+					}
+				}
+				if err := b.put(operation.Key, operation.Value, leaseId); err != nil {
+					return err
+				}
+				modified = true
+			case db.OPERATION_DELETE:
+				removed, err := b.remove(operation.Key)
+				if err != nil {
+					return err
+				}
+				modified = modified || removed
+			case db.OPERATION_DELETE_RANGE:
+				removed, err := b.removeRange(operation.Key)
+				if err != nil {
+					return err
+				}
+				modified = modified || removed
+			}
+		}
+		if !modified {
+			b.revision--
+		}
+		succeeded = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return succeeded, nil
+}
+
+// Delete deletes one specific kv by key.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	return c.update(func(b *batch) error {
+		b.revision++
+		removed, err := b.remove(key)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			b.revision--
+		}
+		return nil
+	})
+}
+
+// DeleteRange deletes all kvs that match the prefix.
+// Like etcd, all deletions share one revision and are emitted in lexical key order.
+func (c *Client) DeleteRange(ctx context.Context, prefix string) error {
+	return c.update(func(b *batch) error {
+		b.revision++
+		removed, err := b.removeRange(prefix)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			b.revision--
+		}
+		return nil
+	})
+}
+
+// Watch starts a blocking listener that reacts to changes on the specified key.
+// The event function is triggered on every event, containing the event or an error on failure.
+// Events are delivered from the specified revision on (0 = current revision).
+// Stop the watcher by cancelling the context.
+func (c *Client) Watch(ctx context.Context, key string, revision int64, event func(db.Event, error)) error {
+	return c.startWatchCycle(ctx, key, false, revision, event)
+}
+
+// WatchRange starts a blocking listener that reacts to changes on keys in the specified prefix.
+// The event function is triggered on every event, containing the event or an error on failure.
+// Events are delivered from the specified revision on (0 = current revision).
+// Stop the watcher by cancelling the context.
+func (c *Client) WatchRange(ctx context.Context, prefix string, revision int64, event func(db.Event, error)) error {
+	return c.startWatchCycle(ctx, prefix, true, revision, event)
+}
+
+// startWatchCycle registers the watcher on the client and delivers its events until the context is cancelled
+// or the client is terminated. Events are delivered sequentially in the order they were applied to the store.
+// If a start revision is provided, matching events from the history are replayed before live events.
+// Revisions written before the store was opened are not part of the history and are treated as compacted.
+func (c *Client) startWatchCycle(
+	ctx context.Context, key string, prefix bool, revision int64, eventFunc func(db.Event, error),
+) error {
+	c.storeLock.Lock()
+	if err := c.initStore(); err != nil {
+		c.storeLock.Unlock()
+		return err
+	}
+	w, err := c.watchHub.Register(key, prefix, revision, c.startRevision+1)
+	c.storeLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		c.storeLock.Lock()
+		c.watchHub.Unregister(w)
+		c.storeLock.Unlock()
+	}()
+
+	w.Deliver(ctx, c.rootCtx, eventFunc)
+	return nil
+}
+
+// Expire revokes all expired leases and emits the events of their kvs.
+// Expiry is also performed before every write operation and expired kvs are hidden from reads,
+// calling Expire is only required to notify watchers about expired keys immediately.
+func (c *Client) Expire() error {
+	// expired leases are looked up in a read-only transaction first,
+	// this prevents the background routine from committing (and syncing) the file on every interval.
+	pending := false
+	err := c.view(func(b *batch) error {
+		expired, err := b.expired()
+		pending = len(expired) > 0
+		return err
+	})
+	if err != nil || !pending {
+		return err
+	}
+	return c.update(func(b *batch) error {
+		return nil
+	})
+}
+
+// Terminate stops the expiry routine and all active watchers and closes the database file.
+// The context is only provided to match the cthul terminate pattern.
+func (c *Client) Terminate(ctx context.Context) error {
+	c.rootCtxCancel()
+	<-c.finChan
+
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	if c.store != nil {
+		err := c.store.Close()
+		c.store = nil
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package boltdb
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/db/internal/dbtest"
+)
+
+func TestClient(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, clock func() time.Time, historyLimit int) (db.Client, func()) {
+		// expiry is triggered by the tests, the background routine would race with the test clock.
+		client := New(filepath.Join(t.TempDir(), "cthul.db"),
+			WithClock(clock), WithHistoryLimit(historyLimit), WithExpiryInterval(time.Hour),
+		)
+		t.Cleanup(func() { client.Terminate(context.Background()) })
+		return client, func() { client.Expire() }
+	})
+}
+
+func TestReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cthul.db")
+
+	client := New(path)
+	lease, err := client.GrantLease(ctx, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Set(ctx, "/a", "1", 0)
+	client.SetLease(ctx, "/b", "1", lease)
+	_, rev, _ := client.GetRangeRevision(ctx, "/")
+	if err := client.Terminate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	client = New(path)
+	defer client.Terminate(ctx)
+
+	kvs, reopenRev, err := client.GetRangeRevision(ctx, "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kvs) != 2 || kvs["/a"] != "1" || kvs["/b"] != "1" {
+		t.Errorf("expected kvs to be persisted, got %v", kvs)
+	}
+	if reopenRev != rev {
+		t.Errorf("expected revision %d to be persisted, got %d", rev, reopenRev)
+	}
+
+	// leases are persisted, the revocation removes the kv attached before the reopen.
+	if err := client.RevokeLease(ctx, lease); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := client.Get(ctx, "/b"); value != "" {
+		t.Errorf("expected '/b' to be removed with its lease, got '%s'", value)
+	}
+
+	// the watch history is not persisted, revisions written before the reopen are compacted.
+	err = client.WatchRange(ctx, "/", rev, func(db.Event, error) {})
+	if err == nil || !strings.Contains(err.Error(), "compacted") {
+		t.Errorf("expected compaction error, got %v", err)
+	}
+}
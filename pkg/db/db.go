@@ -26,21 +26,48 @@ import "context"
 type Client interface {
 	// Get returns a single value from database. Returns "" if the key is emtpy OR does not exist.
 	Get(context.Context, string) (string, error)
+	// GetRevision returns a single value and its modification revision from database.
+	// Returns "" and revision 0 if the key does not exist.
+	GetRevision(context.Context, string) (string, int64, error)
 	// GetRange returns a map of kvs based on the provided prefix.
 	GetRange(context.Context, string) (map[string]string, error)
+	// GetRangeRevision returns a map of kvs based on the provided prefix and the revision the range was read at.
+	// Watchers started at the returned revision + 1 receive every subsequent update of the range.
+	GetRangeRevision(context.Context, string) (map[string]string, int64, error)
 	// Set upserts a kv with the specified ttl and atomically returns the previous value.
 	// If ttl is 0 the kv does not expire. Returns "" if the previous key was empty OR didn't exist.
 	Set(context.Context, string, string, int64) (string, error)
+	// SetLease upserts a kv attached to the specified lease and atomically returns the previous value.
+	// The kv is removed as soon as the lease expires or is revoked.
+	SetLease(context.Context, string, string, int64) (string, error)
+	// CompareAndSet upserts a kv with the specified ttl only if the modification revision of the key
+	// matches the provided revision (revision 0 requires the key to not exist).
+	// Returns false if the revision did not match and the kv was therefore not written.
+	CompareAndSet(context.Context, string, string, int64, int64) (bool, error)
+	// Txn atomically executes all operations if every condition holds.
+	// Returns false if a condition did not hold and no operation was executed.
+	Txn(context.Context, []Condition, []Operation) (bool, error)
+	// GrantLease creates a new lease with the specified ttl and returns its id.
+	// Multiple kvs can be attached to the same lease, they all expire together with the lease.
+	GrantLease(context.Context, int64) (int64, error)
+	// KeepAliveLease periodically renews the lease so that it does not expire.
+	// The function is blocking, to stop it cancel the context. If the lease cannot be renewed anymore
+	// (e.g. because it already expired) an error is returned.
+	KeepAliveLease(context.Context, int64) error
+	// RevokeLease revokes the lease and immediately removes all kvs attached to it.
+	RevokeLease(context.Context, int64) error
 	// Delete removes a kv from the database.
 	Delete(context.Context, string) error
 	// DeleteRange removes all kvs from the database by prefix.
 	DeleteRange(context.Context, string) error
 	// Watch calls the specified function on every update of the specified key.
-	// The callback provides the updated key, value and an error in case of a failure.
+	// The callback provides the event (including its type, revision and previous value) or an error.
+	// Events are delivered starting at the specified revision, revision 0 starts at the current revision.
 	// The function is blocking, to stop it cancel the context.
-	Watch(context.Context, string, func(string, string, error)) error
+	Watch(context.Context, string, int64, func(Event, error)) error
 	// WatchRange calls the specified function on every update of a key in the prefix range.
-	// The callback provides the updated key, value and an error in case of a failure.
+	// The callback provides the event (including its type, revision and previous value) or an error.
+	// Events are delivered starting at the specified revision, revision 0 starts at the current revision.
 	// The function is blocking, to stop it cancel the context.
-	WatchRange(context.Context, string, func(string, string, error)) error
+	WatchRange(context.Context, string, int64, func(Event, error)) error
 }
@@ -22,35 +22,92 @@ package etcdv3
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
+	"cthul.io/cthul/pkg/db"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/client/v3"
 	"go.uber.org/zap"
 )
 
 // Client provides a Client implementation for etcdv3.
 type Client struct {
+	// rootCtx is active for the full lifetime of the client.
+	// closing it stops the health monitor.
+	rootCtx       context.Context
+	rootCtxCancel context.CancelFunc
+
+	// finChan is used to send the absolute exist signal
+	// if the channel emits, this indicates that the health monitor is fully cleaned up.
+	finChan chan struct{}
+
 	config clientv3.Config
-	client *clientv3.Client
+
+	// clientLock protects the lazy initialization of the client.
+	clientLock sync.Mutex
+	client     *clientv3.Client
+
+	// healthInterval specifies the interval of the background health checks (0 disables them).
+	healthInterval time.Duration
+	// maxRaftLag specifies how many committed raft entries the endpoint may not have applied yet.
+	maxRaftLag uint64
+
+	// healthLock protects the result of the latest background health check.
+	healthLock sync.RWMutex
+	health     error
 }
 
 type Option func(*Client)
 
-// New creates a new etcdv3 client.
+// New creates a new etcdv3 client. If the health monitor is enabled, it is started immediately.
 func New(endpoints []string, opts ...Option) *Client {
+	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
 	etcdClient := &Client{
+		rootCtx:       rootCtx,
+		rootCtxCancel: rootCtxCancel,
+		finChan:       make(chan struct{}),
 		config: clientv3.Config{
 			Endpoints: endpoints,
 			TLS: &tls.Config{},
 			DialTimeout: time.Second * 2,
 			Logger: zap.NewNop(),
 		},
-		client: nil,
+		clientLock:     sync.Mutex{},
+		client:         nil,
+		healthInterval: 0,
+		maxRaftLag:     5000,
+		healthLock:     sync.RWMutex{},
+		health:         fmt.Errorf("health not evaluated yet"),
 	}
 
 	for _, opt := range opts {
 		opt(etcdClient)
 	}
+
+	go func() {
+		defer close(etcdClient.finChan)
+		if etcdClient.healthInterval <= 0 {
+			return
+		}
+		for {
+			ctx, cancel := context.WithTimeout(etcdClient.rootCtx, etcdClient.config.DialTimeout)
+			err := etcdClient.CheckEndpointHealth(ctx)
+			cancel()
+			etcdClient.healthLock.Lock()
+			etcdClient.health = err
+			etcdClient.healthLock.Unlock()
+
+			select {
+			case <-etcdClient.rootCtx.Done():
+				return
+			case <-time.After(etcdClient.healthInterval):
+			}
+		}
+	}()
 	
 	return etcdClient
 }
@@ -77,9 +134,27 @@ func WithSkipVerify(skip bool) Option {
 	}
 }
 
+// WithHealthInterval enables the health monitor, which runs CheckEndpointHealth in the specified interval.
+// The result of the latest check is exposed via Health().
+func WithHealthInterval(interval time.Duration) Option {
+	return func (c *Client) {
+		c.healthInterval = interval
+	}
+}
+
+// WithMaxRaftLag defines how many committed raft entries the endpoint may lag behind when applying them
+// before it is considered unhealthy.
+func WithMaxRaftLag(lag uint64) Option {
+	return func (c *Client) {
+		c.maxRaftLag = lag
+	}
+}
+
 
 // initClient creates the underlying etcdv3 client if not already initialized.
 func (c *Client) initClient() error {
+	c.clientLock.Lock()
+	defer c.clientLock.Unlock()
 	if c.client != nil {
 		return nil
 	}
@@ -91,16 +166,66 @@ func (c *Client) initClient() error {
 	return nil
 }
 
-// CheckEndpointHealth initially checks if the database endpoint is reachable.
-// This method is used to ensure the database connection works before launching various components.
+// CheckEndpointHealth checks if the database endpoints are operational.
+// Every endpoint must be reachable, must see a leader, must have applied its committed raft entries
+// (within the allowed lag) and must not report errors. Additionally the cluster must not have active alarms
+// (e.g. NOSPACE) and the client must be able to authenticate.
 func (c *Client) CheckEndpointHealth(ctx context.Context) error {
 	if err := c.initClient(); err!=nil {
 		return err
 	}
-	// Function currently only initializes the client, the idea is to add more 'health' checks in the future.
+
+	for _, endpoint := range c.client.Endpoints() {
+		status, err := c.client.Status(ctx, endpoint)
+		if err!=nil {
+			return fmt.Errorf("endpoint %s is unreachable: %w", endpoint, err)
+		}
+		if len(status.Errors) > 0 {
+			return fmt.Errorf("endpoint %s reports errors: %s", endpoint, strings.Join(status.Errors, ", "))
+		}
+		if status.Leader == 0 {
+			return fmt.Errorf("endpoint %s has no leader", endpoint)
+		}
+		if status.RaftIndex > status.RaftAppliedIndex && status.RaftIndex-status.RaftAppliedIndex > c.maxRaftLag {
+			return fmt.Errorf("endpoint %s lags behind: %d committed raft entries are not applied",
+				endpoint, status.RaftIndex-status.RaftAppliedIndex)
+		}
+	}
+
+	alarms, err := c.client.AlarmList(ctx)
+	if err!=nil {
+		return fmt.Errorf("failed to list alarms: %w", err)
+	}
+	if len(alarms.Alarms) > 0 {
+		alarmTypes := []string{}
+		for _, alarm := range alarms.Alarms {
+			alarmTypes = append(alarmTypes, alarm.Alarm.String())
+		}
+		return fmt.Errorf("cluster has active alarms: %s", strings.Join(alarmTypes, ", "))
+	}
+
+	// a simple read verifies that the client can authenticate,
+	// like etcdctl a missing permission on the key is fine as it proves that authentication succeeded.
+	_, err = c.client.Get(ctx, "health", clientv3.WithCountOnly())
+	if err!=nil && err != rpctypes.ErrPermissionDenied {
+		return fmt.Errorf("failed to read from database: %w", err)
+	}
 	return nil
 }
 
+// Health returns the result of the latest background health check.
+// If the health monitor is disabled, the health is checked synchronously.
+func (c *Client) Health() error {
+	if c.healthInterval <= 0 {
+		ctx, cancel := context.WithTimeout(c.rootCtx, c.config.DialTimeout)
+		defer cancel()
+		return c.CheckEndpointHealth(ctx)
+	}
+	c.healthLock.RLock()
+	defer c.healthLock.RUnlock()
+	return c.health
+}
+
 // Get returns a single key. If the key is empty or not existent, an empty string is returned.
 func (c *Client) Get(ctx context.Context, key string) (string, error) {
 	if err := c.initClient(); err!=nil {
@@ -117,6 +242,23 @@ func (c *Client) Get(ctx context.Context, key string) (string, error) {
 	return string(res.Kvs[0].Value), nil
 }
 
+// GetRevision returns a single key and its modification revision.
+// If the key is not existent, an empty string and revision 0 is returned.
+func (c *Client) GetRevision(ctx context.Context, key string) (string, int64, error) {
+	if err := c.initClient(); err!=nil {
+		return "", 0, err
+	}
+	res, err := c.client.KV.Get(ctx, key)
+	if err!=nil {
+		return "", 0, err
+	}
+
+	if len(res.Kvs) < 1 {
+		return "", 0, nil
+	}
+	return string(res.Kvs[0].Value), res.Kvs[0].ModRevision, nil
+}
+
 // GetRange returns a kv map with all keys that match the prefix.
 func (c *Client) GetRange(ctx context.Context, prefix string) (map[string]string, error) {
 	if err := c.initClient(); err!=nil {
@@ -135,6 +277,24 @@ func (c *Client) GetRange(ctx context.Context, prefix string) (map[string]string
 	return kvMap, nil
 }
 
+// GetRangeRevision returns a kv map with all keys that match the prefix and the revision it was read at.
+func (c *Client) GetRangeRevision(ctx context.Context, prefix string) (map[string]string, int64, error) {
+	if err := c.initClient(); err!=nil {
+		return nil, 0, err
+	}
+	res, err := c.client.KV.Get(ctx, prefix, clientv3.WithPrefix())
+	if err!=nil {
+		return nil, 0, err
+	}
+
+	kvMap := map[string]string{}
+	for _, kv := range res.Kvs {
+		kvMap[string(kv.Key)] = string(kv.Value)
+	}
+
+	return kvMap, res.Header.Revision, nil
+}
+
 
 // Set upserts a kv to the database and returns the previous value. If ttl is set to 0 the kv never expires.
 func (c *Client) Set(ctx context.Context, key, value string, ttl int64) (string, error) {
@@ -142,17 +302,21 @@ func (c *Client) Set(ctx context.Context, key, value string, ttl int64) (string,
 		return "", err
 	}
 	opts := []clientv3.OpOption{}
+	leases := []clientv3.LeaseID{}
 	if ttl!=0 {
-		// utilizing lease checking, keep-alive, revokation, etc. hardly overcomplicates this usecase
-		// therefore we just grant a new lease every time the key is set.
+		// a new lease is granted every time the key is set, this is fine for keys that are rarely written.
+		// keys that are periodically renewed should use a shared lease (see GrantLease / SetLease).
 		lease, err := c.client.Lease.Grant(ctx, ttl)
 		if err!=nil {
 			return "", err
 		}
+		leases = append(leases, lease.ID)
 		opts = append(opts, clientv3.WithLease(lease.ID))
 	}
+	opts = append(opts, clientv3.WithPrevKV())
 	res, err := c.client.KV.Put(ctx, key, value, opts...)
 		if err!=nil {
+			c.revokeLeases(ctx, leases)
 			return "", err
 		}
 		if res.PrevKv != nil {
@@ -161,6 +325,147 @@ func (c *Client) Set(ctx context.Context, key, value string, ttl int64) (string,
 		return "", nil
 }
 
+// SetLease upserts a kv attached to the lease and returns the previous value.
+func (c *Client) SetLease(ctx context.Context, key, value string, lease int64) (string, error) {
+	if err := c.initClient(); err!=nil {
+		return "", err
+	}
+	res, err := c.client.KV.Put(ctx, key, value, clientv3.WithLease(clientv3.LeaseID(lease)), clientv3.WithPrevKV())
+	if err!=nil {
+		return "", err
+	}
+	if res.PrevKv != nil {
+		return string(res.PrevKv.Value), nil
+	}
+	return "", nil
+}
+
+// GrantLease creates a new lease with the specified ttl (seconds) and returns the lease id.
+func (c *Client) GrantLease(ctx context.Context, ttl int64) (int64, error) {
+	if err := c.initClient(); err!=nil {
+		return 0, err
+	}
+	res, err := c.client.Lease.Grant(ctx, ttl)
+	if err!=nil {
+		return 0, err
+	}
+	return int64(res.ID), nil
+}
+
+// KeepAliveLease starts a blocking routine that renews the lease until the context is cancelled.
+// If the lease cannot be renewed anymore (expired, revoked or connection lost for longer than the ttl),
+// an error is returned.
+func (c *Client) KeepAliveLease(ctx context.Context, lease int64) error {
+	if err := c.initClient(); err!=nil {
+		return err
+	}
+	keepAliveChan, err := c.client.Lease.KeepAlive(ctx, clientv3.LeaseID(lease))
+	if err!=nil {
+		return err
+	}
+	for {
+		select {
+		case _, ok := <-keepAliveChan:
+			if ok {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+				return fmt.Errorf("lease %d cannot be renewed anymore", lease)
+			}
+		}
+	}
+}
+
+// RevokeLease revokes the lease, all kvs attached to it are removed.
+func (c *Client) RevokeLease(ctx context.Context, lease int64) error {
+	if err := c.initClient(); err!=nil {
+		return err
+	}
+	_, err := c.client.Lease.Revoke(ctx, clientv3.LeaseID(lease))
+	if err!=nil {
+		return err
+	}
+	return nil
+}
+
+// CompareAndSet upserts a kv to the database if the modification revision of the key matches the revision.
+// Revision 0 requires the key to not exist. Returns false if the revision did not match.
+func (c *Client) CompareAndSet(ctx context.Context, key, value string, revision, ttl int64) (bool, error) {
+	return c.Txn(ctx, []db.Condition{{
+		Key: key, Compare: db.COMPARE_EQUAL, Revision: revision,
+	}}, []db.Operation{{
+		Type: db.OPERATION_SET, Key: key, Value: value, TTL: ttl,
+	}})
+}
+
+// Txn executes all operations in one etcd transaction if every condition holds.
+// Returns false if a condition did not hold.
+func (c *Client) Txn(ctx context.Context, conditions []db.Condition, operations []db.Operation) (bool, error) {
+	if err := c.initClient(); err!=nil {
+		return false, err
+	}
+	cmps := []clientv3.Cmp{}
+	for _, condition := range conditions {
+		cmps = append(cmps, clientv3.Compare(
+			clientv3.ModRevision(condition.Key), string(condition.Compare), condition.Revision,
+		))
+	}
+
+	// leases granted for ttl operations are only referenced by this transaction,
+	// they are revoked if the transaction is not applied to avoid leaking them until they expire.
+	leases := []clientv3.LeaseID{}
+	ops := []clientv3.Op{}
+	for _, operation := range operations {
+		switch operation.Type {
+		case db.OPERATION_SET:
+			opts := []clientv3.OpOption{}
+			if operation.Lease!=0 {
+				opts = append(opts, clientv3.WithLease(clientv3.LeaseID(operation.Lease)))
+			} else if operation.TTL!=0 {
+				lease, err := c.client.Lease.Grant(ctx, operation.TTL)
+				if err!=nil {
+					c.revokeLeases(ctx, leases)
+					return false, err
+				}
+				leases = append(leases, lease.ID)
+				opts = append(opts, clientv3.WithLease(lease.ID))
+			}
+			ops = append(ops, clientv3.OpPut(operation.Key, operation.Value, opts...))
+		case db.OPERATION_DELETE:
+			ops = append(ops, clientv3.OpDelete(operation.Key))
+		case db.OPERATION_DELETE_RANGE:
+			ops = append(ops, clientv3.OpDelete(operation.Key, clientv3.WithPrefix()))
+		default:
+			c.revokeLeases(ctx, leases)
+			return false, fmt.Errorf("unknown operation type '%s'", operation.Type)
+		}
+	}
+
+	res, err := c.client.KV.Txn(ctx).If(cmps...).Then(ops...).Commit()
+	if err!=nil {
+		c.revokeLeases(ctx, leases)
+		return false, err
+	}
+	if !res.Succeeded {
+		c.revokeLeases(ctx, leases)
+	}
+	return res.Succeeded, nil
+}
+
+// revokeLeases revokes leases that were granted for a write that was not applied.
+// The revocation is best effort and also runs if ctx is already canceled, leases that cannot be
+// revoked expire after their ttl anyway.
+func (c *Client) revokeLeases(ctx context.Context, leases []clientv3.LeaseID) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	for _, lease := range leases {
+		c.client.Lease.Revoke(ctx, lease)
+	}
+}
+
 // Delete deletes one specific kv by key.
 func (c *Client) Delete(ctx context.Context, key string) error {
 	if err := c.initClient(); err!=nil {
@@ -188,46 +493,82 @@ func (c *Client) DeleteRange(ctx context.Context, prefix string) error {
 }
 
 // Watch starts a blocking listener that reacts to changes on the specified key.
-// The event function is triggered on every event, containing the key, value and an error on failure.
+// The event function is triggered on every event, containing the event or an error on failure.
+// Events are delivered from the specified revision on (0 = current revision).
 // Stop the watcher by cancelling the context.
-func (c *Client) Watch(ctx context.Context, key string, event func(string, string, error)) error {
+func (c *Client) Watch(ctx context.Context, key string, revision int64, event func(db.Event, error)) error {
 	if err := c.initClient(); err!=nil {
 		return err
 	}
-	return c.startWatchCycle(c.client.Watcher.Watch(ctx, key), event)
+	opts := []clientv3.OpOption{clientv3.WithPrevKV()}
+	if revision > 0 {
+		opts = append(opts, clientv3.WithRev(revision))
+	}
+	return c.startWatchCycle(c.client.Watcher.Watch(ctx, key, opts...), event)
 }
 
 // WatchRange starts a blocking listener that reacts to changes on keys in the specified prefix.
-// The event function is triggered on every event, containing the key, value and an error on failure.
+// The event function is triggered on every event, containing the event or an error on failure.
+// Events are delivered from the specified revision on (0 = current revision).
 // Stop the watcher by cancelling the context.
-func (c *Client) WatchRange(ctx context.Context, prefix string, event func(string, string, error)) error {
+func (c *Client) WatchRange(ctx context.Context, prefix string, revision int64, event func(db.Event, error)) error {
 	if err := c.initClient(); err!=nil {
 		return err
 	}
-	return c.startWatchCycle(c.client.Watcher.Watch(ctx, prefix, clientv3.WithPrefix()), event)
+	opts := []clientv3.OpOption{clientv3.WithPrefix(), clientv3.WithPrevKV()}
+	if revision > 0 {
+		opts = append(opts, clientv3.WithRev(revision))
+	}
+	return c.startWatchCycle(c.client.Watcher.Watch(ctx, prefix, opts...), event)
 }
 
 // startWatchCycle implements the actual watch cycle.
-func (c *Client) startWatchCycle(watchChan clientv3.WatchChan, eventFunc func(string, string, error)) error {
+// If the watch is cancelled by the server (e.g. because the requested revision is compacted)
+// the cancel reason is returned, if the watch is closed by the client (context cancelled) nil is returned.
+func (c *Client) startWatchCycle(watchChan clientv3.WatchChan, eventFunc func(db.Event, error)) error {
 	for {
 		select {
 		case event, ok := <- watchChan:
-			if !ok || event.Canceled {
+			if !ok {
 				return nil
 			}
+			if event.Canceled {
+				return event.Err()
+			}
+			if err := event.Err(); err!=nil {
+				eventFunc(db.Event{}, err)
+				continue
+			}
 
 			for _,update := range event.Events {
-				eventFunc(string(update.Kv.Key), string(update.Kv.Value), event.Err())
+				dbEvent := db.Event{
+					Type: db.EVENT_PUT,
+					Key: string(update.Kv.Key),
+					Value: string(update.Kv.Value),
+					Revision: update.Kv.ModRevision,
+				}
+				if update.Type == mvccpb.DELETE {
+					dbEvent.Type = db.EVENT_DELETE
+					dbEvent.Value = ""
+				}
+				if update.PrevKv != nil {
+					dbEvent.PrevValue = string(update.PrevKv.Value)
+				}
+				eventFunc(dbEvent, nil)
 			}
 		}
 	}
 }
 
 
-// Terminate cleans up the underlying etcd client, terminating all client connections.
+// Terminate stops the health monitor and cleans up the underlying etcd client, terminating all client connections.
 // Connections are terminated forcefully, the context is only provided to match the cthul terminate pattern.
 func (c *Client) Terminate(ctx context.Context) error {
-	// c.rootCtxCancel()
+	c.rootCtxCancel()
+	<-c.finChan
+
+	c.clientLock.Lock()
+	defer c.clientLock.Unlock()
 	if c.client!=nil {
 		return c.client.Close()
 	}
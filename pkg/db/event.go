@@ -0,0 +1,41 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package db
+
+type EVENT_TYPE string
+
+const (
+	EVENT_PUT    EVENT_TYPE = "put"
+	EVENT_DELETE EVENT_TYPE = "delete"
+)
+
+// Event describes a single modification of a key reported by a watcher.
+type Event struct {
+	// Type specifies whether the key was upserted or deleted.
+	Type EVENT_TYPE
+	Key  string
+	// Value holds the new value of the key, it is always "" on EVENT_DELETE.
+	Value string
+	// PrevValue holds the value before the modification, "" if the key did not exist before.
+	PrevValue string
+	// Revision holds the modification revision of the event.
+	// Watchers can be resumed from Revision + 1 to receive all subsequent events.
+	Revision int64
+}
@@ -0,0 +1,565 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// dbtest provides the conformance tests shared by the embedded db.Client implementations (memdb & boltdb).
+// Every backend runs the same table tests, which ensures they expose the exact same semantics.
+package dbtest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+)
+
+// Factory creates a fresh client for a single test. The client must evaluate ttls with the provided clock and
+// retain historyLimit events to resume watchers. The factory is responsible for the cleanup (see t.Cleanup()).
+// The returned expire function removes expired keys immediately, so tests do not wait for the backend routine.
+type Factory func(t *testing.T, clock func() time.Time, historyLimit int) (db.Client, func())
+
+// Run executes all conformance tests against the clients created by the factory.
+func Run(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		run  func(*testing.T, Factory)
+	}{
+		{"Txn", testTxn},
+		{"TxnSharesRevision", testTxnSharesRevision},
+		{"CompareAndSet", testCompareAndSet},
+		{"GrantLease", testGrantLease},
+		{"TTLExpiry", testTTLExpiry},
+		{"TTLExpiryEvent", testTTLExpiryEvent},
+		{"Lease", testLease},
+		{"KeepAliveLease", testKeepAliveLease},
+		{"WatchOrder", testWatchOrder},
+		{"WatchResume", testWatchResume},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, factory)
+		})
+	}
+}
+
+// testClock is a manually advanced clock used to control the ttl expiry.
+type testClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newClient creates a client that uses the system clock and a large watch history.
+func newClient(t *testing.T, factory Factory) db.Client {
+	t.Helper()
+	client, _ := factory(t, time.Now, 1000)
+	return client
+}
+
+// collect starts a range watcher at the revision and returns a function that waits for n events.
+func collect(t *testing.T, client db.Client, prefix string, revision int64) func(n int) []db.Event {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	events := make(chan db.Event, 100)
+	go client.WatchRange(ctx, prefix, revision, func(e db.Event, err error) {
+		if err != nil {
+			t.Errorf("unexpected watch error: %v", err)
+			return
+		}
+		events <- e
+	})
+
+	return func(n int) []db.Event {
+		t.Helper()
+		result := []db.Event{}
+		for len(result) < n {
+			select {
+			case e := <-events:
+				result = append(result, e)
+			case <-time.After(time.Second * 5):
+				t.Fatalf("received %d of %d events", len(result), n)
+			}
+		}
+		return result
+	}
+}
+
+func testTxn(t *testing.T, factory Factory) {
+	tests := []struct {
+		name       string
+		conditions func(rev int64) []db.Condition
+		operations []db.Operation
+		wantOk     bool
+		wantErr    bool
+		wantKeys   map[string]string
+	}{
+		{
+			name: "equal revision holds",
+			conditions: func(rev int64) []db.Condition {
+				return []db.Condition{{Key: "/a", Compare: db.COMPARE_EQUAL, Revision: rev}}
+			},
+			operations: []db.Operation{{Type: db.OPERATION_SET, Key: "/a", Value: "2"}},
+			wantOk:     true,
+			wantKeys:   map[string]string{"/a": "2", "/b": "1"},
+		},
+		{
+			name: "equal revision fails",
+			conditions: func(rev int64) []db.Condition {
+				return []db.Condition{{Key: "/a", Compare: db.COMPARE_EQUAL, Revision: rev + 1}}
+			},
+			operations: []db.Operation{{Type: db.OPERATION_SET, Key: "/a", Value: "2"}},
+			wantOk:     false,
+			wantKeys:   map[string]string{"/a": "1", "/b": "1"},
+		},
+		{
+			name: "missing key has revision 0",
+			conditions: func(rev int64) []db.Condition {
+				return []db.Condition{{Key: "/c", Compare: db.COMPARE_EQUAL, Revision: 0}}
+			},
+			operations: []db.Operation{{Type: db.OPERATION_SET, Key: "/c", Value: "1"}},
+			wantOk:     true,
+			wantKeys:   map[string]string{"/a": "1", "/b": "1", "/c": "1"},
+		},
+		{
+			name: "existing key fails create condition",
+			conditions: func(rev int64) []db.Condition {
+				return []db.Condition{{Key: "/a", Compare: db.COMPARE_EQUAL, Revision: 0}}
+			},
+			operations: []db.Operation{{Type: db.OPERATION_SET, Key: "/a", Value: "2"}},
+			wantOk:     false,
+			wantKeys:   map[string]string{"/a": "1", "/b": "1"},
+		},
+		{
+			name: "not equal requires existing key",
+			conditions: func(rev int64) []db.Condition {
+				return []db.Condition{{Key: "/a", Compare: db.COMPARE_NOT_EQUAL, Revision: 0}}
+			},
+			operations: []db.Operation{{Type: db.OPERATION_DELETE, Key: "/a"}},
+			wantOk:     true,
+			wantKeys:   map[string]string{"/b": "1"},
+		},
+		{
+			name: "greater and less",
+			conditions: func(rev int64) []db.Condition {
+				return []db.Condition{
+					{Key: "/a", Compare: db.COMPARE_GREATER, Revision: 0},
+					{Key: "/a", Compare: db.COMPARE_LESS, Revision: rev + 1},
+				}
+			},
+			operations: []db.Operation{{Type: db.OPERATION_DELETE_RANGE, Key: "/"}},
+			wantOk:     true,
+			wantKeys:   map[string]string{},
+		},
+		{
+			name: "one failing condition rejects all operations",
+			conditions: func(rev int64) []db.Condition {
+				return []db.Condition{
+					{Key: "/a", Compare: db.COMPARE_EQUAL, Revision: rev},
+					{Key: "/b", Compare: db.COMPARE_LESS, Revision: 0},
+				}
+			},
+			operations: []db.Operation{
+				{Type: db.OPERATION_SET, Key: "/a", Value: "2"},
+				{Type: db.OPERATION_DELETE, Key: "/b"},
+			},
+			wantOk:   false,
+			wantKeys: map[string]string{"/a": "1", "/b": "1"},
+		},
+		{
+			name: "unknown compare type",
+			conditions: func(rev int64) []db.Condition {
+				return []db.Condition{{Key: "/a", Compare: "~", Revision: rev}}
+			},
+			operations: []db.Operation{{Type: db.OPERATION_SET, Key: "/a", Value: "2"}},
+			wantErr:    true,
+			wantKeys:   map[string]string{"/a": "1", "/b": "1"},
+		},
+		{
+			name:       "unknown lease",
+			conditions: func(rev int64) []db.Condition { return nil },
+			operations: []db.Operation{{Type: db.OPERATION_SET, Key: "/a", Value: "2", Lease: 42}},
+			wantErr:    true,
+			wantKeys:   map[string]string{"/a": "1", "/b": "1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := newClient(t, factory)
+			client.Set(ctx, "/a", "1", 0)
+			client.Set(ctx, "/b", "1", 0)
+			_, rev, _ := client.GetRevision(ctx, "/a")
+
+			ok, err := client.Txn(ctx, tt.conditions(rev), tt.operations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ok != tt.wantOk {
+				t.Errorf("expected txn result %v, got %v", tt.wantOk, ok)
+			}
+
+			kvs, _ := client.GetRange(ctx, "/")
+			if len(kvs) != len(tt.wantKeys) {
+				t.Fatalf("expected keys %v, got %v", tt.wantKeys, kvs)
+			}
+			for key, value := range tt.wantKeys {
+				if kvs[key] != value {
+					t.Errorf("expected '%s' to be '%s', got '%s'", key, value, kvs[key])
+				}
+			}
+		})
+	}
+}
+
+func testTxnSharesRevision(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	client := newClient(t, factory)
+	_, start, _ := client.GetRangeRevision(ctx, "/")
+	next := collect(t, client, "/", start+1)
+
+	ok, err := client.Txn(ctx, nil, []db.Operation{
+		{Type: db.OPERATION_SET, Key: "/b", Value: "1"},
+		{Type: db.OPERATION_SET, Key: "/a", Value: "1"},
+	})
+	if err != nil || !ok {
+		t.Fatalf("txn failed: %v", err)
+	}
+
+	events := next(2)
+	if events[0].Revision != events[1].Revision || events[0].Revision != start+1 {
+		t.Errorf("expected both events at revision %d, got %d and %d",
+			start+1, events[0].Revision, events[1].Revision)
+	}
+	if events[0].Key != "/b" || events[1].Key != "/a" {
+		t.Errorf("expected events in operation order, got '%s', '%s'", events[0].Key, events[1].Key)
+	}
+}
+
+func testCompareAndSet(t *testing.T, factory Factory) {
+	tests := []struct {
+		name      string
+		key       string
+		revision  func(rev int64) int64
+		ttl       int64
+		wantOk    bool
+		wantValue string
+	}{
+		{name: "create missing key", key: "/b", revision: func(int64) int64 { return 0 }, wantOk: true, wantValue: "2"},
+		{name: "create existing key", key: "/a", revision: func(int64) int64 { return 0 }, wantOk: false, wantValue: "1"},
+		{name: "matching revision", key: "/a", revision: func(rev int64) int64 { return rev }, wantOk: true, wantValue: "2"},
+		{name: "stale revision", key: "/a", revision: func(rev int64) int64 { return rev - 1 }, wantOk: false, wantValue: "1"},
+		{name: "ttl expires", key: "/a", revision: func(rev int64) int64 { return rev }, ttl: 5, wantOk: true, wantValue: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clock := &testClock{now: time.Unix(1000, 0)}
+			client, _ := factory(t, clock.Now, 1000)
+			client.Set(ctx, "/x", "0", 0)
+			client.Set(ctx, "/a", "1", 0)
+			_, rev, _ := client.GetRevision(ctx, "/a")
+
+			ok, err := client.CompareAndSet(ctx, tt.key, "2", tt.revision(rev), tt.ttl)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ok != tt.wantOk {
+				t.Errorf("expected compare-and-set result %v, got %v", tt.wantOk, ok)
+			}
+
+			clock.Advance(time.Second * 5)
+			if value, _ := client.Get(ctx, tt.key); value != tt.wantValue {
+				t.Errorf("expected '%s' to be '%s', got '%s'", tt.key, tt.wantValue, value)
+			}
+		})
+	}
+}
+
+func testGrantLease(t *testing.T, factory Factory) {
+	tests := []struct {
+		name    string
+		ttl     int64
+		wantErr bool
+	}{
+		{name: "negative ttl", ttl: -1, wantErr: true},
+		{name: "zero ttl", ttl: 0, wantErr: true},
+		{name: "one second", ttl: 1, wantErr: false},
+		{name: "one minute", ttl: 60, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t, factory)
+			_, err := client.GrantLease(context.Background(), tt.ttl)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func testTTLExpiry(t *testing.T, factory Factory) {
+	tests := []struct {
+		name       string
+		ttl        int64
+		advance    time.Duration
+		wantExists bool
+	}{
+		{name: "no ttl never expires", ttl: 0, advance: time.Hour * 24, wantExists: true},
+		{name: "before expiry", ttl: 10, advance: time.Second * 9, wantExists: true},
+		{name: "at expiry", ttl: 10, advance: time.Second * 10, wantExists: false},
+		{name: "after expiry", ttl: 10, advance: time.Minute, wantExists: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clock := &testClock{now: time.Unix(1000, 0)}
+			client, _ := factory(t, clock.Now, 1000)
+
+			client.Set(ctx, "/a", "1", tt.ttl)
+			clock.Advance(tt.advance)
+
+			_, rev, _ := client.GetRevision(ctx, "/a")
+			if (rev != 0) != tt.wantExists {
+				t.Errorf("expected key to exist: %v", tt.wantExists)
+			}
+		})
+	}
+}
+
+func testTTLExpiryEvent(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	clock := &testClock{now: time.Unix(1000, 0)}
+	client, expire := factory(t, clock.Now, 1000)
+
+	client.Set(ctx, "/a", "1", 5)
+	_, rev, _ := client.GetRangeRevision(ctx, "/")
+	next := collect(t, client, "/", rev+1)
+
+	clock.Advance(time.Second * 5)
+	expire()
+
+	events := next(1)
+	if events[0].Type != db.EVENT_DELETE || events[0].Key != "/a" || events[0].PrevValue != "1" {
+		t.Errorf("expected delete event of '/a' with previous value, got %+v", events[0])
+	}
+}
+
+func testLease(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	clock := &testClock{now: time.Unix(1000, 0)}
+	client, _ := factory(t, clock.Now, 1000)
+
+	lease, err := client.GrantLease(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetLease(ctx, "/a", "1", lease)
+	client.SetLease(ctx, "/b", "1", lease)
+	client.Set(ctx, "/c", "1", 0)
+
+	if err := client.RevokeLease(ctx, lease); err != nil {
+		t.Fatal(err)
+	}
+	kvs, _ := client.GetRange(ctx, "/")
+	if len(kvs) != 1 || kvs["/c"] != "1" {
+		t.Errorf("expected only '/c' to survive the revocation, got %v", kvs)
+	}
+
+	if _, err := client.SetLease(ctx, "/a", "1", lease); err == nil {
+		t.Errorf("expected revoked lease to be rejected")
+	}
+	if err := client.KeepAliveLease(ctx, lease); err == nil {
+		t.Errorf("expected keep alive of revoked lease to fail")
+	}
+}
+
+func testKeepAliveLease(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	clock := &testClock{now: time.Unix(1000, 0)}
+	client, _ := factory(t, clock.Now, 1000)
+
+	lease, _ := client.GrantLease(ctx, 3)
+	client.SetLease(ctx, "/a", "1", lease)
+
+	keepCtx, keepCancel := context.WithCancel(ctx)
+	keepDone := make(chan error)
+	go func() { keepDone <- client.KeepAliveLease(keepCtx, lease) }()
+
+	// the lease is renewed every second (a third of its ttl), it is renewed relative to the test clock.
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Millisecond * 1100)
+		clock.Advance(time.Second * 2)
+		if value, _ := client.Get(ctx, "/a"); value != "1" {
+			t.Fatalf("expected kept alive key to exist after %d renewals", i+1)
+		}
+	}
+	keepCancel()
+	if err := <-keepDone; err != nil {
+		t.Errorf("expected keep alive to stop without error, got %v", err)
+	}
+
+	clock.Advance(time.Second * 3)
+	if value, _ := client.Get(ctx, "/a"); value != "" {
+		t.Errorf("expected key to expire after keep alive stopped")
+	}
+}
+
+func testWatchOrder(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	client := newClient(t, factory)
+	client.Set(ctx, "/x/a", "0", 0)
+	_, start, _ := client.GetRangeRevision(ctx, "/")
+	next := collect(t, client, "/x/", start+1)
+
+	client.Set(ctx, "/x/a", "1", 0)
+	client.Set(ctx, "/y/a", "1", 0)
+	client.Set(ctx, "/x/b", "1", 0)
+	client.Txn(ctx, nil, []db.Operation{
+		{Type: db.OPERATION_SET, Key: "/x/c", Value: "1"},
+		{Type: db.OPERATION_SET, Key: "/x/a", Value: "2"},
+	})
+	client.Delete(ctx, "/x/b")
+	client.DeleteRange(ctx, "/x/")
+
+	want := []db.Event{
+		{Type: db.EVENT_PUT, Key: "/x/a", Value: "1", PrevValue: "0", Revision: start + 1},
+		{Type: db.EVENT_PUT, Key: "/x/b", Value: "1", PrevValue: "", Revision: start + 3},
+		{Type: db.EVENT_PUT, Key: "/x/c", Value: "1", PrevValue: "", Revision: start + 4},
+		{Type: db.EVENT_PUT, Key: "/x/a", Value: "2", PrevValue: "1", Revision: start + 4},
+		{Type: db.EVENT_DELETE, Key: "/x/b", Value: "", PrevValue: "1", Revision: start + 5},
+		{Type: db.EVENT_DELETE, Key: "/x/a", Value: "", PrevValue: "2", Revision: start + 6},
+		{Type: db.EVENT_DELETE, Key: "/x/c", Value: "", PrevValue: "1", Revision: start + 6},
+	}
+	got := next(len(want))
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func testWatchResume(t *testing.T, factory Factory) {
+	tests := []struct {
+		name         string
+		historyLimit int
+		// rangeWatch resumes a range watcher on the prefix of the key instead of a key watcher.
+		rangeWatch bool
+		revision   func(start int64) int64
+		wantErr    bool
+		wantValues []string
+	}{
+		{
+			name:         "resume within history",
+			historyLimit: 10,
+			revision:     func(start int64) int64 { return start + 3 },
+			wantValues:   []string{"3", "4", "5"},
+		},
+		{
+			name:         "resume at oldest retained revision",
+			historyLimit: 2,
+			revision:     func(start int64) int64 { return start + 4 },
+			wantValues:   []string{"4", "5"},
+		},
+		{
+			name:         "compacted revision",
+			historyLimit: 2,
+			revision:     func(start int64) int64 { return start + 3 },
+			wantErr:      true,
+		},
+		{
+			name:         "resume range within history",
+			historyLimit: 10,
+			rangeWatch:   true,
+			revision:     func(start int64) int64 { return start + 2 },
+			wantValues:   []string{"2", "3", "4", "5"},
+		},
+		{
+			name:         "compacted range revision",
+			historyLimit: 2,
+			rangeWatch:   true,
+			revision:     func(start int64) int64 { return start + 1 },
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			client, _ := factory(t, time.Now, tt.historyLimit)
+			_, start, _ := client.GetRangeRevision(ctx, "/")
+			for _, value := range []string{"1", "2", "3", "4", "5"} {
+				client.Set(ctx, "/a", value, 0)
+			}
+
+			values := make(chan string, 10)
+			watchErr := make(chan error, 1)
+			watch := client.Watch
+			if tt.rangeWatch {
+				watch = client.WatchRange
+			}
+			go func() {
+				watchErr <- watch(ctx, "/a", tt.revision(start), func(e db.Event, err error) {
+					values <- e.Value
+				})
+			}()
+
+			if tt.wantErr {
+				select {
+				case err := <-watchErr:
+					if err == nil || !strings.Contains(err.Error(), "compacted") {
+						t.Errorf("expected compaction error, got %v", err)
+					}
+				case <-time.After(time.Second * 5):
+					t.Fatal("expected watch to fail")
+				}
+				return
+			}
+			for _, want := range tt.wantValues {
+				select {
+				case got := <-values:
+					if got != want {
+						t.Errorf("expected value '%s', got '%s'", want, got)
+					}
+				case <-time.After(time.Second * 5):
+					t.Fatalf("missing replayed value '%s'", want)
+				}
+			}
+		})
+	}
+}
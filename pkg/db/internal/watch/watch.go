@@ -0,0 +1,175 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// watch provides the watch event distribution and the condition evaluation shared by the embedded
+// database backends (memdb, boltdb). It mimics the etcd semantics of ordered watch events and
+// watchers resuming from older revisions.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"cthul.io/cthul/pkg/db"
+)
+
+// Compare evaluates a revision condition.
+func Compare(revision int64, compareType db.COMPARE_TYPE, target int64) (bool, error) {
+	switch compareType {
+	case db.COMPARE_EQUAL:
+		return revision == target, nil
+	case db.COMPARE_NOT_EQUAL:
+		return revision != target, nil
+	case db.COMPARE_GREATER:
+		return revision > target, nil
+	case db.COMPARE_LESS:
+		return revision < target, nil
+	default:
+		return false, fmt.Errorf("unknown compare type '%s'", compareType)
+	}
+}
+
+// Hub records the event history and distributes the events to the registered watchers.
+// The hub is not synchronized, the backend must serialize all calls (usually by holding its store lock).
+// Emitting events while holding the store lock ensures all watchers receive the events in the exact
+// same order as they were applied to the store.
+type Hub struct {
+	// historyLimit specifies the number of events retained to resume watchers from older revisions.
+	historyLimit int
+	history      []db.Event
+	watchers     map[*Watcher]struct{}
+}
+
+// NewHub creates a hub that retains the specified number of events.
+func NewHub(historyLimit int) *Hub {
+	return &Hub{
+		historyLimit: historyLimit,
+		history:      []db.Event{},
+		watchers:     map[*Watcher]struct{}{},
+	}
+}
+
+// Emit records the event in the history and queues it on every watcher that matches its key.
+func (h *Hub) Emit(e db.Event) {
+	h.history = append(h.history, e)
+	if len(h.history) > h.historyLimit {
+		h.history = h.history[len(h.history)-h.historyLimit:]
+	}
+
+	for w := range h.watchers {
+		if w.match(e.Key) {
+			w.push(e)
+		}
+	}
+}
+
+// Register creates a watcher for the key (or all keys in the prefix) and registers it on the hub.
+// If a start revision is provided, matching events from the history are queued before live events.
+// The oldest revision specifies the oldest revision that can be resumed if the history is empty,
+// older revisions fail with a compaction error.
+func (h *Hub) Register(key string, prefix bool, revision, oldestRevision int64) (*Watcher, error) {
+	w := &Watcher{
+		key:       key,
+		prefix:    prefix,
+		queueLock: sync.Mutex{},
+		queue:     []db.Event{},
+		notify:    make(chan struct{}, 1),
+	}
+	if revision > 0 {
+		if len(h.history) > 0 {
+			oldestRevision = h.history[0].Revision
+		}
+		if revision < oldestRevision {
+			return nil, fmt.Errorf("required revision %d has been compacted", revision)
+		}
+		for _, e := range h.history {
+			if e.Revision >= revision && w.match(e.Key) {
+				w.push(e)
+			}
+		}
+	}
+	h.watchers[w] = struct{}{}
+	return w, nil
+}
+
+// Unregister removes the watcher from the hub, no further events are queued on it.
+func (h *Hub) Unregister(w *Watcher) {
+	delete(h.watchers, w)
+}
+
+// Watcher holds the state of a single Watch / WatchRange call.
+// Events are queued without blocking the store and are drained by the goroutine running the watch cycle.
+type Watcher struct {
+	key    string
+	prefix bool
+
+	queueLock sync.Mutex
+	queue     []db.Event
+
+	// notify signals the watch cycle that new events are queued.
+	notify chan struct{}
+}
+
+// match checks if the key is observed by the watcher.
+func (w *Watcher) match(key string) bool {
+	if w.prefix {
+		return strings.HasPrefix(key, w.key)
+	}
+	return key == w.key
+}
+
+// push appends an event to the queue and signals the watch cycle.
+func (w *Watcher) push(e db.Event) {
+	w.queueLock.Lock()
+	w.queue = append(w.queue, e)
+	w.queueLock.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// pop removes and returns all queued events.
+func (w *Watcher) pop() []db.Event {
+	w.queueLock.Lock()
+	defer w.queueLock.Unlock()
+	events := w.queue
+	w.queue = []db.Event{}
+	return events
+}
+
+// Deliver passes the queued events sequentially to the event function until the context or the
+// client context (rootCtx of the backend) is done. The watcher must be unregistered by the caller afterwards.
+func (w *Watcher) Deliver(ctx, clientCtx context.Context, eventFunc func(db.Event, error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-clientCtx.Done():
+			return
+		case <-w.notify:
+			for _, e := range w.pop() {
+				eventFunc(e, nil)
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package db
+
+import (
+	"context"
+	"sync"
+)
+
+// Lease manages a database lease that is granted once and kept alive in the background.
+// It is used by components that periodically write keys which must disappear when the component crashes
+// (e.g. node registrations or the leader key). All keys attached to the lease expire together with it.
+type Lease struct {
+	client Client
+	ttl    int64
+
+	leaseLock   sync.Mutex
+	leaseId     int64
+	leaseCancel context.CancelFunc
+	// leaseWg tracks the keep alive routine of the lease.
+	leaseWg sync.WaitGroup
+}
+
+// NewLease creates a new lease manager, the lease itself is granted on the first Acquire() call.
+func NewLease(client Client, ttl int64) *Lease {
+	return &Lease{
+		client:      client,
+		ttl:         ttl,
+		leaseLock:   sync.Mutex{},
+		leaseId:     0,
+		leaseCancel: nil,
+		leaseWg:     sync.WaitGroup{},
+	}
+}
+
+// Acquire returns the id of the active lease. If no lease is active, a new lease is granted and kept alive
+// in the background until Release() is called. If the lease is lost (e.g. it expired because the database
+// was not reachable for longer than the ttl), the next Acquire() call grants a new lease.
+func (l *Lease) Acquire(ctx context.Context) (int64, error) {
+	l.leaseLock.Lock()
+	defer l.leaseLock.Unlock()
+
+	if l.leaseId != 0 {
+		return l.leaseId, nil
+	}
+
+	leaseId, err := l.client.GrantLease(ctx, l.ttl)
+	if err != nil {
+		return 0, err
+	}
+	keepAliveCtx, keepAliveCtxCancel := context.WithCancel(context.Background())
+	l.leaseId, l.leaseCancel = leaseId, keepAliveCtxCancel
+
+	l.leaseWg.Add(1)
+	go func() {
+		defer l.leaseWg.Done()
+		err := l.client.KeepAliveLease(keepAliveCtx, leaseId)
+		if err != nil {
+			l.leaseLock.Lock()
+			if l.leaseId == leaseId {
+				l.leaseId, l.leaseCancel = 0, nil
+			}
+			l.leaseLock.Unlock()
+			keepAliveCtxCancel()
+		}
+	}()
+
+	return leaseId, nil
+}
+
+// Release stops the keep alive routine and revokes the active lease (if any).
+// Revoking the lease immediately removes all keys attached to it.
+func (l *Lease) Release(ctx context.Context) error {
+	l.leaseLock.Lock()
+	leaseId, leaseCancel := l.leaseId, l.leaseCancel
+	l.leaseId, l.leaseCancel = 0, nil
+	l.leaseLock.Unlock()
+
+	if leaseCancel != nil {
+		leaseCancel()
+	}
+	l.leaseWg.Wait()
+
+	if leaseId == 0 {
+		return nil
+	}
+	return l.client.RevokeLease(ctx, leaseId)
+}
@@ -0,0 +1,561 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/db/internal/watch"
+)
+
+// Client provides an in-memory Client implementation.
+// It mimics the etcd semantics (prefix ranges, leases, ttl expiry and ordered watch events) without requiring
+// an external database, which allows running controllers, operators and the scheduler fully in-process.
+// The state is not persisted and is lost when the client is terminated.
+type Client struct {
+	// rootCtx is active for the full lifetime of the client.
+	// closing it stops the expiry routine and all active watchers.
+	rootCtx       context.Context
+	rootCtxCancel context.CancelFunc
+
+	// finChan is used to send the absolute exist signal
+	// if the channel emits, this indicates that the expiry routine is fully cleaned up.
+	finChan chan struct{}
+
+	// clock is used to determine the current time for ttl evaluation.
+	clock func() time.Time
+	// expiryInterval specifies the interval in which expired keys are removed in the background.
+	expiryInterval time.Duration
+
+	// historyLimit specifies the number of events retained to resume watchers from older revisions.
+	historyLimit int
+
+	// storeLock protects the store, the leases, the revision counter and the watch hub.
+	// Every modification emits its events while holding the lock, this ensures all watchers receive
+	// the events in the exact same order as they were applied to the store.
+	storeLock sync.Mutex
+	store     map[string]*entry
+	leases    map[int64]*lease
+	leaseId   int64
+	revision  int64
+	watchHub  *watch.Hub
+}
+
+// entry holds a single kv in the store.
+type entry struct {
+	value       string
+	modRevision int64
+	// lease holds the id of the lease the kv is attached to. Zero means the kv never expires.
+	lease int64
+}
+
+// lease holds a single lease. Like in etcd, kvs written with a ttl are attached to an implicit lease.
+type lease struct {
+	ttl int64
+	// expiry holds the point in time the lease and all attached kvs expire.
+	expiry time.Time
+}
+
+type Option func(*Client)
+
+// New creates a new in-memory client and starts its background expiry routine.
+func New(opts ...Option) *Client {
+	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
+	client := &Client{
+		rootCtx:        rootCtx,
+		rootCtxCancel:  rootCtxCancel,
+		finChan:        make(chan struct{}),
+		clock:          time.Now,
+		expiryInterval: time.Second,
+		historyLimit:   1000,
+		storeLock:      sync.Mutex{},
+		store:          map[string]*entry{},
+		leases:         map[int64]*lease{},
+		leaseId:        0,
+		revision:       0,
+		watchHub:       nil,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+	client.watchHub = watch.NewHub(client.historyLimit)
+
+	go func() {
+		defer close(client.finChan)
+		for {
+			select {
+			case <-client.rootCtx.Done():
+				return
+			case <-time.After(client.expiryInterval):
+				client.Expire()
+			}
+		}
+	}()
+
+	return client
+}
+
+// WithClock defines a custom clock used to evaluate ttls. This allows tests to control the expiry of keys
+// by advancing the clock and calling Expire() (or any other operation) afterwards.
+func WithClock(clock func() time.Time) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// WithExpiryInterval defines a custom interval for the background routine that removes expired keys.
+// Expired keys are also removed lazily on every operation, so this only affects watchers that wait
+// for an expiry event without other operations happening on the client.
+func WithExpiryInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.expiryInterval = interval
+	}
+}
+
+// WithHistoryLimit defines a custom number of events that are retained to resume watchers.
+// Watchers requesting a revision older than the retained history fail with a compaction error.
+func WithHistoryLimit(limit int) Option {
+	return func(c *Client) {
+		c.historyLimit = limit
+	}
+}
+
+// CheckEndpointHealth checks if the client is still operational.
+func (c *Client) CheckEndpointHealth(ctx context.Context) error {
+	select {
+	case <-c.rootCtx.Done():
+		return fmt.Errorf("client is terminated")
+	default:
+		return nil
+	}
+}
+
+// Health reports whether the client is operational. It matches the health reporting of the etcd client,
+// the check is cheap enough to be evaluated synchronously.
+func (c *Client) Health() error {
+	return c.CheckEndpointHealth(c.rootCtx)
+}
+
+// Get returns a single key. If the key is empty or not existent, an empty string is returned.
+func (c *Client) Get(ctx context.Context, key string) (string, error) {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	c.expire()
+
+	if entry, ok := c.store[key]; ok {
+		return entry.value, nil
+	}
+	return "", nil
+}
+
+// GetRange returns a kv map with all keys that match the prefix.
+func (c *Client) GetRange(ctx context.Context, prefix string) (map[string]string, error) {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	c.expire()
+
+	kvMap := map[string]string{}
+	for key, entry := range c.store {
+		if strings.HasPrefix(key, prefix) {
+			kvMap[key] = entry.value
+		}
+	}
+	return kvMap, nil
+}
+
+// GetRangeRevision returns a kv map with all keys that match the prefix and the current store revision.
+func (c *Client) GetRangeRevision(ctx context.Context, prefix string) (map[string]string, int64, error) {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	c.expire()
+
+	kvMap := map[string]string{}
+	for key, entry := range c.store {
+		if strings.HasPrefix(key, prefix) {
+			kvMap[key] = entry.value
+		}
+	}
+	return kvMap, c.revision, nil
+}
+
+// GetRevision returns a single key and its modification revision.
+// If the key is not existent, an empty string and revision 0 is returned.
+func (c *Client) GetRevision(ctx context.Context, key string) (string, int64, error) {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	c.expire()
+
+	if entry, ok := c.store[key]; ok {
+		return entry.value, entry.modRevision, nil
+	}
+	return "", 0, nil
+}
+
+// Set upserts a kv to the store and returns the previous value. If ttl is set to 0 the kv never expires.
+func (c *Client) Set(ctx context.Context, key, value string, ttl int64) (string, error) {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	c.expire()
+
+	prevValue := ""
+	if prev, ok := c.store[key]; ok {
+		prevValue = prev.value
+	}
+
+	leaseId := int64(0)
+	if ttl != 0 {
+		leaseId = c.grant(ttl)
+	}
+	c.revision++
+	c.put(key, value, leaseId)
+	return prevValue, nil
+}
+
+// SetLease upserts a kv attached to the lease and returns the previous value.
+func (c *Client) SetLease(ctx context.Context, key, value string, leaseId int64) (string, error) {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	c.expire()
+
+	if _, ok := c.leases[leaseId]; !ok {
+		return "", fmt.Errorf("lease %d not found", leaseId)
+	}
+
+	prevValue := ""
+	if prev, ok := c.store[key]; ok {
+		prevValue = prev.value
+	}
+
+	c.revision++
+	c.put(key, value, leaseId)
+	return prevValue, nil
+}
+
+// GrantLease creates a new lease with the specified ttl (seconds) and returns the lease id.
+// The ttl must be at least one second, shorter leases could not be renewed by KeepAliveLease().
+func (c *Client) GrantLease(ctx context.Context, ttl int64) (int64, error) {
+	if ttl < 1 {
+		return 0, fmt.Errorf("lease ttl must be at least 1 second")
+	}
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	c.expire()
+
+	return c.grant(ttl), nil
+}
+
+// KeepAliveLease starts a blocking routine that renews the lease until the context is cancelled.
+// The lease is renewed in a third of its ttl, if it cannot be renewed anymore (expired or revoked),
+// an error is returned.
+func (c *Client) KeepAliveLease(ctx context.Context, leaseId int64) error {
+	for {
+		c.storeLock.Lock()
+		c.expire()
+		l, ok := c.leases[leaseId]
+		if !ok {
+			c.storeLock.Unlock()
+			return fmt.Errorf("lease %d cannot be renewed anymore", leaseId)
+		}
+		l.expiry = c.clock().Add(time.Second * time.Duration(l.ttl))
+		interval := time.Second * time.Duration(l.ttl) / 3
+		c.storeLock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.rootCtx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// RevokeLease revokes the lease, all kvs attached to it are removed.
+func (c *Client) RevokeLease(ctx context.Context, leaseId int64) error {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	c.expire()
+
+	if _, ok := c.leases[leaseId]; !ok {
+		return fmt.Errorf("lease %d not found", leaseId)
+	}
+	c.revoke(leaseId)
+	return nil
+}
+
+// CompareAndSet upserts a kv to the store if the modification revision of the key matches the revision.
+// Revision 0 requires the key to not exist. Returns false if the revision did not match.
+func (c *Client) CompareAndSet(ctx context.Context, key, value string, revision, ttl int64) (bool, error) {
+	return c.Txn(ctx, []db.Condition{{
+		Key: key, Compare: db.COMPARE_EQUAL, Revision: revision,
+	}}, []db.Operation{{
+		Type: db.OPERATION_SET, Key: key, Value: value, TTL: ttl,
+	}})
+}
+
+// Txn executes all operations atomically if every condition holds. Returns false if a condition did not hold.
+// Like etcd, all modifications of the transaction share one revision.
+func (c *Client) Txn(ctx context.Context, conditions []db.Condition, operations []db.Operation) (bool, error) {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	c.expire()
+
+	for _, operation := range operations {
+		switch operation.Type {
+		case db.OPERATION_SET:
+			if _, ok := c.leases[operation.Lease]; operation.Lease != 0 && !ok {
+				return false, fmt.Errorf("lease %d not found", operation.Lease)
+			}
+		case db.OPERATION_DELETE, db.OPERATION_DELETE_RANGE:
+		default:
+			return false, fmt.Errorf("unknown operation type '%s'", operation.Type)
+		}
+	}
+
+	for _, condition := range conditions {
+		revision := int64(0)
+		if entry, ok := c.store[condition.Key]; ok {
+			revision = entry.modRevision
+		}
+		ok, err := watch.Compare(revision, condition.Compare, condition.Revision)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+
+	// the revision is only increased if the transaction actually modifies the store.
+	c.revision++
+	modified := false
+	for _, operation := range operations {
+		switch operation.Type {
+		case db.OPERATION_SET:
+			leaseId := operation.Lease
+			if leaseId == 0 && operation.TTL != 0 {
+				leaseId = c.grant(operation.TTL)
+			}
+			c.put(operation.Key, operation.Value, leaseId)
+			modified = true
+		case db.OPERATION_DELETE:
+			if c.remove(operation.Key) {
+				modified = true
+			}
+		case db.OPERATION_DELETE_RANGE:
+			if c.removeRange(operation.Key) {
+				modified = true
+			}
+		}
+	}
+	if !modified {
+		c.revision--
+	}
+	return true, nil
+}
+
+// Delete deletes one specific kv by key.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	c.expire()
+
+	if _, ok := c.store[key]; !ok {
+		return nil
+	}
+	c.revision++
+	c.remove(key)
+	return nil
+}
+
+// DeleteRange deletes all kvs that match the prefix.
+// Like etcd, all deletions share one revision and are emitted in lexical key order.
+func (c *Client) DeleteRange(ctx context.Context, prefix string) error {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	c.expire()
+
+	c.revision++
+	if !c.removeRange(prefix) {
+		c.revision--
+	}
+	return nil
+}
+
+// put writes the kv with the current revision and emits its event, the caller must hold the storeLock.
+func (c *Client) put(key, value string, leaseId int64) {
+	newEntry := &entry{value: value, modRevision: c.revision, lease: leaseId}
+	prevValue := ""
+	if prev, ok := c.store[key]; ok {
+		prevValue = prev.value
+	}
+	c.store[key] = newEntry
+	c.watchHub.Emit(db.Event{
+		Type: db.EVENT_PUT, Key: key, Value: value, PrevValue: prevValue, Revision: c.revision,
+	})
+}
+
+// remove deletes the kv and emits its event, the caller must hold the storeLock.
+// Returns false if the key did not exist.
+func (c *Client) remove(key string) bool {
+	prev, ok := c.store[key]
+	if !ok {
+		return false
+	}
+	delete(c.store, key)
+	c.watchHub.Emit(db.Event{
+		Type: db.EVENT_DELETE, Key: key, Value: "", PrevValue: prev.value, Revision: c.revision,
+	})
+	return true
+}
+
+// removeRange deletes all kvs matching the prefix in lexical key order, the caller must hold the storeLock.
+// Returns false if no key matched.
+func (c *Client) removeRange(prefix string) bool {
+	keys := []string{}
+	for key := range c.store {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		c.remove(key)
+	}
+	return len(keys) > 0
+}
+
+// Watch starts a blocking listener that reacts to changes on the specified key.
+// The event function is triggered on every event, containing the event or an error on failure.
+// Events are delivered from the specified revision on (0 = current revision).
+// Stop the watcher by cancelling the context.
+func (c *Client) Watch(ctx context.Context, key string, revision int64, event func(db.Event, error)) error {
+	return c.startWatchCycle(ctx, key, false, revision, event)
+}
+
+// WatchRange starts a blocking listener that reacts to changes on keys in the specified prefix.
+// The event function is triggered on every event, containing the event or an error on failure.
+// Events are delivered from the specified revision on (0 = current revision).
+// Stop the watcher by cancelling the context.
+func (c *Client) WatchRange(ctx context.Context, prefix string, revision int64, event func(db.Event, error)) error {
+	return c.startWatchCycle(ctx, prefix, true, revision, event)
+}
+
+// startWatchCycle registers the watcher on the client and delivers its events until the context is cancelled
+// or the client is terminated. Events are delivered sequentially in the order they were applied to the store.
+// If a start revision is provided, matching events from the history are replayed before live events.
+func (c *Client) startWatchCycle(
+	ctx context.Context, key string, prefix bool, revision int64, eventFunc func(db.Event, error),
+) error {
+	c.storeLock.Lock()
+	w, err := c.watchHub.Register(key, prefix, revision, 0)
+	c.storeLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		c.storeLock.Lock()
+		c.watchHub.Unregister(w)
+		c.storeLock.Unlock()
+	}()
+
+	w.Deliver(ctx, c.rootCtx, eventFunc)
+	return nil
+}
+
+// Expire revokes all leases that expired according to the client clock and emits the events of their kvs.
+// Expiry is also performed lazily before every operation, calling Expire is only required to notify
+// watchers about expired keys immediately.
+func (c *Client) Expire() {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	c.expire()
+}
+
+// expire revokes all expired leases, the caller must hold the storeLock.
+// Leases are expired in order of their expiry time, every lease that removes kvs gets a dedicated revision.
+func (c *Client) expire() {
+	now := c.clock()
+	expired := []int64{}
+	for id, l := range c.leases {
+		if !l.expiry.After(now) {
+			expired = append(expired, id)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		iExpiry, jExpiry := c.leases[expired[i]].expiry, c.leases[expired[j]].expiry
+		if iExpiry.Equal(jExpiry) {
+			return expired[i] < expired[j]
+		}
+		return iExpiry.Before(jExpiry)
+	})
+
+	for _, id := range expired {
+		c.revoke(id)
+	}
+}
+
+// grant creates a new lease and returns its id, the caller must hold the storeLock.
+func (c *Client) grant(ttl int64) int64 {
+	c.leaseId++
+	c.leases[c.leaseId] = &lease{
+		ttl:    ttl,
+		expiry: c.clock().Add(time.Second * time.Duration(ttl)),
+	}
+	return c.leaseId
+}
+
+// revoke removes the lease and all kvs attached to it in lexical key order, the caller must hold the storeLock.
+func (c *Client) revoke(leaseId int64) {
+	delete(c.leases, leaseId)
+
+	keys := []string{}
+	for key, entry := range c.store {
+		if entry.lease == leaseId {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) < 1 {
+		return
+	}
+	sort.Strings(keys)
+
+	c.revision++
+	for _, key := range keys {
+		c.remove(key)
+	}
+}
+
+// Terminate stops the expiry routine and all active watchers.
+// The context is only provided to match the cthul terminate pattern.
+func (c *Client) Terminate(ctx context.Context) error {
+	c.rootCtxCancel()
+	<-c.finChan
+	return nil
+}
@@ -0,0 +1,38 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package memdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/db/internal/dbtest"
+)
+
+func TestClient(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, clock func() time.Time, historyLimit int) (db.Client, func()) {
+		// expiry is triggered by the tests, the background routine would race with the test clock.
+		client := New(WithClock(clock), WithHistoryLimit(historyLimit), WithExpiryInterval(time.Hour))
+		t.Cleanup(func() { client.Terminate(context.Background()) })
+		return client, client.Expire
+	})
+}
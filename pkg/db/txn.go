@@ -0,0 +1,56 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package db
+
+type COMPARE_TYPE string
+
+const (
+	COMPARE_EQUAL     COMPARE_TYPE = "="
+	COMPARE_NOT_EQUAL COMPARE_TYPE = "!="
+	COMPARE_GREATER   COMPARE_TYPE = ">"
+	COMPARE_LESS      COMPARE_TYPE = "<"
+)
+
+// Condition describes a transaction guard that compares the modification revision of a key.
+// Keys that do not exist have revision 0 (e.g. {Key, COMPARE_NOT_EQUAL, 0} requires the key to exist).
+type Condition struct {
+	Key      string
+	Compare  COMPARE_TYPE
+	Revision int64
+}
+
+type OPERATION_TYPE string
+
+const (
+	OPERATION_SET          OPERATION_TYPE = "set"
+	OPERATION_DELETE       OPERATION_TYPE = "delete"
+	OPERATION_DELETE_RANGE OPERATION_TYPE = "delete_range"
+)
+
+// Operation describes a single mutation executed by a transaction.
+// Value, TTL and Lease are only used by OPERATION_SET, OPERATION_DELETE_RANGE interprets the key as prefix.
+// If Lease is set, the kv is attached to the lease and TTL is ignored.
+type Operation struct {
+	Type  OPERATION_TYPE
+	Key   string
+	Value string
+	TTL   int64
+	Lease int64
+}
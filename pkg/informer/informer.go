@@ -0,0 +1,373 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// informer provides a local cache of a database prefix that is kept up to date by a watcher.
+// Components that frequently list resources (e.g. the scheduler or the api) read from the cache instead of
+// querying the database on every call, which keeps the database load independent of the request rate.
+package informer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/workqueue"
+)
+
+// IndexFunc returns the index values of a kv, kvs that are not part of the index return no values.
+type IndexFunc func(key, value string) []string
+
+// Handler is called for every change of the cache. Handlers are called sequentially in the order the
+// changes are applied, they must not block for a long time as this delays subsequent updates.
+type Handler func(db.Event)
+
+// Informer caches all kvs under a prefix and keeps them up to date with an incremental watcher.
+// If the watcher fails, the prefix is listed again and the difference is emitted to the handlers,
+// this ensures that no change (including deletions) is lost even if the watched revision was compacted.
+type Informer struct {
+	rootCtx       context.Context
+	rootCtxCancel context.CancelFunc
+	finChan       chan struct{}
+
+	client db.Client
+	logger *slog.Logger
+	prefix string
+
+	// retryBase specifies the delay before the first relist after a failure, it is doubled on every attempt.
+	retryBase time.Duration
+	// retryMax specifies the maximum delay between two relists.
+	retryMax time.Duration
+
+	// storeLock protects the store, the indexes and the revision.
+	storeLock sync.RWMutex
+	store     map[string]string
+	revision  int64
+	indexers  map[string]IndexFunc
+	// indexes holds the keys of every index value (index name -> index value -> keys).
+	indexes map[string]map[string]map[string]struct{}
+
+	handlers []Handler
+
+	// syncedChan is closed as soon as the initial list was loaded into the cache.
+	syncedOnce sync.Once
+	syncedChan chan struct{}
+
+	// healthErr holds the error of the last failed inform cycle (nil = the cache is up to date).
+	healthLock sync.Mutex
+	healthErr  error
+}
+
+type Option func(*Informer)
+
+func New(logger *slog.Logger, client db.Client, prefix string, opts ...Option) *Informer {
+	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
+	informer := &Informer{
+		rootCtx:       rootCtx,
+		rootCtxCancel: rootCtxCancel,
+		finChan:       make(chan struct{}),
+		client:        client,
+		logger:        logger.WithGroup("informer"),
+		prefix:        prefix,
+		retryBase:     time.Second,
+		retryMax:      time.Second * 30,
+		storeLock:     sync.RWMutex{},
+		store:         map[string]string{},
+		revision:      0,
+		indexers:      map[string]IndexFunc{},
+		indexes:       map[string]map[string]map[string]struct{}{},
+		handlers:      []Handler{},
+		syncedOnce:    sync.Once{},
+		syncedChan:    make(chan struct{}),
+		healthLock:    sync.Mutex{},
+		healthErr:     nil,
+	}
+
+	for _, opt := range opts {
+		opt(informer)
+	}
+
+	return informer
+}
+
+// WithIndex adds an index to the informer. Keys can then be looked up by index value with ByIndex().
+func WithIndex(name string, index IndexFunc) Option {
+	return func(i *Informer) {
+		i.indexers[name] = index
+		i.indexes[name] = map[string]map[string]struct{}{}
+	}
+}
+
+// WithHandler adds a change notification handler to the informer.
+func WithHandler(handler Handler) Option {
+	return func(i *Informer) {
+		i.handlers = append(i.handlers, handler)
+	}
+}
+
+// WithRetryBackoff defines a custom backoff for relisting the prefix after the watcher failed.
+func WithRetryBackoff(base, max time.Duration) Option {
+	return func(i *Informer) {
+		i.retryBase = base
+		i.retryMax = max
+	}
+}
+
+// ServeAndDetach starts the informer in a detached goroutine.
+func (i *Informer) ServeAndDetach() {
+	go func() {
+		defer close(i.finChan)
+		i.run()
+	}()
+}
+
+// run lists the prefix and watches it from the listed revision on. On failure the cycle is restarted.
+func (i *Informer) run() {
+	attempts := int64(0)
+	for {
+		err := i.relist(i.rootCtx)
+		if err == nil {
+			attempts = 0
+			i.setHealth(nil)
+			err = i.client.WatchRange(i.rootCtx, i.prefix, i.currentRevision()+1, func(event db.Event, err error) {
+				if err != nil {
+					i.logger.Error(fmt.Sprintf("failed to watch '%s': %s", i.prefix, err.Error()))
+					return
+				}
+				i.apply(event)
+			})
+			if err == nil {
+				err = fmt.Errorf("watch closed unexpectedly")
+			}
+		}
+		if i.rootCtx.Err() != nil {
+			return
+		}
+
+		attempts++
+		i.setHealth(fmt.Errorf("failed to inform '%s': %w", i.prefix, err))
+		i.logger.Error(fmt.Sprintf(
+			"failed to inform '%s': %s; relisting...", i.prefix, err.Error(),
+		), slog.Int64("attempts", attempts))
+
+		select {
+		case <-i.rootCtx.Done():
+			return
+		case <-time.After(workqueue.Backoff(i.retryBase, i.retryMax, attempts)):
+		}
+	}
+}
+
+// relist replaces the cache with the current state of the prefix and emits the difference to the handlers.
+func (i *Informer) relist(ctx context.Context) error {
+	kvs, revision, err := i.client.GetRangeRevision(ctx, i.prefix)
+	if err != nil {
+		return err
+	}
+
+	events := []db.Event{}
+	i.storeLock.Lock()
+	for key, value := range i.store {
+		if _, ok := kvs[key]; !ok {
+			events = append(events, db.Event{
+				Type: db.EVENT_DELETE, Key: key, Value: "", PrevValue: value, Revision: revision,
+			})
+			i.unindex(key, value)
+			delete(i.store, key)
+		}
+	}
+	for key, value := range kvs {
+		prevValue, ok := i.store[key]
+		if ok && prevValue == value {
+			continue
+		}
+		events = append(events, db.Event{
+			Type: db.EVENT_PUT, Key: key, Value: value, PrevValue: prevValue, Revision: revision,
+		})
+		if ok {
+			i.unindex(key, prevValue)
+		}
+		i.store[key] = value
+		i.index(key, value)
+	}
+	i.revision = revision
+	i.storeLock.Unlock()
+
+	i.syncedOnce.Do(func() {
+		close(i.syncedChan)
+	})
+
+	for _, event := range events {
+		i.notify(event)
+	}
+	return nil
+}
+
+// apply applies a watch event to the cache and emits it to the handlers.
+func (i *Informer) apply(event db.Event) {
+	i.storeLock.Lock()
+	if event.Revision <= i.revision && event.Revision != 0 {
+		i.storeLock.Unlock()
+		return
+	}
+	if prevValue, ok := i.store[event.Key]; ok {
+		i.unindex(event.Key, prevValue)
+	}
+	if event.Type == db.EVENT_DELETE {
+		delete(i.store, event.Key)
+	} else {
+		i.store[event.Key] = event.Value
+		i.index(event.Key, event.Value)
+	}
+	// revisions are only increased, events of the same transaction share one revision.
+	i.revision = max(i.revision, event.Revision-1)
+	i.storeLock.Unlock()
+
+	i.notify(event)
+}
+
+// notify calls all handlers with the event.
+func (i *Informer) notify(event db.Event) {
+	for _, handler := range i.handlers {
+		handler(event)
+	}
+}
+
+// index adds the kv to all indexes, the storeLock must be held.
+func (i *Informer) index(key, value string) {
+	for name, indexer := range i.indexers {
+		for _, indexValue := range indexer(key, value) {
+			keys, ok := i.indexes[name][indexValue]
+			if !ok {
+				keys = map[string]struct{}{}
+				i.indexes[name][indexValue] = keys
+			}
+			keys[key] = struct{}{}
+		}
+	}
+}
+
+// unindex removes the kv from all indexes, the storeLock must be held.
+func (i *Informer) unindex(key, value string) {
+	for name, indexer := range i.indexers {
+		for _, indexValue := range indexer(key, value) {
+			delete(i.indexes[name][indexValue], key)
+			if len(i.indexes[name][indexValue]) == 0 {
+				delete(i.indexes[name], indexValue)
+			}
+		}
+	}
+}
+
+// currentRevision returns the revision the cache is up to date with.
+func (i *Informer) currentRevision() int64 {
+	i.storeLock.RLock()
+	defer i.storeLock.RUnlock()
+	return i.revision
+}
+
+// Synced returns true if the initial list was loaded into the cache.
+func (i *Informer) Synced() bool {
+	select {
+	case <-i.syncedChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// Health reports whether the cache is synced and up to date. Returns an error if the initial list was not
+// loaded yet or if the last inform cycle failed (until the prefix is relisted successfully).
+func (i *Informer) Health() error {
+	if !i.Synced() {
+		return fmt.Errorf("cache of '%s' is not synced", i.prefix)
+	}
+	i.healthLock.Lock()
+	defer i.healthLock.Unlock()
+	return i.healthErr
+}
+
+// setHealth updates the health error reported by Health().
+func (i *Informer) setHealth(err error) {
+	i.healthLock.Lock()
+	defer i.healthLock.Unlock()
+	i.healthErr = err
+}
+
+// WaitForSync blocks until the initial list was loaded into the cache or the context is cancelled.
+func (i *Informer) WaitForSync(ctx context.Context) error {
+	select {
+	case <-i.syncedChan:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Get returns the cached value of the key. Returns false if the key does not exist.
+func (i *Informer) Get(key string) (string, bool) {
+	i.storeLock.RLock()
+	defer i.storeLock.RUnlock()
+	value, ok := i.store[key]
+	return value, ok
+}
+
+// List returns a copy of all cached kvs.
+func (i *Informer) List() map[string]string {
+	i.storeLock.RLock()
+	defer i.storeLock.RUnlock()
+	kvs := make(map[string]string, len(i.store))
+	for key, value := range i.store {
+		kvs[key] = value
+	}
+	return kvs
+}
+
+// ByIndex returns all cached kvs with the specified index value.
+func (i *Informer) ByIndex(name, indexValue string) (map[string]string, error) {
+	i.storeLock.RLock()
+	defer i.storeLock.RUnlock()
+	index, ok := i.indexes[name]
+	if !ok {
+		return nil, fmt.Errorf("index '%s' does not exist", name)
+	}
+	kvs := map[string]string{}
+	for key := range index[indexValue] {
+		kvs[key] = i.store[key]
+	}
+	return kvs, nil
+}
+
+// Prefix returns the prefix cached by the informer.
+func (i *Informer) Prefix() string {
+	return i.prefix
+}
+
+// Terminate stops the informer and waits until the watcher exited.
+func (i *Informer) Terminate(ctx context.Context) error {
+	i.rootCtxCancel()
+	select {
+	case <-i.finChan:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
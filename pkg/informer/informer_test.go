@@ -0,0 +1,260 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package informer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/db/memdb"
+)
+
+func newTestInformer(t *testing.T, client db.Client, opts ...Option) *Informer {
+	t.Helper()
+	return New(slog.New(slog.NewTextHandler(io.Discard, nil)), client, "/res/", opts...)
+}
+
+// byValue indexes the kvs by their value.
+func byValue(key, value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+// recorder records the events emitted by the informer.
+type recorder struct {
+	lock   sync.Mutex
+	events []db.Event
+}
+
+func (r *recorder) handle(e db.Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) take() []db.Event {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	events := r.events
+	r.events = nil
+	sort.Slice(events, func(i, j int) bool { return events[i].Key < events[j].Key })
+	return events
+}
+
+func TestInformerSync(t *testing.T) {
+	ctx := context.Background()
+	client := memdb.New()
+	defer client.Terminate(ctx)
+	client.Set(ctx, "/res/a", "x", 0)
+	client.Set(ctx, "/res/b", "y", 0)
+	client.Set(ctx, "/other/c", "x", 0)
+
+	informer := newTestInformer(t, client, WithIndex("value", byValue))
+	informer.ServeAndDetach()
+	defer informer.Terminate(ctx)
+
+	syncCtx, syncCancel := context.WithTimeout(ctx, time.Second*5)
+	defer syncCancel()
+	if err := informer.WaitForSync(syncCtx); err != nil {
+		t.Fatal(err)
+	}
+	if err := informer.Health(); err != nil {
+		t.Errorf("expected synced informer to be healthy, got %v", err)
+	}
+	if want := map[string]string{"/res/a": "x", "/res/b": "y"}; !maps.Equal(informer.List(), want) {
+		t.Fatalf("expected %v, got %v", want, informer.List())
+	}
+
+	client.Set(ctx, "/res/b", "x", 0)
+	client.Delete(ctx, "/res/a")
+	client.Set(ctx, "/res/c", "z", 0)
+
+	want := map[string]string{"/res/b": "x", "/res/c": "z"}
+	deadline := time.Now().Add(time.Second * 5)
+	for !maps.Equal(informer.List(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %v, got %v", want, informer.List())
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	tests := []struct {
+		value string
+		want  map[string]string
+	}{
+		{value: "x", want: map[string]string{"/res/b": "x"}},
+		{value: "y", want: map[string]string{}},
+		{value: "z", want: map[string]string{"/res/c": "z"}},
+	}
+	for _, tt := range tests {
+		got, err := informer.ByIndex("value", tt.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !maps.Equal(got, tt.want) {
+			t.Errorf("index '%s': expected %v, got %v", tt.value, tt.want, got)
+		}
+	}
+	if _, err := informer.ByIndex("missing", "x"); err == nil {
+		t.Errorf("expected unknown index to fail")
+	}
+}
+
+func TestInformerRelist(t *testing.T) {
+	tests := []struct {
+		name       string
+		cached     map[string]string
+		stored     map[string]string
+		wantEvents []db.Event
+	}{
+		{
+			name:       "nothing changed",
+			cached:     map[string]string{"/res/a": "1"},
+			stored:     map[string]string{"/res/a": "1"},
+			wantEvents: nil,
+		},
+		{
+			name:   "missed deletion",
+			cached: map[string]string{"/res/a": "1", "/res/b": "1"},
+			stored: map[string]string{"/res/b": "1"},
+			wantEvents: []db.Event{
+				{Type: db.EVENT_DELETE, Key: "/res/a", PrevValue: "1"},
+			},
+		},
+		{
+			name:   "missed update and creation",
+			cached: map[string]string{"/res/a": "1"},
+			stored: map[string]string{"/res/a": "2", "/res/b": "1"},
+			wantEvents: []db.Event{
+				{Type: db.EVENT_PUT, Key: "/res/a", Value: "2", PrevValue: "1"},
+				{Type: db.EVENT_PUT, Key: "/res/b", Value: "1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := memdb.New()
+			defer client.Terminate(ctx)
+
+			rec := &recorder{}
+			informer := newTestInformer(t, client, WithIndex("value", byValue), WithHandler(rec.handle))
+			for key, value := range tt.cached {
+				client.Set(ctx, key, value, 0)
+			}
+			if err := informer.relist(ctx); err != nil {
+				t.Fatal(err)
+			}
+			rec.take()
+
+			client.DeleteRange(ctx, "/res/")
+			for key, value := range tt.stored {
+				client.Set(ctx, key, value, 0)
+			}
+			if err := informer.relist(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			_, revision, _ := client.GetRangeRevision(ctx, "/res/")
+			events := rec.take()
+			if len(events) != len(tt.wantEvents) {
+				t.Fatalf("expected %d events, got %+v", len(tt.wantEvents), events)
+			}
+			for i, want := range tt.wantEvents {
+				want.Revision = revision
+				if events[i] != want {
+					t.Errorf("expected %+v, got %+v", want, events[i])
+				}
+			}
+			if !maps.Equal(informer.List(), tt.stored) {
+				t.Errorf("expected cache %v, got %v", tt.stored, informer.List())
+			}
+			for key, value := range tt.stored {
+				if kvs, _ := informer.ByIndex("value", value); kvs[key] != value {
+					t.Errorf("expected '%s' to be indexed by '%s'", key, value)
+				}
+			}
+		})
+	}
+}
+
+func TestInformerApply(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     db.Event
+		wantValue string
+		wantKey   bool
+	}{
+		{
+			name:      "newer put",
+			event:     db.Event{Type: db.EVENT_PUT, Key: "/res/a", Value: "2", Revision: 11},
+			wantValue: "2",
+			wantKey:   true,
+		},
+		{
+			name:      "newer delete",
+			event:     db.Event{Type: db.EVENT_DELETE, Key: "/res/a", Revision: 11},
+			wantValue: "",
+			wantKey:   false,
+		},
+		{
+			name:      "stale event is ignored",
+			event:     db.Event{Type: db.EVENT_PUT, Key: "/res/a", Value: "2", Revision: 10},
+			wantValue: "1",
+			wantKey:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			informer := newTestInformer(t, nil, WithHandler(rec.handle))
+			informer.store["/res/a"] = "1"
+			informer.revision = 10
+
+			informer.apply(tt.event)
+			value, ok := informer.Get("/res/a")
+			if value != tt.wantValue || ok != tt.wantKey {
+				t.Errorf("expected ('%s', %v), got ('%s', %v)", tt.wantValue, tt.wantKey, value, ok)
+			}
+			if emitted := len(rec.take()) > 0; emitted != (tt.event.Revision > 10) {
+				t.Errorf("expected event to be emitted: %v", tt.event.Revision > 10)
+			}
+		})
+	}
+}
+
+func TestInformerNotSynced(t *testing.T) {
+	informer := newTestInformer(t, nil)
+	if err := informer.Health(); err == nil || !strings.Contains(err.Error(), "not synced") {
+		t.Errorf("expected unsynced informer to be unhealthy, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// keyspace defines the database layout of all cthul services.
+// Every key is built and parsed through this package, which ensures the layout has one source of truth:
+// controllers and operators that use different keys for the same resource no longer compile.
+package keyspace
+
+import (
+	"strings"
+)
+
+// Field describes a family of keys that hold one attribute of a resource type (e.g. the configs of all domains).
+// Every key of the field has the form <prefix><id>.
+type Field struct {
+	prefix string
+}
+
+func newField(resource, field string) Field {
+	return Field{prefix: resource + "/" + field + "/"}
+}
+
+// Prefix returns the prefix shared by all keys of the field (used for range operations and range watches).
+func (f Field) Prefix() string {
+	return f.prefix
+}
+
+// Key returns the key of the field for the specified resource id.
+func (f Field) Key(id string) string {
+	return f.prefix + id
+}
+
+// Id returns the resource id of a key in the field.
+// The key is expected to be part of the field (e.g. returned by a range operation on Prefix()).
+func (f Field) Id(key string) string {
+	return strings.TrimPrefix(key, f.prefix)
+}
+
+// Parse returns the resource id of the key or false if the key is not part of the field.
+func (f Field) Parse(key string) (string, bool) {
+	if !strings.HasPrefix(key, f.prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(key, f.prefix), true
+}
+
+// Resource describes the layout of a resource type that is placed on a node.
+// The config holds the serialized resource configuration, reqnode holds the node the resource is requested on
+// and node holds the node the resource is currently running on (set by the operator of this node).
+type Resource struct {
+	root    string
+	Config  Field
+	Reqnode Field
+	Node    Field
+}
+
+func newResource(resource string) Resource {
+	return Resource{
+		root:    resource + "/",
+		Config:  newField(resource, "CONFIG"),
+		Reqnode: newField(resource, "REQNODE"),
+		Node:    newField(resource, "NODE"),
+	}
+}
+
+// Prefix returns the prefix shared by all fields of the resource type (used to watch the full resource type).
+func (r Resource) Prefix() string {
+	return r.root
+}
+
+// ClusterResource describes the layout of a resource type that is placed on a node and replicated on a
+// cluster of nodes. The cluster holds the serialized cluster configuration.
+type ClusterResource struct {
+	Resource
+	Cluster Field
+}
+
+func newClusterResource(resource string) ClusterResource {
+	return ClusterResource{
+		Resource: newResource(resource),
+		Cluster:  newField(resource, "CLUSTER"),
+	}
+}
+
+// NodeResource describes the layout of the node registrations.
+// The config holds the serialized node information reported by the node itself.
+type NodeResource struct {
+	root   string
+	Config Field
+}
+
+func newNodeResource(resource string) NodeResource {
+	return NodeResource{
+		root:   resource + "/",
+		Config: newField(resource, "CONFIG"),
+	}
+}
+
+// Prefix returns the prefix shared by all fields of the node registrations.
+func (r NodeResource) Prefix() string {
+	return r.root
+}
+
+const (
+	// SchemaVersion holds the version of the database layout (managed by the migration package).
+	SchemaVersion = "/CTHUL/SCHEMA/VERSION"
+	// SchemaLock is held by the node that currently migrates the database layout.
+	SchemaLock = "/CTHUL/SCHEMA/LOCK"
+
+	// WaveLeader holds the leader of the wave cluster.
+	WaveLeader = "/WAVE/LEADER"
+	// WaveSchedulerNext holds the unix timestamp of the next scheduler cycle.
+	WaveSchedulerNext = "/WAVE/SCHEDULER/NEXT"
+)
+
+var (
+	// SyncStatus holds the synchronization status of keys applied by a syncer.
+	// The id of the status is the node id followed by the synced key without its leading slash.
+	SyncStatus = newField("/CTHUL/SYNC", "STATUS")
+
+	// WaveElectionCandidate holds the candidacy (id & cash) of every node contesting the wave leader.
+	WaveElectionCandidate = newField("/WAVE/ELECTION", "CANDIDATE")
+
+	// WaveRebalanceCooldown holds the unix timestamp of the last rebalancer move of a domain.
+	// The key expires with the cooldown, domains with a cooldown key are not moved by the rebalancer.
+	WaveRebalanceCooldown = newField("/WAVE/REBALANCE", "COOLDOWN")
+
+	WaveNode   = newNodeResource("/WAVE/NODE")
+	WaveDomain = newResource("/WAVE/DOMAIN")
+	WaveVideo  = newResource("/WAVE/VIDEO")
+	WaveSerial = newResource("/WAVE/SERIAL")
+
+	GranitDisk = newClusterResource("/GRANIT/DISK")
+
+	ProtonInter = newClusterResource("/PROTON/INTER")
+)
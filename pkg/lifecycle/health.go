@@ -0,0 +1,197 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package lifecycle
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type PROBE_TYPE string
+
+const (
+	// PROBE_LIVENESS probes report whether the component is alive. A failing liveness probe indicates that
+	// the component cannot recover on its own (e.g. the api listener stopped serving).
+	PROBE_LIVENESS PROBE_TYPE = "liveness"
+	// PROBE_READINESS probes report whether the component is currently working (e.g. the database is reachable).
+	PROBE_READINESS PROBE_TYPE = "readiness"
+)
+
+// ProbeResult holds the result of a single probe.
+type ProbeResult struct {
+	Name string
+	Type PROBE_TYPE
+	// Err holds the error reported by the probe (nil = healthy).
+	Err error
+}
+
+// Health is a registry that captures the health probes of various components.
+// It aggregates the probes into the liveness and readiness state of the programm.
+type Health struct {
+	probesLock sync.RWMutex
+	probes     map[string]*probe
+
+	// probeTimeout specifies the maximum time a health evaluation waits for a single probe.
+	probeTimeout time.Duration
+}
+
+// probe holds a registered health probe.
+type probe struct {
+	probeType PROBE_TYPE
+	check     func() error
+
+	// pending is closed as soon as the running check returns (nil = no check is running).
+	// Concurrent evaluations share the running check instead of starting another one.
+	pendingLock sync.Mutex
+	pending     chan struct{}
+	result      error
+}
+
+type HealthOption func(*Health)
+
+// NewHealth creates a new health registry.
+func NewHealth(opts ...HealthOption) *Health {
+	health := &Health{
+		probesLock:   sync.RWMutex{},
+		probes:       map[string]*probe{},
+		probeTimeout: time.Second * 2,
+	}
+
+	for _, opt := range opts {
+		opt(health)
+	}
+
+	return health
+}
+
+// WithProbeTimeout defines a custom timeout for a single probe. Probes that do not return in time are
+// reported as failing, the check keeps running in the background and is reused by the next evaluation.
+func WithProbeTimeout(timeout time.Duration) HealthOption {
+	return func(h *Health) {
+		h.probeTimeout = timeout
+	}
+}
+
+// AddProbe registers a named health probe. The check is executed on every health evaluation, therefore it
+// should not block (it should report a state tracked by the component, e.g. the result of its last cycle).
+// Checks that perform I/O are cut off after the probe timeout, so that one stuck probe cannot block the
+// health endpoints. Registering a probe with an existing name replaces the previous probe.
+func (h *Health) AddProbe(name string, probeType PROBE_TYPE, check func() error) {
+	h.probesLock.Lock()
+	defer h.probesLock.Unlock()
+	h.probes[name] = &probe{probeType: probeType, check: check}
+}
+
+// evaluate runs the check of the probe and waits for its result until the probe timeout is exceeded.
+func (h *Health) evaluate(probe *probe) error {
+	probe.pendingLock.Lock()
+	pending := probe.pending
+	if pending == nil {
+		pending = make(chan struct{})
+		probe.pending = pending
+		go func() {
+			result := probe.check()
+			probe.pendingLock.Lock()
+			probe.result = result
+			probe.pending = nil
+			probe.pendingLock.Unlock()
+			close(pending)
+		}()
+	}
+	probe.pendingLock.Unlock()
+
+	select {
+	case <-pending:
+		probe.pendingLock.Lock()
+		defer probe.pendingLock.Unlock()
+		return probe.result
+	case <-time.After(h.probeTimeout):
+		return fmt.Errorf("probe did not respond within %s", h.probeTimeout)
+	}
+}
+
+// Results evaluates the probes of the specified type and returns their results sorted by name.
+// Evaluating the readiness includes the liveness probes, a component that is not alive is never ready.
+func (h *Health) Results(probeType PROBE_TYPE) []ProbeResult {
+	h.probesLock.RLock()
+	probes := map[string]*probe{}
+	for name, probe := range h.probes {
+		if probeType == PROBE_LIVENESS && probe.probeType != PROBE_LIVENESS {
+			continue
+		}
+		probes[name] = probe
+	}
+	h.probesLock.RUnlock()
+
+	// probes are evaluated concurrently, so that the evaluation takes at most one probe timeout.
+	resultsLock := sync.Mutex{}
+	resultsWg := sync.WaitGroup{}
+	results := []ProbeResult{}
+	for name, probe := range probes {
+		resultsWg.Add(1)
+		go func() {
+			defer resultsWg.Done()
+			err := h.evaluate(probe)
+			resultsLock.Lock()
+			defer resultsLock.Unlock()
+			results = append(results, ProbeResult{Name: name, Type: probe.probeType, Err: err})
+		}()
+	}
+	resultsWg.Wait()
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+// Probe evaluates the probe with the specified name. Returns false if no such probe is registered.
+func (h *Health) Probe(name string) (ProbeResult, bool) {
+	h.probesLock.RLock()
+	probe, ok := h.probes[name]
+	h.probesLock.RUnlock()
+	if !ok {
+		return ProbeResult{}, false
+	}
+	return ProbeResult{Name: name, Type: probe.probeType, Err: h.evaluate(probe)}, true
+}
+
+// Live returns the aggregated errors of all failing liveness probes (nil = alive).
+func (h *Health) Live() error {
+	return aggregate(h.Results(PROBE_LIVENESS))
+}
+
+// Ready returns the aggregated errors of all failing probes (nil = ready).
+func (h *Health) Ready() error {
+	return aggregate(h.Results(PROBE_READINESS))
+}
+
+// aggregate joins the errors of all failing probe results.
+func aggregate(results []ProbeResult) error {
+	errs := []error{}
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Name, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
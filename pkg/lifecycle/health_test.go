@@ -0,0 +1,160 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package lifecycle
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProbeTimeout(t *testing.T) {
+	health := NewHealth(WithProbeTimeout(time.Millisecond * 50))
+	release := make(chan struct{})
+	defer close(release)
+	calls := atomic.Int32{}
+	health.AddProbe("blocking", PROBE_READINESS, func() error {
+		calls.Add(1)
+		<-release
+		return nil
+	})
+
+	start := time.Now()
+	result, ok := health.Probe("blocking")
+	if !ok {
+		t.Fatalf("expected probe 'blocking' to be registered")
+	}
+	if duration := time.Since(start); duration > time.Millisecond*500 {
+		t.Errorf("expected blocking probe to be cut off after the probe timeout, took %s", duration)
+	}
+	if result.Err == nil || !strings.Contains(result.Err.Error(), "did not respond within") {
+		t.Errorf("expected timeout result, got %v", result.Err)
+	}
+
+	// concurrent evaluations must share the check that is still running.
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			health.Results(PROBE_READINESS)
+		}()
+	}
+	wg.Wait()
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected the check to be started once, got %d calls", got)
+	}
+}
+
+func TestProbeReuse(t *testing.T) {
+	health := NewHealth(WithProbeTimeout(time.Second))
+	release := make(chan struct{})
+	calls := atomic.Int32{}
+	health.AddProbe("slow", PROBE_READINESS, func() error {
+		calls.Add(1)
+		<-release
+		return errors.New("database unreachable")
+	})
+
+	wg := sync.WaitGroup{}
+	results := make([]ProbeResult, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = health.Probe("slow")
+		}()
+	}
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected the check to be started once, got %d calls", got)
+	}
+	for _, result := range results {
+		if result.Err == nil || result.Err.Error() != "database unreachable" {
+			t.Errorf("expected shared check result, got %v", result.Err)
+		}
+	}
+
+	// a finished check is started again on the next evaluation.
+	release = make(chan struct{})
+	close(release)
+	health.Probe("slow")
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected the check to be restarted after it finished, got %d calls", got)
+	}
+}
+
+func TestHealthState(t *testing.T) {
+	failing := errors.New("failing")
+	tests := []struct {
+		name      string
+		liveness  error
+		readiness error
+		wantLive  bool
+		wantReady bool
+	}{
+		{
+			name:      "healthy",
+			wantLive:  true,
+			wantReady: true,
+		},
+		{
+			name:      "readiness failing",
+			readiness: failing,
+			wantLive:  true,
+			wantReady: false,
+		},
+		{
+			name:      "liveness failing is never ready",
+			liveness:  failing,
+			wantLive:  false,
+			wantReady: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := NewHealth()
+			health.AddProbe("api", PROBE_LIVENESS, func() error { return tt.liveness })
+			health.AddProbe("database", PROBE_READINESS, func() error { return tt.readiness })
+
+			if live := health.Live() == nil; live != tt.wantLive {
+				t.Errorf("expected live %t, got %t", tt.wantLive, live)
+			}
+			if ready := health.Ready() == nil; ready != tt.wantReady {
+				t.Errorf("expected ready %t, got %t", tt.wantReady, ready)
+			}
+
+			results := health.Results(PROBE_READINESS)
+			if len(results) != 2 || results[0].Name != "api" || results[1].Name != "database" {
+				t.Errorf("expected readiness results to include the liveness probes, got %v", results)
+			}
+			if results := health.Results(PROBE_LIVENESS); len(results) != 1 || results[0].Name != "api" {
+				t.Errorf("expected liveness results to only include liveness probes, got %v", results)
+			}
+		})
+	}
+}
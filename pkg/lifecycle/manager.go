@@ -22,7 +22,8 @@ package lifecycle
 import (
   "fmt"
 	"context"
-	"sync"
+	"slices"
+	"strings"
 	"time"
   "log/slog"
 )
@@ -30,9 +31,31 @@ import (
 // Manager provides a basic termination lifecycle.
 // It captures termination hooks of various detached components, allowing the programm
 // to terminate all of those components in a controlled manner.
+//
+// Hooks can be named and declare the hooks they depend on (e.g. an operator depends on the database client).
+// Terminate() shuts down the hooks in phases: a hook is terminated in an earlier phase than all of its
+// dependencies, which ensures components can still use their dependencies while they are shutting down.
 type Manager struct {
   logger *slog.Logger
-	hooks []func(context.Context) error
+	hooks []hook
+	// phaseTimeout specifies the maximum duration of one termination phase (0 = evenly shared timeout).
+	phaseTimeout time.Duration
+	// forceTimeout specifies how long the final forced phase waits for hooks that did not return in their phase.
+	forceTimeout time.Duration
+}
+
+// hook holds a termination hook and the names of the hooks it depends on.
+type hook struct {
+	name string
+	terminateFunc func(context.Context) error
+	dependencies []string
+}
+
+// pendingHook holds a hook that did not return within its phase.
+type pendingHook struct {
+	name string
+	// done is closed as soon as the hook returned.
+	done chan struct{}
 }
 
 type Option func(*Manager)
@@ -41,7 +64,9 @@ type Option func(*Manager)
 func NewManager(logger *slog.Logger, opts ...Option) *Manager {
 	manager := &Manager{
 		logger: logger.WithGroup("lifecycle-manager"),
-		hooks: []func(context.Context) error{},
+		hooks: []hook{},
+		phaseTimeout: 0,
+		forceTimeout: time.Second * 5,
 	}
 
 	for _, opt := range opts {
@@ -51,34 +76,227 @@ func NewManager(logger *slog.Logger, opts ...Option) *Manager {
 	return manager
 }
 
+// WithPhaseTimeout specifies the maximum duration of one termination phase. If a phase exceeds it, the hooks
+// of the phase are expected to forcefully close their components. By default, the remaining timeout is
+// shared evenly between the remaining phases.
+func WithPhaseTimeout(timeout time.Duration) Option {
+	return func(m *Manager) {
+		m.phaseTimeout = timeout
+	}
+}
+
+// WithForceTimeout specifies how long the final forced phase waits for hooks that did not return within their
+// phase (their context is already canceled at this point). Hooks that exceed it are abandoned.
+func WithForceTimeout(timeout time.Duration) Option {
+	return func(m *Manager) {
+		m.forceTimeout = timeout
+	}
+}
+
 // AddHook adds a termination hook. The provided terminateFunc should try to gracefully close / shutdown
 // the component. If the context exceeds, the function is expected to immediately forcefully close / shutdown
 // the component. In case of an error, the error should be returned.
 func (m *Manager) AddHook(terminateFunc func(context.Context) error) {
-	m.hooks = append(m.hooks, terminateFunc)
+	m.AddNamedHook(fmt.Sprintf("hook-%d", len(m.hooks)), terminateFunc)
 }
 
-// TerminateParallel executes all termination hooks at the same time.
+// AddNamedHook adds a named termination hook (see AddHook()). The dependencies specify the names of the hooks
+// the component relies on, those hooks are only terminated after this hook returned.
+// Dependencies on hooks that are never added are ignored.
+func (m *Manager) AddNamedHook(name string, terminateFunc func(context.Context) error, dependencies ...string) {
+	m.hooks = append(m.hooks, hook{
+		name: name,
+		terminateFunc: terminateFunc,
+		dependencies: dependencies,
+	})
+}
+
+// TerminateParallel executes all termination hooks at the same time (ignoring dependencies).
 // After the provided timeout, the hooks stop the graceful shutdown process and immediately
-// and forcefully close the components. The function blocks until all hooks returned.
-func (m *Manager) TerminateParallel(timeout time.Duration) {
+// and forcefully close the components. Hooks that did not return in time are handled by the final forced
+// phase (see Terminate()). Returns the names of the hooks that had to be forced.
+func (m *Manager) TerminateParallel(timeout time.Duration) []string {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	m.logger.Debug(fmt.Sprintf("initiating termination of %d hooks in %v...", len(m.hooks), timeout))
 
-	exitWg := sync.WaitGroup{}
-	for i, hook := range m.hooks {
-		exitWg.Add(1)
+	return m.forcePhase(m.terminatePhase(ctx, m.hooks))
+}
+
+// Terminate executes the termination hooks in phases ordered by their dependencies.
+// Every phase runs its hooks at the same time and is limited by the phase timeout, the next phase starts as
+// soon as all hooks of the phase returned or the phase timeout exceeded. After the provided timeout, the
+// remaining phases are still executed in order, but the hooks immediately and forcefully close the components.
+// Hooks that did not return within their phase are collected by a final forced phase, which waits for them
+// up to the force timeout and abandons the remaining ones. Returns the names of the hooks that had to be forced.
+func (m *Manager) Terminate(timeout time.Duration) []string {
+	deadline := time.Now().Add(timeout)
+	phases := m.phases()
+
+	m.logger.Debug(fmt.Sprintf(
+		"initiating termination of %d hooks in %d phases in %v...", len(m.hooks), len(phases), timeout,
+	))
+
+	pending := []pendingHook{}
+	for i, phase := range phases {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			m.logger.Warn(fmt.Sprintf("termination timeout exceeded; forcing termination of phase %d...", i))
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			pending = append(pending, m.terminatePhase(ctx, phase)...)
+			continue
+		}
+
+		phaseTimeout := m.phaseTimeout
+		if phaseTimeout <= 0 {
+			phaseTimeout = remaining / time.Duration(len(phases) - i)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), min(phaseTimeout, remaining))
+		m.logger.Debug(fmt.Sprintf("terminating phase %d (%d hooks)...", i, len(phase)))
+		pending = append(pending, m.terminatePhase(ctx, phase)...)
+		cancel()
+	}
+
+	return m.forcePhase(pending)
+}
+
+// terminatePhase executes the provided hooks at the same time and blocks until all hooks returned or the
+// context exceeded. Returns the hooks that did not return in time, they keep running with the canceled context.
+func (m *Manager) terminatePhase(ctx context.Context, hooks []hook) []pendingHook {
+	started := []pendingHook{}
+	for _, h := range hooks {
+		done := make(chan struct{})
+		started = append(started, pendingHook{name: h.name, done: done})
 		go func() {
-			defer exitWg.Done()
-			if err := hook(ctx); err!=nil {
-				m.logger.Warn(fmt.Sprintf("termination of hook %d failed: %v", i, err))
+			defer close(done)
+			if err := h.terminateFunc(ctx); err!=nil {
+				m.logger.Warn(fmt.Sprintf("termination of hook '%s' failed: %v", h.name, err))
 				return
 			}
-			m.logger.Debug(fmt.Sprintf("hook %d terminated successfully", i))
+			m.logger.Debug(fmt.Sprintf("hook '%s' terminated successfully", h.name))
 		}()
 	}
 
-	exitWg.Wait()
+	pending := []pendingHook{}
+	for _, h := range started {
+		select {
+		case <-h.done:
+		case <-ctx.Done():
+			select {
+			case <-h.done:
+			default:
+				pending = append(pending, h)
+			}
+		}
+	}
+	return pending
+}
+
+// forcePhase is the final termination phase. It waits for the hooks that did not return within their phase
+// up to the force timeout, hooks that still did not return are abandoned. Returns the names of all forced hooks.
+func (m *Manager) forcePhase(pending []pendingHook) []string {
+	if len(pending) == 0 {
+		return nil
+	}
+
+	forced := []string{}
+	for _, h := range pending {
+		forced = append(forced, h.name)
+	}
+	m.logger.Warn(fmt.Sprintf(
+		"forcing termination of %d hooks that did not return in their phase: %s", len(forced), strings.Join(forced, ", "),
+	))
+
+	timeout := time.After(m.forceTimeout)
+	expired := false
+	abandoned := []string{}
+	for _, h := range pending {
+		if !expired {
+			select {
+			case <-h.done:
+				continue
+			case <-timeout:
+				expired = true
+			}
+		}
+		select {
+		case <-h.done:
+		default:
+			abandoned = append(abandoned, h.name)
+		}
+	}
+	if len(abandoned) > 0 {
+		m.logger.Error(fmt.Sprintf(
+			"abandoning %d hooks that did not return after forced termination: %s",
+			len(abandoned), strings.Join(abandoned, ", "),
+		))
+	}
+	return forced
+}
+
+// phases groups the hooks into termination phases. A hook that no other hook depends on is terminated in the
+// first phase, every other hook is terminated one phase after the last hook that depends on it.
+// Hooks that are part of a dependency cycle cannot be ordered, they are terminated in the last phase.
+func (m *Manager) phases() [][]hook {
+	// dependents holds the indices of all hooks that depend on the hook with the specified name.
+	dependents := map[string][]int{}
+	for i, h := range m.hooks {
+		for _, dependency := range h.dependencies {
+			dependents[dependency] = append(dependents[dependency], i)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make([]int, len(m.hooks))
+	levels := make([]int, len(m.hooks))
+	cyclic := make([]bool, len(m.hooks))
+	var visit func(int) bool
+	visit = func(i int) bool {
+		switch states[i] {
+		case visiting:
+			return false
+		case visited:
+			return !cyclic[i]
+		}
+		states[i] = visiting
+		for _, dependent := range dependents[m.hooks[i].name] {
+			if !visit(dependent) {
+				cyclic[i] = true
+				continue
+			}
+			levels[i] = max(levels[i], levels[dependent] + 1)
+		}
+		states[i] = visited
+		return !cyclic[i]
+	}
+
+	maxLevel := 0
+	for i := range m.hooks {
+		visit(i)
+		if !cyclic[i] {
+			maxLevel = max(maxLevel, levels[i])
+		}
+	}
+
+	phases := make([][]hook, maxLevel + 1)
+	cycle := []hook{}
+	for i, h := range m.hooks {
+		if cyclic[i] {
+			m.logger.Warn(fmt.Sprintf("hook '%s' depends on a dependency cycle; terminating it last", h.name))
+			cycle = append(cycle, h)
+			continue
+		}
+		phases[levels[i]] = append(phases[levels[i]], h)
+	}
+	phases = append(phases, cycle)
+	// phases are only empty if no hook or only cyclic hooks exist.
+	return slices.DeleteFunc(phases, func(phase []hook) bool {
+		return len(phase) == 0
+	})
 }
@@ -0,0 +1,199 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package lifecycle
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestManager(opts ...Option) *Manager {
+	return NewManager(slog.New(slog.NewTextHandler(io.Discard, nil)), opts...)
+}
+
+func TestPhases(t *testing.T) {
+	type testHook struct {
+		name         string
+		dependencies []string
+	}
+	tests := []struct {
+		name  string
+		hooks []testHook
+		want  [][]string
+	}{
+		{
+			name:  "independent hooks",
+			hooks: []testHook{{"a", nil}, {"b", nil}},
+			want:  [][]string{{"a", "b"}},
+		},
+		{
+			name:  "hook runs before its dependencies",
+			hooks: []testHook{{"db", nil}, {"api", []string{"operator"}}, {"operator", []string{"db"}}},
+			want:  [][]string{{"api"}, {"operator"}, {"db"}},
+		},
+		{
+			name: "dependency waits for its last dependent",
+			hooks: []testHook{
+				{"db", nil}, {"a", []string{"db"}}, {"b", []string{"db"}}, {"c", []string{"a"}},
+			},
+			want: [][]string{{"b", "c"}, {"a"}, {"db"}},
+		},
+		{
+			name:  "unknown dependencies are ignored",
+			hooks: []testHook{{"a", []string{"missing"}}},
+			want:  [][]string{{"a"}},
+		},
+		{
+			name: "cyclic hooks end up in the last phase",
+			hooks: []testHook{
+				{"a", []string{"b"}}, {"b", []string{"a"}}, {"c", []string{"a"}}, {"d", nil},
+			},
+			want: [][]string{{"c", "d"}, {"a", "b"}},
+		},
+		{
+			name:  "only cyclic hooks",
+			hooks: []testHook{{"a", []string{"a"}}},
+			want:  [][]string{{"a"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newTestManager()
+			for _, h := range tt.hooks {
+				manager.AddNamedHook(h.name, func(context.Context) error { return nil }, h.dependencies...)
+			}
+
+			got := [][]string{}
+			for _, phase := range manager.phases() {
+				names := []string{}
+				for _, h := range phase {
+					names = append(names, h.name)
+				}
+				slices.Sort(names)
+				got = append(got, names)
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("expected phases %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTerminateOrder(t *testing.T) {
+	manager := newTestManager()
+	orderLock, order := sync.Mutex{}, []string{}
+	record := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			// the delay ensures a dependency would overtake its dependent if the phases were not awaited.
+			time.Sleep(time.Millisecond * 20)
+			orderLock.Lock()
+			defer orderLock.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+	manager.AddNamedHook("db", record("db"))
+	manager.AddNamedHook("operator", record("operator"), "db")
+	manager.AddNamedHook("api", record("api"), "operator", "db")
+
+	if forced := manager.Terminate(time.Second * 5); len(forced) > 0 {
+		t.Errorf("expected no forced hooks, got %v", forced)
+	}
+	if want := []string{"api", "operator", "db"}; !slices.Equal(order, want) {
+		t.Errorf("expected termination order %v, got %v", want, order)
+	}
+}
+
+func TestTerminateForced(t *testing.T) {
+	tests := []struct {
+		name string
+		// stuckDelay specifies how long the stuck hook ignores its context.
+		stuckDelay   time.Duration
+		timeout      time.Duration
+		forceTimeout time.Duration
+		// maxDuration specifies the maximum duration of the termination.
+		maxDuration time.Duration
+		wantForced  []string
+	}{
+		{
+			name:         "graceful hooks are not forced",
+			stuckDelay:   0,
+			timeout:      time.Second,
+			forceTimeout: time.Second,
+			maxDuration:  time.Second,
+			wantForced:   nil,
+		},
+		{
+			name:         "hook ignoring its context is forced",
+			stuckDelay:   time.Millisecond * 300,
+			timeout:      time.Millisecond * 100,
+			forceTimeout: time.Second,
+			maxDuration:  time.Millisecond * 900,
+			wantForced:   []string{"stuck"},
+		},
+		{
+			name:         "hook exceeding the force timeout is abandoned",
+			stuckDelay:   time.Second * 10,
+			timeout:      time.Millisecond * 100,
+			forceTimeout: time.Millisecond * 100,
+			maxDuration:  time.Second,
+			wantForced:   []string{"stuck"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newTestManager(WithForceTimeout(tt.forceTimeout))
+			dbGraceful := false
+			// the db honours its context, it only terminates gracefully if its phase got a share of the timeout.
+			manager.AddNamedHook("db", func(ctx context.Context) error {
+				select {
+				case <-time.After(time.Millisecond * 10):
+					dbGraceful = true
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+			manager.AddNamedHook("stuck", func(context.Context) error {
+				time.Sleep(tt.stuckDelay)
+				return nil
+			}, "db")
+
+			start := time.Now()
+			forced := manager.Terminate(tt.timeout)
+			if duration := time.Since(start); duration > tt.maxDuration {
+				t.Errorf("expected termination within %s, took %s", tt.maxDuration, duration)
+			}
+			if !slices.Equal(forced, tt.wantForced) {
+				t.Errorf("expected forced hooks %v, got %v", tt.wantForced, forced)
+			}
+			if !dbGraceful {
+				t.Errorf("expected the db to terminate gracefully after the stuck phase")
+			}
+		})
+	}
+}
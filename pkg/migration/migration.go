@@ -0,0 +1,227 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// migration manages the version of the cthul database layout.
+// The layout version is stored in the database, every layout change is shipped as ordered migration step
+// that upgrades the stored data to the new layout. Steps are executed once per cluster under a cluster-wide lock.
+package migration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/keyspace"
+)
+
+// Step describes a single layout change.
+// Steps must be idempotent: if a node crashes while migrating, the step is executed again by the next node.
+type Step struct {
+	// Version is the layout version after the step was applied. Versions start at 1 and must not have gaps.
+	Version int64
+	// Description is a short human readable summary of the layout change.
+	Description string
+	// Apply upgrades the data from the previous layout version.
+	Apply func(context.Context, db.Client) error
+}
+
+// Migrator upgrades the database layout to the latest version known by the binary.
+type Migrator struct {
+	logger *slog.Logger
+	client db.Client
+	steps  []Step
+
+	// lockTTL specifies the ttl of the migration lock lease. If the migrating node crashes,
+	// other nodes can take over the migration after the lease expired.
+	lockTTL int64
+	// retryInterval specifies the interval in which a node waiting for the migration lock retries to acquire it.
+	retryInterval time.Duration
+}
+
+type Option func(*Migrator)
+
+// New creates a migrator for the provided steps. The steps must be ordered by version.
+func New(logger *slog.Logger, client db.Client, steps []Step, opts ...Option) *Migrator {
+	migrator := &Migrator{
+		logger:        logger,
+		client:        client,
+		steps:         steps,
+		lockTTL:       10,
+		retryInterval: time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(migrator)
+	}
+
+	return migrator
+}
+
+// WithLockTTL defines a custom ttl (seconds) for the migration lock.
+func WithLockTTL(ttl int64) Option {
+	return func(m *Migrator) {
+		m.lockTTL = ttl
+	}
+}
+
+// WithRetryInterval defines a custom interval for retrying to acquire the migration lock.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(m *Migrator) {
+		m.retryInterval = interval
+	}
+}
+
+// Latest returns the latest layout version known by the migrator.
+func (m *Migrator) Latest() int64 {
+	if len(m.steps) < 1 {
+		return 0
+	}
+	return m.steps[len(m.steps)-1].Version
+}
+
+// Version returns the layout version currently stored in the database (0 if the database was never migrated).
+func Version(ctx context.Context, client db.Client) (int64, error) {
+	rawVersion, err := client.Get(ctx, keyspace.SchemaVersion)
+	if err != nil {
+		return 0, fmt.Errorf("fetching schema version: %w", err)
+	}
+	if rawVersion == "" {
+		return 0, nil
+	}
+	version, err := strconv.ParseInt(rawVersion, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing schema version: %w", err)
+	}
+	return version, nil
+}
+
+// Run upgrades the database layout to the latest version. The function blocks until the migration lock is
+// acquired, the database is up-to-date or the context is cancelled.
+// If the stored version is newer than the latest version known by the binary, an error is returned,
+// as the binary would operate on a layout it does not understand.
+func (m *Migrator) Run(ctx context.Context) error {
+	for i, step := range m.steps {
+		if step.Version != int64(i+1) {
+			return fmt.Errorf("invalid migration step '%s': expected version %d got %d",
+				step.Description, i+1, step.Version)
+		}
+	}
+
+	version, err := Version(ctx, m.client)
+	if err != nil {
+		return err
+	}
+	if err := m.checkVersion(version); err != nil {
+		return err
+	}
+	if version == m.Latest() {
+		return nil
+	}
+
+	lock := db.NewLease(m.client, m.lockTTL)
+	defer func() {
+		// the lock is released with a fresh context, as the provided one may already be cancelled.
+		releaseCtx, releaseCtxCancel := context.WithTimeout(context.Background(), time.Second*time.Duration(m.lockTTL))
+		defer releaseCtxCancel()
+		if err := lock.Release(releaseCtx); err != nil {
+			m.logger.Warn(fmt.Sprintf("failed to release migration lock: %s", err.Error()))
+		}
+	}()
+	lockRevision, err := m.acquireLock(ctx, lock)
+	if err != nil {
+		return err
+	}
+
+	// the version is reevaluated as another node may have migrated the database while waiting for the lock.
+	version, err = Version(ctx, m.client)
+	if err != nil {
+		return err
+	}
+	if err := m.checkVersion(version); err != nil {
+		return err
+	}
+
+	for _, step := range m.steps[version:] {
+		m.logger.Info(fmt.Sprintf("migrating database schema to version %d: %s", step.Version, step.Description))
+		if err := step.Apply(ctx, m.client); err != nil {
+			return fmt.Errorf("migrating to schema version %d: %w", step.Version, err)
+		}
+
+		// the version is only written if the lock is still held, this ensures that a node
+		// that lost the lock (e.g. due to a network partition) cannot overwrite the progress of its successor.
+		ok, err := m.client.Txn(ctx, []db.Condition{{
+			Key: keyspace.SchemaLock, Compare: db.COMPARE_EQUAL, Revision: lockRevision,
+		}}, []db.Operation{{
+			Type: db.OPERATION_SET, Key: keyspace.SchemaVersion, Value: strconv.FormatInt(step.Version, 10),
+		}})
+		if err != nil {
+			return fmt.Errorf("updating schema version: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("migration lock was lost while migrating to schema version %d", step.Version)
+		}
+	}
+	return nil
+}
+
+// checkVersion ensures the binary understands the stored layout version.
+func (m *Migrator) checkVersion(version int64) error {
+	if version > m.Latest() {
+		return fmt.Errorf(
+			"database schema version %d is newer than the latest version %d supported by this binary; upgrade the binary",
+			version, m.Latest(),
+		)
+	}
+	return nil
+}
+
+// acquireLock blocks until the migration lock is acquired and returns the revision of the lock key.
+func (m *Migrator) acquireLock(ctx context.Context, lock *db.Lease) (int64, error) {
+	for {
+		leaseId, err := lock.Acquire(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("acquiring migration lock lease: %w", err)
+		}
+		ok, err := m.client.Txn(ctx, []db.Condition{{
+			Key: keyspace.SchemaLock, Compare: db.COMPARE_EQUAL, Revision: 0,
+		}}, []db.Operation{{
+			Type: db.OPERATION_SET, Key: keyspace.SchemaLock, Value: strconv.FormatInt(leaseId, 10), Lease: leaseId,
+		}})
+		if err != nil {
+			return 0, fmt.Errorf("acquiring migration lock: %w", err)
+		}
+		if ok {
+			_, revision, err := m.client.GetRevision(ctx, keyspace.SchemaLock)
+			if err != nil {
+				return 0, fmt.Errorf("acquiring migration lock: %w", err)
+			}
+			return revision, nil
+		}
+
+		m.logger.Info("waiting for another node to complete the database migration...")
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("context exceeded: migration lock couldn't be acquired")
+		case <-time.After(m.retryInterval):
+		}
+	}
+}
@@ -0,0 +1,210 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package migration
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/db/memdb"
+	"cthul.io/cthul/pkg/keyspace"
+)
+
+// recordSteps creates steps with the specified versions that record their execution.
+func recordSteps(applied *[]int64, versions ...int64) []Step {
+	steps := []Step{}
+	for _, version := range versions {
+		steps = append(steps, Step{
+			Version:     version,
+			Description: "test step " + strconv.FormatInt(version, 10),
+			Apply: func(ctx context.Context, client db.Client) error {
+				*applied = append(*applied, version)
+				return nil
+			},
+		})
+	}
+	return steps
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		stored      int64
+		versions    []int64
+		failVersion int64
+		wantErr     bool
+		wantApplied []int64
+		wantVersion int64
+	}{
+		{
+			name:        "fresh database",
+			stored:      0,
+			versions:    []int64{1, 2, 3},
+			wantApplied: []int64{1, 2, 3},
+			wantVersion: 3,
+		},
+		{
+			name:        "partially migrated",
+			stored:      2,
+			versions:    []int64{1, 2, 3},
+			wantApplied: []int64{3},
+			wantVersion: 3,
+		},
+		{
+			name:        "up to date",
+			stored:      3,
+			versions:    []int64{1, 2, 3},
+			wantApplied: []int64{},
+			wantVersion: 3,
+		},
+		{
+			name:        "database newer than binary",
+			stored:      4,
+			versions:    []int64{1, 2, 3},
+			wantErr:     true,
+			wantApplied: []int64{},
+			wantVersion: 4,
+		},
+		{
+			name:        "version gap",
+			stored:      0,
+			versions:    []int64{1, 3},
+			wantErr:     true,
+			wantApplied: []int64{},
+			wantVersion: 0,
+		},
+		{
+			name:        "failing step keeps previous version",
+			stored:      0,
+			versions:    []int64{1, 2, 3},
+			failVersion: 2,
+			wantErr:     true,
+			wantApplied: []int64{1},
+			wantVersion: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := memdb.New()
+			defer client.Terminate(ctx)
+			if tt.stored > 0 {
+				client.Set(ctx, keyspace.SchemaVersion, strconv.FormatInt(tt.stored, 10), 0)
+			}
+
+			applied := []int64{}
+			steps := recordSteps(&applied, tt.versions...)
+			for i := range steps {
+				if steps[i].Version == tt.failVersion {
+					steps[i].Apply = func(context.Context, db.Client) error { return errors.New("step failed") }
+				}
+			}
+
+			migrator := New(slog.New(slog.NewTextHandler(io.Discard, nil)), client, steps)
+			if err := migrator.Run(ctx); (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(applied, tt.wantApplied) {
+				t.Errorf("expected applied steps %v, got %v", tt.wantApplied, applied)
+			}
+			if version, _ := Version(ctx, client); version != tt.wantVersion {
+				t.Errorf("expected version %d, got %d", tt.wantVersion, version)
+			}
+			if _, revision, _ := client.GetRevision(ctx, keyspace.SchemaLock); revision != 0 {
+				t.Errorf("expected migration lock to be released")
+			}
+		})
+	}
+}
+
+func TestRunWaitsForLock(t *testing.T) {
+	ctx := context.Background()
+	client := memdb.New()
+	defer client.Terminate(ctx)
+
+	// another node holds the migration lock.
+	lease, _ := client.GrantLease(ctx, 60)
+	client.SetLease(ctx, keyspace.SchemaLock, "other", lease)
+
+	applied := []int64{}
+	migrator := New(slog.New(slog.NewTextHandler(io.Discard, nil)), client, recordSteps(&applied, 1),
+		WithRetryInterval(time.Millisecond*10),
+	)
+
+	runCtx, runCancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer runCancel()
+	if err := migrator.Run(runCtx); err == nil {
+		t.Fatal("expected migration to fail while the lock is held")
+	}
+	if len(applied) > 0 {
+		t.Fatal("expected no step to be applied without lock")
+	}
+
+	// the other node completed the migration and released the lock.
+	client.Set(ctx, keyspace.SchemaVersion, "1", 0)
+	client.RevokeLease(ctx, lease)
+	if err := migrator.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) > 0 {
+		t.Errorf("expected already migrated steps to be skipped, got %v", applied)
+	}
+}
+
+func TestMoveGranitDiskClusters(t *testing.T) {
+	ctx := context.Background()
+	client := memdb.New()
+	defer client.Terminate(ctx)
+
+	const legacyPrefix = "/GRANIT/DISK/CLUSTERNODES/"
+	client.Set(ctx, legacyPrefix+"a", "legacy-a", 0)
+	client.Set(ctx, legacyPrefix+"b", "legacy-b", 0)
+	client.Set(ctx, keyspace.GranitDisk.Cluster.Key("b"), "current-b", 0)
+
+	// the step must be idempotent, it is executed twice.
+	for i := 0; i < 2; i++ {
+		if err := moveGranitDiskClusters(ctx, client); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: keyspace.GranitDisk.Cluster.Key("a"), want: "legacy-a"},
+		{key: keyspace.GranitDisk.Cluster.Key("b"), want: "current-b"},
+		{key: legacyPrefix + "a", want: ""},
+		{key: legacyPrefix + "b", want: ""},
+	}
+	for _, tt := range tests {
+		if got, _ := client.Get(ctx, tt.key); got != tt.want {
+			t.Errorf("expected '%s' to be '%s', got '%s'", tt.key, tt.want, got)
+		}
+	}
+}
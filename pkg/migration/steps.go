@@ -0,0 +1,62 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package migration
+
+import (
+	"context"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/keyspace"
+)
+
+// Steps holds the ordered layout changes of the cthul database.
+// New layout changes are appended with the next version, existing steps must never be modified.
+var Steps = []Step{
+	{
+		Version:     1,
+		Description: "initial database layout",
+		Apply: func(ctx context.Context, client db.Client) error {
+			return nil
+		},
+	},
+	{
+		Version:     2,
+		Description: "move granit disk clusters from CLUSTERNODES to CLUSTER",
+		Apply:       moveGranitDiskClusters,
+	},
+}
+
+// moveGranitDiskClusters moves disk clusters written with the legacy operator prefix to the cluster field.
+// Clusters that already exist in the new field are not overwritten.
+func moveGranitDiskClusters(ctx context.Context, client db.Client) error {
+	const legacyPrefix = "/GRANIT/DISK/CLUSTERNODES/"
+
+	clusters, err := client.GetRange(ctx, legacyPrefix)
+	if err != nil {
+		return err
+	}
+	for key, rawCluster := range clusters {
+		_, err := client.CompareAndSet(ctx, keyspace.GranitDisk.Cluster.Key(key[len(legacyPrefix):]), rawCluster, 0, 0)
+		if err != nil {
+			return err
+		}
+	}
+	return client.DeleteRange(ctx, legacyPrefix)
+}
@@ -23,27 +23,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
 	"cthul.io/cthul/pkg/api/proton/v1/inter"
 	"cthul.io/cthul/pkg/db"
-	"golang.org/x/sync/errgroup"
+	"cthul.io/cthul/pkg/keyspace"
+	"cthul.io/cthul/pkg/resource"
 	"google.golang.org/protobuf/proto"
 )
 
-// NodeMismatchErr indicates that the action cannot be executed on this node.
-type NodeMismatchErr struct {
-	Node    string
-	Message string
-}
-
-func (n *NodeMismatchErr) Error() string {
-	return n.Message
-}
-
-// Controller provides an interface for wave inter device operations.
+// Controller provides an interface for proton inter device operations.
+// The generic resource operations (Create, Apply, Attach, Detach, Delete) are provided by the resource controller,
+// the device cluster is removed together with the device.
 type Controller struct {
-	node   string
+	*resource.Controller[*inter.InterConfig]
 	client db.Client
 }
 
@@ -51,7 +43,9 @@ type Option func(*Controller)
 
 func New(node string, client db.Client, opts ...Option) *Controller {
 	controller := &Controller{
-		node:   node,
+		Controller: resource.New[*inter.InterConfig](node, "device", keyspace.ProtonInter.Resource, client,
+			resource.WithOwnedFields[*inter.InterConfig](keyspace.ProtonInter.Cluster),
+		),
 		client: client,
 	}
 
@@ -64,79 +58,36 @@ func New(node string, client db.Client, opts ...Option) *Controller {
 
 // List returns a map containing inter device uuids and associated metadata from the database.
 func (c *Controller) List(ctx context.Context) (map[string]*inter.Inter, error) {
-	inters := map[string]*inter.Inter{}
-
-	reqnodes, err := c.client.GetRange(ctx, "/PROTON/INTER/REQNODE/")
-	if err != nil {
-		return nil, fmt.Errorf("fetching inter device reqnode: %w", err)
-	}
-	nodes, err := c.client.GetRange(ctx, "/PROTON/INTER/NODE/")
+	devices, err := c.Controller.List(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("fetching inter device node: %w", err)
+		return nil, err
 	}
-	clusters, err := c.client.GetRange(ctx, "/PROTON/INTER/CLUSTER/")
+	clusters, err := c.client.GetRange(ctx, keyspace.ProtonInter.Cluster.Prefix())
 	if err != nil {
-		return nil, fmt.Errorf("fetching inter device cluster: %w", err)
+		return nil, fmt.Errorf("fetching device cluster: %w", err)
 	}
-	configs, err := c.client.GetRange(ctx, "/PROTON/INTER/CONFIG/")
-	if err != nil {
-		return nil, fmt.Errorf("fetching inter device config: %w", err)
-	}
-
-	for key, rawConfig := range configs {
-		var interErr error
-		id := strings.TrimPrefix(key, "/PROTON/INTER/CONFIG/")
-		rawCluster := clusters[fmt.Sprint("/PROTON/INTER/CLUSTER/", id)]
-		reqnode := reqnodes[fmt.Sprint("/PROTON/INTER/REQNODE/", id)]
-		node := nodes[fmt.Sprint("/PROTON/INTER/NODE/", id)]
-
-		config := &inter.InterConfig{}
-		err = proto.Unmarshal([]byte(rawConfig), config)
-		if err != nil {
-			interErr = errors.Join(interErr, fmt.Errorf("parsing device config: %w", err))
-		}
 
+	inters := map[string]*inter.Inter{}
+	for id, device := range devices {
 		cluster := &inter.InterCluster{}
-		err = proto.Unmarshal([]byte(rawCluster), cluster)
+		err = proto.Unmarshal([]byte(clusters[keyspace.ProtonInter.Cluster.Key(id)]), cluster)
 		if err != nil {
-			interErr = errors.Join(interErr, fmt.Errorf("parsing device cluster: %w", err))
-		}
-
-		inter := &inter.Inter{
-			Reqnode: reqnode,
-			Node:    node,
-			Cluster: cluster,
-			Config:  config,
-		}
-		if interErr != nil {
-			inter.Error = interErr.Error()
+			device.Error = errors.Join(device.Error, fmt.Errorf("parsing device cluster: %w", err))
 		}
-		inters[id] = inter
+		inters[id] = newInter(device, cluster)
 	}
 	return inters, nil
 }
 
 // Lookup searches for the device by id and returns its configuration.
 func (c *Controller) Lookup(ctx context.Context, id string) (*inter.Inter, error) {
-	reqnode, err := c.client.Get(ctx, fmt.Sprintf("/PROTON/INTER/REQNODE/%s", id))
-	if err != nil {
-		return nil, fmt.Errorf("fetching inter device reqnode: %w", err)
-	}
-	node, err := c.client.Get(ctx, fmt.Sprintf("/PROTON/INTER/NODE/%s", id))
+	device, err := c.Controller.Lookup(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("fetching inter device node: %w", err)
+		return nil, err
 	}
-	rawCluster, err := c.client.Get(ctx, fmt.Sprintf("/PROTON/INTER/CLUSTER/%s", id))
+	rawCluster, err := c.client.Get(ctx, keyspace.ProtonInter.Cluster.Key(id))
 	if err != nil {
-		return nil, fmt.Errorf("fetching inter device cluster: %w", err)
-	}
-	rawConfig, err := c.client.Get(ctx, fmt.Sprintf("/PROTON/INTER/CONFIG/%s", id))
-	if err != nil {
-		return nil, fmt.Errorf("fetching inter device config: %w", err)
-	}
-
-	if rawConfig == "" {
-		return nil, fmt.Errorf("device not found")
+		return nil, fmt.Errorf("fetching device cluster: %w", err)
 	}
 
 	cluster := &inter.InterCluster{}
@@ -144,106 +95,20 @@ func (c *Controller) Lookup(ctx context.Context, id string) (*inter.Inter, error
 	if err != nil {
 		return nil, fmt.Errorf("parsing device cluster: %w", err)
 	}
-
-	config := &inter.InterConfig{}
-	err = proto.Unmarshal([]byte(rawConfig), config)
-	if err != nil {
-		return nil, fmt.Errorf("parsing device config: %w", err)
-	}
-
-	return &inter.Inter{
-		Reqnode: reqnode,
-		Node:    node,
-		Config:  config,
-    Cluster: cluster,
-		Error:   "",
-	}, nil
+	return newInter(device, cluster), nil
 }
 
-// Attach requests the device to be relocated to the specified node and waits until it's ready (if wait flag is set).
-func (c *Controller) Attach(ctx context.Context, id, node string, wait bool) error {
-	if !wait {
-		_, err := c.client.Set(ctx, fmt.Sprintf("/PROTON/INTER/REQNODE/%s", id), node, 0)
-		if err != nil {
-			return err
-		}
-	}
-
-	pollCtx, pollCtxCancel := context.WithCancel(ctx)
-	pollG, pollGCtx := errgroup.WithContext(pollCtx)
-
-	pollG.Go(func() error {
-		err := c.client.Watch(pollGCtx, fmt.Sprintf("/PROTON/INTER/NODE/%s", id), func(_, activeNode string, err error) {
-			if err == nil && node == activeNode {
-				pollCtxCancel()
-			}
-		})
-		if err != nil {
-			return err
-		}
-		return nil
-	})
-
-	// initial check, required in case the node is already set to the requested node (watch will not trigger in this case)
-	pollG.Go(func() error {
-		activeNode, err := c.client.Get(pollGCtx, fmt.Sprintf("/PROTON/INTER/NODE/%s", id))
-		if err != nil {
-			return err
-		}
-		if node == activeNode {
-			pollCtxCancel()
-		}
-		return nil
-	})
-
-	_, err := c.client.Set(ctx, fmt.Sprintf("/PROTON/INTER/REQNODE/%s", id), node, 0)
-	if err != nil {
-		return err
-	}
-
-	err = pollG.Wait()
-	if err != nil {
-		return err
-	}
-
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("context exceeded: device couldn't be attached in the provided context window")
-	default:
-		return nil
-	}
-}
-
-// Detach removes the device from the current node. It doesn't wait until the node fully detached it.
-func (c *Controller) Detach(ctx context.Context, id string) error {
-	err := c.client.Delete(ctx, fmt.Sprintf("/PROTON/INTER/NODE/%s", id))
-	if err != nil {
-		return err
-	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/PROTON/INTER/REQNODE/%s", id))
-	if err != nil {
-		return nil
-	}
-	return nil
-}
-
-// Delete completely removes a inter device and its associated metadata.
-func (c *Controller) Delete(ctx context.Context, id string) error {
-	err := c.client.Delete(ctx, fmt.Sprintf("/PROTON/INTER/NODE/%s", id))
-	if err != nil {
-		return err
-	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/PROTON/INTER/REQNODE/%s", id))
-	if err != nil {
-		return err
-	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/PROTON/INTER/CLUSTER/%s", id))
-	if err != nil {
-		return err
+// newInter converts the generic resource and its cluster to the inter api structure.
+func newInter(device *resource.Resource[*inter.InterConfig], cluster *inter.InterCluster) *inter.Inter {
+	inter := &inter.Inter{
+		Reqnode: device.Reqnode,
+		Node:    device.Node,
+		Cluster: cluster,
+		Config:  device.Config,
+		Error:   "",
 	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/PROTON/INTER/CONFIG/%s", id))
-	if err != nil {
-		return err
+	if device.Error != nil {
+		inter.Error = device.Error.Error()
 	}
-	return nil
+	return inter
 }
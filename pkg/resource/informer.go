@@ -0,0 +1,55 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package resource
+
+import (
+	"log/slog"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/informer"
+	"cthul.io/cthul/pkg/keyspace"
+)
+
+const (
+	// IndexReqnode indexes the reqnode keys of a resource informer by the requested node.
+	IndexReqnode = "reqnode"
+	// IndexNode indexes the node keys of a resource informer by the node the resource is running on.
+	IndexNode = "node"
+)
+
+// NewInformer creates an informer that caches all fields of the resource type.
+// The informer provides the reqnode and node indexes used by the controller (see WithInformer()).
+func NewInformer(logger *slog.Logger, client db.Client, layout keyspace.Resource, opts ...informer.Option) *informer.Informer {
+	opts = append([]informer.Option{
+		informer.WithIndex(IndexReqnode, fieldIndex(layout.Reqnode)),
+		informer.WithIndex(IndexNode, fieldIndex(layout.Node)),
+	}, opts...)
+	return informer.New(logger, client, layout.Prefix(), opts...)
+}
+
+// fieldIndex indexes the keys of the field by their value.
+func fieldIndex(field keyspace.Field) informer.IndexFunc {
+	return func(key, value string) []string {
+		if _, ok := field.Parse(key); ok && value != "" {
+			return []string{value}
+		}
+		return nil
+	}
+}
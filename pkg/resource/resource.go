@@ -0,0 +1,503 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// resource provides the shared controller for resources that are placed on a node.
+// Those resources follow the same database pattern: the config describes the resource, the reqnode holds the node
+// the resource is requested on and the node holds the node the resource is currently running on.
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/informer"
+	"cthul.io/cthul/pkg/keyspace"
+	"cthul.io/cthul/pkg/syncer"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+)
+
+// Resource holds the database state of a single placed resource.
+type Resource[C proto.Message] struct {
+	Reqnode string
+	Node    string
+	Config  C
+	// Error holds errors that occurred while parsing the resource.
+	// It is only set by List, as a single malformed resource must not break the full listing.
+	Error error
+}
+
+// SyncStatus holds the synchronization status of a resource key reported by a node.
+type SyncStatus struct {
+	Node string
+	Key  string
+	syncer.Status
+}
+
+// Controller provides the generic operations for a placed resource type.
+// Resource specific controllers embed it and add their own operations (or wrap List / Lookup to return api types).
+type Controller[C proto.Message] struct {
+	node   string
+	kind   string
+	layout keyspace.Resource
+	client db.Client
+	// owned holds additional fields that belong to the resource and are removed together with it.
+	owned []keyspace.Field
+
+	// informer caches the resource type, reads are served from it once it is synced (nil = no cache).
+	informer *informer.Informer
+	// configs memoizes the parsed configs of the informer cache, so that configs are only parsed on change.
+	configLock sync.Mutex
+	configs    map[string]*parsedConfig[C]
+}
+
+// parsedConfig holds a parsed config and the raw config it was parsed from.
+type parsedConfig[C proto.Message] struct {
+	raw    string
+	config C
+}
+
+type Option[C proto.Message] func(*Controller[C])
+
+// New creates a controller for the resource type. The kind is the human readable name used in errors
+// (e.g. "domain" or "device"), the layout describes the database keys of the resource type.
+func New[C proto.Message](node, kind string, layout keyspace.Resource, client db.Client, opts ...Option[C]) *Controller[C] {
+	controller := &Controller[C]{
+		node:       node,
+		kind:       kind,
+		layout:     layout,
+		client:     client,
+		owned:      []keyspace.Field{},
+		informer:   nil,
+		configLock: sync.Mutex{},
+		configs:    map[string]*parsedConfig[C]{},
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// WithOwnedFields defines additional fields that belong to the resource (e.g. the cluster of a replicated resource).
+// Those fields are removed atomically together with the resource on Delete.
+func WithOwnedFields[C proto.Message](fields ...keyspace.Field) Option[C] {
+	return func(c *Controller[C]) {
+		c.owned = append(c.owned, fields...)
+	}
+}
+
+// WithInformer defines an informer that caches the resource type (see NewInformer()).
+// List and Lookup are served from the cache once it is synced, modifications always go to the database.
+func WithInformer[C proto.Message](informer *informer.Informer) Option[C] {
+	return func(c *Controller[C]) {
+		c.informer = informer
+	}
+}
+
+// cached returns true if reads can be served from the informer cache.
+func (c *Controller[C]) cached() bool {
+	return c.informer != nil && c.informer.Synced()
+}
+
+// parseConfig parses the raw config. If the controller uses an informer, the parsed config of the id is
+// memoized and a copy of it is returned as long as the raw config does not change.
+func (c *Controller[C]) parseConfig(id, rawConfig string) (C, error) {
+	if c.informer == nil {
+		config := c.newConfig()
+		err := proto.Unmarshal([]byte(rawConfig), config)
+		return config, err
+	}
+
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	if parsed, ok := c.configs[id]; ok && parsed.raw == rawConfig {
+		return proto.Clone(parsed.config).(C), nil
+	}
+	config := c.newConfig()
+	if err := proto.Unmarshal([]byte(rawConfig), config); err != nil {
+		delete(c.configs, id)
+		return config, err
+	}
+	c.configs[id] = &parsedConfig[C]{raw: rawConfig, config: proto.Clone(config).(C)}
+	return config, nil
+}
+
+// newConfig creates an empty config message.
+func (c *Controller[C]) newConfig() C {
+	var config C
+	return config.ProtoReflect().Type().New().Interface().(C)
+}
+
+// List returns a map containing resource ids and associated metadata from the database.
+func (c *Controller[C]) List(ctx context.Context) (map[string]*Resource[C], error) {
+	if c.cached() {
+		kvs := c.informer.List()
+		resources := map[string]*Resource[C]{}
+		for key, rawConfig := range kvs {
+			if id, ok := c.layout.Config.Parse(key); ok {
+				resources[id] = c.newResource(id, rawConfig, kvs[c.layout.Reqnode.Key(id)], kvs[c.layout.Node.Key(id)])
+			}
+		}
+
+		// parsed configs of removed resources are dropped to keep the memoization bounded.
+		c.configLock.Lock()
+		for id := range c.configs {
+			if _, ok := resources[id]; !ok {
+				delete(c.configs, id)
+			}
+		}
+		c.configLock.Unlock()
+		return resources, nil
+	}
+
+	resources := map[string]*Resource[C]{}
+
+	reqnodes, err := c.client.GetRange(ctx, c.layout.Reqnode.Prefix())
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s reqnode: %w", c.kind, err)
+	}
+	nodes, err := c.client.GetRange(ctx, c.layout.Node.Prefix())
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s node: %w", c.kind, err)
+	}
+	configs, err := c.client.GetRange(ctx, c.layout.Config.Prefix())
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s config: %w", c.kind, err)
+	}
+
+	for key, rawConfig := range configs {
+		id := c.layout.Config.Id(key)
+		resources[id] = c.newResource(id, rawConfig, reqnodes[c.layout.Reqnode.Key(id)], nodes[c.layout.Node.Key(id)])
+	}
+	return resources, nil
+}
+
+// ListByReqnode returns all resources requested on the specified node.
+func (c *Controller[C]) ListByReqnode(ctx context.Context, node string) (map[string]*Resource[C], error) {
+	return c.listBy(ctx, IndexReqnode, c.layout.Reqnode, node, func(r *Resource[C]) string { return r.Reqnode })
+}
+
+// ListByNode returns all resources currently running on the specified node.
+func (c *Controller[C]) ListByNode(ctx context.Context, node string) (map[string]*Resource[C], error) {
+	return c.listBy(ctx, IndexNode, c.layout.Node, node, func(r *Resource[C]) string { return r.Node })
+}
+
+// listBy returns all resources with the specified node in the field. With a synced informer the index is used,
+// otherwise all resources are listed and filtered.
+func (c *Controller[C]) listBy(
+	ctx context.Context, index string, field keyspace.Field, node string, value func(*Resource[C]) string,
+) (map[string]*Resource[C], error) {
+	resources := map[string]*Resource[C]{}
+	if c.cached() {
+		keys, err := c.informer.ByIndex(index, node)
+		if err != nil {
+			return nil, err
+		}
+		for key := range keys {
+			id := field.Id(key)
+			rawConfig, ok := c.informer.Get(c.layout.Config.Key(id))
+			if !ok {
+				continue
+			}
+			reqnode, _ := c.informer.Get(c.layout.Reqnode.Key(id))
+			node, _ := c.informer.Get(c.layout.Node.Key(id))
+			resources[id] = c.newResource(id, rawConfig, reqnode, node)
+		}
+		return resources, nil
+	}
+
+	all, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for id, resource := range all {
+		if value(resource) == node {
+			resources[id] = resource
+		}
+	}
+	return resources, nil
+}
+
+// newResource creates the resource from its raw database state.
+// Parsing errors are attached to the resource, as a single malformed resource must not break the full listing.
+func (c *Controller[C]) newResource(id, rawConfig, reqnode, node string) *Resource[C] {
+	resource := &Resource[C]{
+		Reqnode: reqnode,
+		Node:    node,
+		Error:   nil,
+	}
+	config, err := c.parseConfig(id, rawConfig)
+	if err != nil {
+		resource.Error = fmt.Errorf("parsing %s config: %w", c.kind, err)
+	}
+	resource.Config = config
+	return resource
+}
+
+// Lookup searches for the resource by id and returns its configuration.
+// Resources missing in the informer cache are looked up in the database, as the cache may lag behind
+// modifications that were just written (e.g. a lookup right after creating the resource).
+func (c *Controller[C]) Lookup(ctx context.Context, id string) (*Resource[C], error) {
+	if c.cached() {
+		if rawConfig, ok := c.informer.Get(c.layout.Config.Key(id)); ok {
+			reqnode, _ := c.informer.Get(c.layout.Reqnode.Key(id))
+			node, _ := c.informer.Get(c.layout.Node.Key(id))
+			config, err := c.parseConfig(id, rawConfig)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s config: %w", c.kind, err)
+			}
+			return &Resource[C]{Reqnode: reqnode, Node: node, Config: config, Error: nil}, nil
+		}
+	}
+
+	reqnode, err := c.client.Get(ctx, c.layout.Reqnode.Key(id))
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s reqnode: %w", c.kind, err)
+	}
+	node, err := c.client.Get(ctx, c.layout.Node.Key(id))
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s node: %w", c.kind, err)
+	}
+	rawConfig, err := c.client.Get(ctx, c.layout.Config.Key(id))
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s config: %w", c.kind, err)
+	}
+
+	if rawConfig == "" {
+		return nil, fmt.Errorf("%s not found", c.kind)
+	}
+
+	config, err := c.parseConfig(id, rawConfig)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s config: %w", c.kind, err)
+	}
+
+	return &Resource[C]{
+		Reqnode: reqnode,
+		Node:    node,
+		Config:  config,
+		Error:   nil,
+	}, nil
+}
+
+// CheckLocal ensures the resource is currently located on the node of the controller.
+// Returns a NodeMismatchErr pointing to the actual node of the resource otherwise.
+func (c *Controller[C]) CheckLocal(ctx context.Context, id string) error {
+	node, err := c.client.Get(ctx, c.layout.Node.Key(id))
+	if err != nil {
+		return fmt.Errorf("fetching %s node: %w", c.kind, err)
+	}
+	if node != c.node {
+		return &NodeMismatchErr{
+			Message: fmt.Sprintf("%s must be on the same node as the controller", c.kind), Node: node,
+		}
+	}
+	return nil
+}
+
+// SyncStatus returns the synchronization status of the resource keys (config and reqnode) reported by the node
+// the resource is requested on and, while the resource is moved, by the node it is currently located on.
+// It shows why a resource is not converging, keys without a reported status are omitted.
+func (c *Controller[C]) SyncStatus(ctx context.Context, id string) ([]SyncStatus, error) {
+	reqnode, err := c.client.Get(ctx, c.layout.Reqnode.Key(id))
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s reqnode: %w", c.kind, err)
+	}
+	node, err := c.client.Get(ctx, c.layout.Node.Key(id))
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s node: %w", c.kind, err)
+	}
+
+	nodes := []string{}
+	if reqnode != "" {
+		nodes = append(nodes, reqnode)
+	}
+	if node != "" && node != reqnode {
+		nodes = append(nodes, node)
+	}
+
+	statuses := []SyncStatus{}
+	for _, node := range nodes {
+		for _, key := range []string{c.layout.Config.Key(id), c.layout.Reqnode.Key(id)} {
+			status, err := syncer.LookupStatus(ctx, c.client, node, key)
+			if err != nil {
+				return nil, fmt.Errorf("fetching %s sync status: %w", c.kind, err)
+			}
+			if status != nil {
+				statuses = append(statuses, SyncStatus{Node: node, Key: key, Status: *status})
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// Create inserts the resource configuration. Fails if the id is already in use.
+func (c *Controller[C]) Create(ctx context.Context, id string, config C) error {
+	rawConfig, err := proto.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("cannot serialize config: %w", err)
+	}
+
+	ok, err := c.client.CompareAndSet(ctx, c.layout.Config.Key(id), string(rawConfig), 0, 0)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s already exists", c.kind)
+	}
+	return nil
+}
+
+// Apply upserts the resource configuration.
+func (c *Controller[C]) Apply(ctx context.Context, id string, config C) error {
+	rawConfig, err := proto.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("cannot serialize config: %w", err)
+	}
+
+	_, err = c.client.Set(ctx, c.layout.Config.Key(id), string(rawConfig), 0)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Attach requests the resource to be relocated to the specified node and waits until it's ready (if wait flag is set).
+func (c *Controller[C]) Attach(ctx context.Context, id, node string, wait bool) error {
+	if !wait {
+		return c.request(ctx, id, node, nil, nil)
+	}
+
+	pollCtx, pollCtxCancel := context.WithCancel(ctx)
+	pollG, pollGCtx := errgroup.WithContext(pollCtx)
+
+	pollG.Go(func() error {
+		err := c.client.Watch(pollGCtx, c.layout.Node.Key(id), 0, func(event db.Event, err error) {
+			if err == nil && event.Type == db.EVENT_PUT && node == event.Value {
+				pollCtxCancel()
+			}
+		})
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+
+	// initial check, required in case the node is already set to the requested node (watch will not trigger in this case)
+	pollG.Go(func() error {
+		activeNode, err := c.client.Get(pollGCtx, c.layout.Node.Key(id))
+		if err != nil {
+			return err
+		}
+		if node == activeNode {
+			pollCtxCancel()
+		}
+		return nil
+	})
+
+	err := c.request(ctx, id, node, nil, nil)
+	if err != nil {
+		pollCtxCancel()
+		pollG.Wait()
+		return err
+	}
+
+	err = pollG.Wait()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context exceeded: %s couldn't be attached in the provided context window", c.kind)
+	default:
+		return nil
+	}
+}
+
+// AttachFenced requests the resource to be relocated to the specified node, only if the fence condition holds.
+// It is used by leader-only components that attach the fencing token of their term (see elect.Campaign),
+// so that requests of a deposed leader are rejected. It doesn't wait until the resource is ready.
+func (c *Controller[C]) AttachFenced(ctx context.Context, id, node string, fence db.Condition) error {
+	return c.request(ctx, id, node, []db.Condition{fence}, nil)
+}
+
+// AttachTxn requests the resource to be relocated to the specified node in one transaction together with the
+// provided operations, only if all conditions hold. It is used by components that track their own state next
+// to the request (e.g. the rebalancer cooldown), so that the state cannot diverge from the request.
+// It doesn't wait until the resource is ready.
+func (c *Controller[C]) AttachTxn(ctx context.Context, id, node string, conditions []db.Condition, operations []db.Operation) error {
+	return c.request(ctx, id, node, conditions, operations)
+}
+
+// request sets the reqnode of the resource and applies the additional operations,
+// only if the resource exists and all conditions hold.
+func (c *Controller[C]) request(ctx context.Context, id, node string, conditions []db.Condition, operations []db.Operation) error {
+	conditions = append([]db.Condition{{
+		Key: c.layout.Config.Key(id), Compare: db.COMPARE_NOT_EQUAL, Revision: 0,
+	}}, conditions...)
+	operations = append([]db.Operation{{
+		Type: db.OPERATION_SET, Key: c.layout.Reqnode.Key(id), Value: node,
+	}}, operations...)
+	ok, err := c.client.Txn(ctx, conditions, operations)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if len(conditions) > 1 {
+			return fmt.Errorf("%s not found or condition rejected the request", c.kind)
+		}
+		return fmt.Errorf("%s not found", c.kind)
+	}
+	return nil
+}
+
+// Detach atomically removes the resource from the current node. It doesn't wait until the node fully detached it.
+func (c *Controller[C]) Detach(ctx context.Context, id string) error {
+	_, err := c.client.Txn(ctx, nil, []db.Operation{
+		{Type: db.OPERATION_DELETE, Key: c.layout.Node.Key(id)},
+		{Type: db.OPERATION_DELETE, Key: c.layout.Reqnode.Key(id)},
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete completely and atomically removes a resource and its associated metadata.
+func (c *Controller[C]) Delete(ctx context.Context, id string) error {
+	operations := []db.Operation{
+		{Type: db.OPERATION_DELETE, Key: c.layout.Node.Key(id)},
+		{Type: db.OPERATION_DELETE, Key: c.layout.Reqnode.Key(id)},
+	}
+	for _, field := range c.owned {
+		operations = append(operations, db.Operation{Type: db.OPERATION_DELETE, Key: field.Key(id)})
+	}
+	operations = append(operations, db.Operation{Type: db.OPERATION_DELETE, Key: c.layout.Config.Key(id)})
+
+	_, err := c.client.Txn(ctx, nil, operations)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"cthul.io/cthul/pkg/api/wave/v1/serial"
+	"cthul.io/cthul/pkg/db/memdb"
+	"cthul.io/cthul/pkg/keyspace"
+	"cthul.io/cthul/pkg/syncer"
+)
+
+func TestSyncStatus(t *testing.T) {
+	configKey := keyspace.WaveSerial.Config.Key("dev")
+	reqnodeKey := keyspace.WaveSerial.Reqnode.Key("dev")
+
+	tests := []struct {
+		name     string
+		reqnode  string
+		node     string
+		reported map[string]string
+		want     []SyncStatus
+	}{
+		{
+			name:     "detached resource",
+			reported: map[string]string{"a": configKey},
+			want:     []SyncStatus{},
+		},
+		{
+			name:     "status of the requested node",
+			reqnode:  "a",
+			node:     "a",
+			reported: map[string]string{"a": configKey, "b": configKey},
+			want:     []SyncStatus{{Node: "a", Key: configKey}},
+		},
+		{
+			name:     "status of both nodes while moving",
+			reqnode:  "b",
+			node:     "a",
+			reported: map[string]string{"a": reqnodeKey, "b": configKey},
+			want:     []SyncStatus{{Node: "b", Key: configKey}, {Node: "a", Key: reqnodeKey}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := memdb.New()
+			defer client.Terminate(ctx)
+
+			controller := New[*serial.SerialConfig]("a", "device", keyspace.WaveSerial, client)
+			if err := controller.Create(ctx, "dev", &serial.SerialConfig{}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.reqnode != "" {
+				mustSet(t, client, reqnodeKey, tt.reqnode)
+			}
+			if tt.node != "" {
+				mustSet(t, client, keyspace.WaveSerial.Node.Key("dev"), tt.node)
+			}
+			for node, key := range tt.reported {
+				rawStatus, err := json.Marshal(syncer.Status{Error: "failed on " + node, Attempts: 1})
+				if err != nil {
+					t.Fatal(err)
+				}
+				mustSet(t, client, keyspace.SyncStatus.Key(node+"/"+strings.TrimPrefix(key, "/")), string(rawStatus))
+			}
+
+			got, err := controller.SyncStatus(ctx, "dev")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("SyncStatus() = %+v, want %+v", got, tt.want)
+			}
+			for i, want := range tt.want {
+				if got[i].Node != want.Node || got[i].Key != want.Key || got[i].Error != "failed on "+want.Node {
+					t.Errorf("status %d = %+v, want %s on %s", i, got[i], want.Key, want.Node)
+				}
+			}
+		})
+	}
+}
+
+func mustSet(t *testing.T, client *memdb.Client, key, value string) {
+	t.Helper()
+	if _, err := client.Set(context.Background(), key, value, 0); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,219 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/keyspace"
+	"cthul.io/cthul/pkg/workqueue"
+)
+
+// Status describes the synchronization state of a single key on a node. It is written to the database after
+// every failed attempt and after successful attempts (throttled by the status interval, see WithStatusInterval()),
+// which allows api consumers to show why a resource is not converging and when it was applied last.
+type Status struct {
+	// Error holds the error of the latest failed attempt (empty if the latest attempt succeeded).
+	Error string `json:"error,omitempty"`
+	// Attempts holds the number of consecutive failed attempts.
+	Attempts int64 `json:"attempts"`
+	// LastError holds the unix timestamp of the latest failed attempt (0 = never failed).
+	LastError int64 `json:"last_error"`
+	// LastSuccess holds the unix timestamp of the latest successful attempt (0 = never succeeded).
+	LastSuccess int64 `json:"last_success"`
+}
+
+// LookupStatus returns the synchronization status of the key reported by the node.
+// Returns nil if the node did not report a status.
+func LookupStatus(ctx context.Context, client db.Client, node, key string) (*Status, error) {
+	rawStatus, err := client.Get(ctx, statusKey(node, key))
+	if err != nil {
+		return nil, err
+	}
+	if rawStatus == "" {
+		return nil, nil
+	}
+	status := &Status{}
+	if err := json.Unmarshal([]byte(rawStatus), status); err != nil {
+		return nil, fmt.Errorf("parsing sync status: %w", err)
+	}
+	return status, nil
+}
+
+// statusKey returns the status key of the key on the node. The status is scoped per node, as multiple nodes
+// can apply the same key (e.g. the reqnode key of a resource).
+func statusKey(node, key string) string {
+	return keyspace.SyncStatus.Key(node + "/" + strings.TrimPrefix(key, "/"))
+}
+
+// keyState holds the status of a key.
+type keyState struct {
+	status Status
+	// reported holds the point in time the status was last written to the database.
+	reported time.Time
+}
+
+// item holds the latest observed state of a key.
+type item struct {
+	value   string
+	deleted bool
+}
+
+// routine holds the state of a single syncer routine added via Add().
+type routine struct {
+	ctx context.Context
+	fn  func(context.Context, string, string, bool) error
+	// timeout specifies the maximum duration of a single fn call.
+	timeout time.Duration
+	// queue holds the keys that must be reconciled by the workers of the routine.
+	queue *workqueue.Queue
+	// resync triggers an immediate run of the periodic routine (e.g. after the watcher reconnected).
+	resync chan struct{}
+	// wg tracks every goroutine of the routine (periodic, watcher and workers).
+	wg sync.WaitGroup
+
+	stateLock sync.Mutex
+	// items holds the latest observed state of every key, updates of a waiting key are coalesced.
+	items map[string]item
+	// states holds the status of every key that was applied at least once.
+	states map[string]*keyState
+}
+
+// enqueue records the latest state of the key and queues it for reconciliation.
+// Resync states (captured by the periodic routine) do not override a pending deletion, as the list
+// may have been captured before the key was deleted.
+func (r *routine) enqueue(key, value string, deleted, resync bool) {
+	r.stateLock.Lock()
+	if current, ok := r.items[key]; !ok || !resync || !current.deleted {
+		r.items[key] = item{value: value, deleted: deleted}
+	}
+	r.stateLock.Unlock()
+
+	r.queue.Add(key)
+}
+
+// work processes keys from the routine queue until the queue is shut down.
+func (s *Syncer) work(r *routine) {
+	for {
+		key, ok := r.queue.Get()
+		if !ok {
+			return
+		}
+		s.reconcile(r, key)
+		r.queue.Done(key)
+	}
+}
+
+// reconcile calls the routine function with the latest state of the key and records the result.
+// Failed keys are requeued with exponential backoff.
+func (s *Syncer) reconcile(r *routine, key string) {
+	r.stateLock.Lock()
+	current, ok := r.items[key]
+	r.stateLock.Unlock()
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.ctx, r.timeout)
+	defer cancel()
+	err := r.fn(ctx, key, current.value, current.deleted)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("cannot apply state: %s", err.Error()), slog.String("id", path.Base(key)))
+		r.queue.AddRateLimited(key)
+	} else {
+		s.logger.Debug("successfully applied state", slog.String("id", path.Base(key)))
+		r.queue.Forget(key)
+	}
+
+	now := time.Now()
+	var report *Status
+	remove := false
+
+	r.stateLock.Lock()
+	if err == nil && current.deleted && r.items[key] == current {
+		delete(r.items, key)
+	}
+	state, ok := r.states[key]
+	if !ok {
+		state = &keyState{}
+		r.states[key] = state
+	}
+	switch {
+	case err != nil:
+		state.status.Error = err.Error()
+		state.status.Attempts++
+		state.status.LastError = now.Unix()
+		report = &Status{}
+		*report = state.status
+	case current.deleted:
+		// the status of a removed key is removed with it.
+		delete(r.states, key)
+		remove = true
+	default:
+		recovered := state.status.Error != ""
+		state.status = Status{Error: "", Attempts: 0, LastError: state.status.LastError, LastSuccess: now.Unix()}
+		// successful attempts are reported immediately after a recovery, otherwise they are throttled.
+		if recovered || now.Sub(state.reported) >= s.statusInterval {
+			report = &Status{}
+			*report = state.status
+		}
+	}
+	if report != nil {
+		state.reported = now
+	}
+	r.stateLock.Unlock()
+
+	// the status is written without holding the state lock, this ensures slow database writes
+	// do not block the watcher and periodic routine from queueing keys.
+	if remove {
+		s.deleteStatus(r.ctx, key)
+	} else if report != nil {
+		s.writeStatus(r.ctx, key, report)
+	}
+}
+
+// writeStatus reports the status of the key to the database.
+func (s *Syncer) writeStatus(ctx context.Context, key string, status *Status) {
+	rawStatus, err := json.Marshal(status)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("failed to serialize sync status: %s", err.Error()))
+		return
+	}
+	_, err = s.client.Set(ctx, statusKey(s.nodeId, key), string(rawStatus), 0)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("failed to report sync status: %s", err.Error()), slog.String("id", path.Base(key)))
+	}
+}
+
+// deleteStatus removes the status of the key from the database.
+func (s *Syncer) deleteStatus(ctx context.Context, key string) {
+	err := s.client.Delete(ctx, statusKey(s.nodeId, key))
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("failed to remove sync status: %s", err.Error()), slog.String("id", path.Base(key)))
+	}
+}
@@ -23,11 +23,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"path"
 	"sync"
 	"time"
 
 	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/workqueue"
 )
 
 // Syncer is a utility component that helps operators to apply a state from the database to the system.
@@ -52,6 +52,24 @@ type Syncer struct {
 	// wait flag, to ensure goroutines are not leaking, they are tracked by the trackMap AND the operationWg.
 	trackMapLock sync.Mutex
 	trackMap     map[string]func(bool)
+
+	// watchState holds the state of the incremental watcher of every routine (nil = watcher is healthy).
+	watchStateLock sync.Mutex
+	watchState     map[string]error
+
+	// retryBase specifies the delay before the first retry of a failed key or watcher,
+	// it is doubled on every attempt.
+	retryBase time.Duration
+	// retryMax specifies the maximum delay between two retries of a failed key or watcher.
+	retryMax time.Duration
+
+	// workers specifies the number of workers that reconcile the keys of a routine concurrently.
+	workers int
+
+	// nodeId specifies the id of the node the syncer applies the keys to (used to scope the key status).
+	nodeId string
+	// statusInterval specifies the minimum interval between two status reports of a successfully applied key.
+	statusInterval time.Duration
 }
 
 type Option func(*Syncer)
@@ -59,13 +77,20 @@ type Option func(*Syncer)
 func New(logger* slog.Logger, client db.Client, opts ...Option) *Syncer {
 	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
 	syncer := &Syncer{
-		rootCtx:       rootCtx,
-		rootCtxCancel: rootCtxCancel,
-		client:        client,
-		logger:       logger.WithGroup("syncer"),
-		operationWg:   sync.WaitGroup{},
-		trackMapLock:  sync.Mutex{},
-		trackMap:      map[string]func(bool){},
+		rootCtx:        rootCtx,
+		rootCtxCancel:  rootCtxCancel,
+		client:         client,
+		logger:         logger.WithGroup("syncer"),
+		operationWg:    sync.WaitGroup{},
+		trackMapLock:   sync.Mutex{},
+		trackMap:       map[string]func(bool){},
+		watchStateLock: sync.Mutex{},
+		watchState:     map[string]error{},
+		retryBase:      time.Second,
+		retryMax:       time.Minute,
+		workers:        2,
+		nodeId:         "undefined",
+		statusInterval: time.Minute,
 	}
 
 	for _, opt := range opts {
@@ -75,10 +100,47 @@ func New(logger* slog.Logger, client db.Client, opts ...Option) *Syncer {
 	return syncer
 }
 
+// WithRetryBackoff defines a custom backoff for failed keys. The first retry is executed after base,
+// every further attempt doubles the delay up to max.
+func WithRetryBackoff(base, max time.Duration) Option {
+	return func(s *Syncer) {
+		s.retryBase = base
+		s.retryMax = max
+	}
+}
+
+// WithWorkers defines a custom number of workers per routine. A key is never processed by multiple
+// workers at the same time, the worker count only limits how many different keys are applied concurrently.
+func WithWorkers(workers int) Option {
+	return func(s *Syncer) {
+		s.workers = workers
+	}
+}
+
+// WithNodeId specifies the id of the local node. The status of the applied keys is reported per node.
+func WithNodeId(id string) Option {
+	return func(s *Syncer) {
+		s.nodeId = id
+	}
+}
+
+// WithStatusInterval defines a custom minimum interval between two status reports of a successfully applied key.
+// Failed attempts and recoveries are always reported immediately.
+func WithStatusInterval(interval time.Duration) Option {
+	return func(s *Syncer) {
+		s.statusInterval = interval
+	}
+}
+
 // Add adds a routine to the syncer. This means that the syncer starts two goroutines one that incrementally
 // watches $prefix and one that is executed periodically in the specified interval. Both goroutines
-// fire $fn periodically / on change, passing the value of $prefix to $fn.
-func (s *Syncer) Add(prefix string, interval int64, fn func(context.Context, string, string) error) {
+// fire $fn periodically / on change, passing the key, the value and whether the key was deleted to $fn.
+// Deletions are only reported by the incremental watcher, the periodic routine only captures existing keys.
+// If the watcher fails, it is reestablished with backoff and a full resync is triggered (see watch()).
+// Both sources only queue the key, the latest state is applied by the routine workers. Updates of a key
+// that is still waiting are coalesced and $fn is never called concurrently for the same key.
+// If $fn fails, the key is retried with exponential backoff and its status is reported to the database.
+func (s *Syncer) Add(prefix string, interval int64, fn func(context.Context, string, string, bool) error) {
 	s.trackMapLock.Lock()
 	defer s.trackMapLock.Unlock()
 
@@ -86,68 +148,82 @@ func (s *Syncer) Add(prefix string, interval int64, fn func(context.Context, str
 		return
 	}
 
-	funcWg := sync.WaitGroup{}
 	funcCtx, funcCtxCancel := context.WithCancel(s.rootCtx)
+	r := &routine{
+		ctx:       funcCtx,
+		fn:        fn,
+		timeout:   time.Duration(interval) * time.Second,
+		queue:     workqueue.New(workqueue.WithBackoff(s.retryBase, s.retryMax)),
+		resync:    make(chan struct{}, 1),
+		wg:        sync.WaitGroup{},
+		stateLock: sync.Mutex{},
+		items:     map[string]item{},
+		states:    map[string]*keyState{},
+	}
+	s.watchStateLock.Lock()
+	s.watchState[prefix] = nil
+	s.watchStateLock.Unlock()
 
 	s.operationWg.Add(1)
-	funcWg.Add(1)
+	r.wg.Add(1)
 	go func() {
 		defer s.operationWg.Done()
-		defer funcWg.Done()
+		defer r.wg.Done()
 		for {
 			ctx, cancel := context.WithTimeout(funcCtx, time.Duration(interval)*time.Second)
-			defer cancel()
 
 			result, err := s.client.GetRange(ctx, prefix)
 			if err != nil {
 				s.logger.Error(fmt.Sprintf("failed to load key '%s': %s", prefix, err.Error()))
 			} else {
 				for k, state := range result {
-					err = fn(ctx, k, state)
-					if err != nil {
-						s.logger.Error(fmt.Sprintf("cannot apply state: %s", err.Error()), slog.String("id", path.Base(k)))
-					} else {
-						s.logger.Debug("successfully applied state", slog.String("id", path.Base(k)))
-					}
+					r.enqueue(k, state, false, true)
 				}
 			}
 
+			// the timeout context is released on every iteration, as resyncs can restart the loop at any time.
 			select {
 			case <-funcCtx.Done():
+				cancel()
 				return
+			case <-r.resync:
 			case <-ctx.Done():
 			}
+			cancel()
 		}
 	}()
 
 	s.operationWg.Add(1)
-	funcWg.Add(1)
+	r.wg.Add(1)
 	go func() {
 		defer s.operationWg.Done()
-		defer funcWg.Done()
-		err := s.client.WatchRange(funcCtx, prefix, func(k, state string, err error) {
-			if err != nil {
-				s.logger.Error(fmt.Sprintf("failed to load key '%s': %s", prefix, err.Error()))
-				return
-			}
-			err = fn(funcCtx, k, state)
-			if err != nil {
-				s.logger.Error(fmt.Sprintf("cannot apply state: %s", err.Error()), slog.String("id", path.Base(k)))
-			} else {
-				s.logger.Debug("successfully applied state", slog.String("id", path.Base(k)))
-			}
-		})
-		if err != nil {
-			s.logger.Error(fmt.Sprintf(
-				"failed to watch '%s' state: %s; exiting state watcher...", prefix, err.Error(),
-        ), slog.Bool("unrecoverable", true))
-		}
+		defer r.wg.Done()
+		s.watch(prefix, r)
+	}()
+
+	for i := 0; i < s.workers; i++ {
+		s.operationWg.Add(1)
+		r.wg.Add(1)
+		go func() {
+			defer s.operationWg.Done()
+			defer r.wg.Done()
+			s.work(r)
+		}()
+	}
+
+	s.operationWg.Add(1)
+	r.wg.Add(1)
+	go func() {
+		defer s.operationWg.Done()
+		defer r.wg.Done()
+		<-funcCtx.Done()
+		r.queue.Shutdown()
 	}()
 
 	s.trackMap[prefix] = func(wait bool) {
 		funcCtxCancel()
 		if wait {
-			funcWg.Wait()
+			r.wg.Wait()
 		}
 	}
 
@@ -168,6 +244,10 @@ func (s *Syncer) Remove(uuid string, wait bool) {
 
 	cancel(wait)
 	delete(s.trackMap, uuid)
+
+	s.watchStateLock.Lock()
+	delete(s.watchState, uuid)
+	s.watchStateLock.Unlock()
 }
 
 // Shutdown cancels all running syncers, whether they are tracked by the trackMap or not. It waits
@@ -0,0 +1,233 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/db/memdb"
+)
+
+// recorder records every call of a routine function.
+type recorder struct {
+	lock  sync.Mutex
+	calls map[string][]time.Time
+	// failures specifies the number of calls that fail per key (-1 = all calls fail).
+	failures int
+}
+
+func (r *recorder) fn(ctx context.Context, key, value string, deleted bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.calls[key] = append(r.calls[key], time.Now())
+	if r.failures < 0 || len(r.calls[key]) <= r.failures {
+		return fmt.Errorf("attempt %d failed", len(r.calls[key]))
+	}
+	return nil
+}
+
+func (r *recorder) count(key string) int {
+	return len(r.times(key))
+}
+
+func (r *recorder) times(key string) []time.Time {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]time.Time{}, r.calls[key]...)
+}
+
+// flakyClient allows dropping the active watchers of the underlying client.
+type flakyClient struct {
+	*memdb.Client
+	lock    sync.Mutex
+	watches int
+	cancels []context.CancelFunc
+}
+
+func (c *flakyClient) WatchRange(ctx context.Context, prefix string, revision int64, event func(db.Event, error)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c.lock.Lock()
+	c.watches++
+	c.cancels = append(c.cancels, cancel)
+	c.lock.Unlock()
+	return c.Client.WatchRange(ctx, prefix, revision, event)
+}
+
+func (c *flakyClient) drop() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, cancel := range c.cancels {
+		cancel()
+	}
+	c.cancels = nil
+}
+
+func (c *flakyClient) watchCount() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.watches
+}
+
+func newTestSyncer(t *testing.T, client db.Client) *Syncer {
+	t.Helper()
+	syncer := New(slog.New(slog.NewTextHandler(io.Discard, nil)), client,
+		WithNodeId("node"), WithRetryBackoff(time.Millisecond*50, time.Second),
+	)
+	t.Cleanup(syncer.Shutdown)
+	return syncer
+}
+
+// waitFor polls the condition until it holds or fails the test after a timeout.
+func waitFor(t *testing.T, msg string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 5)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", msg)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+	}{
+		{name: "succeeds immediately", failures: 0},
+		{name: "recovers after one failure", failures: 1},
+		{name: "recovers after three failures", failures: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := memdb.New()
+			defer client.Terminate(ctx)
+			client.Set(ctx, "/p/a", "1", 0)
+
+			rec := &recorder{calls: map[string][]time.Time{}, failures: tt.failures}
+			// the periodic interval exceeds the test, every further call is a retry.
+			newTestSyncer(t, client).Add("/p/", 3600, rec.fn)
+
+			waitFor(t, "the key to be applied", func() bool { return rec.count("/p/a") > tt.failures })
+			time.Sleep(time.Millisecond * 200)
+			if got := rec.count("/p/a"); got != tt.failures+1 {
+				t.Fatalf("expected %d calls, got %d", tt.failures+1, got)
+			}
+
+			// the base delay is 50ms with a jitter of 20%, every retry must at least double it.
+			calls := rec.times("/p/a")
+			previous := time.Duration(0)
+			for i := 1; i < len(calls); i++ {
+				delay := calls[i].Sub(calls[i-1])
+				if delay < time.Millisecond*40 || delay <= previous {
+					t.Errorf("retry %d after %s, expected a growing delay (previous %s)", i, delay, previous)
+				}
+				previous = delay
+			}
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		delete   bool
+		want     func(*Status) bool
+	}{
+		{
+			name:     "failing key records the last error",
+			failures: -1,
+			want: func(s *Status) bool {
+				return s != nil && s.Error != "" && s.Attempts >= 2 && s.LastError > 0 && s.LastSuccess == 0
+			},
+		},
+		{
+			name:     "recovered key records the last success",
+			failures: 2,
+			want: func(s *Status) bool {
+				return s != nil && s.Error == "" && s.Attempts == 0 && s.LastError > 0 && s.LastSuccess > 0
+			},
+		},
+		{
+			name:     "deleted key removes the status",
+			failures: 0,
+			delete:   true,
+			want:     func(s *Status) bool { return s == nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := memdb.New()
+			defer client.Terminate(ctx)
+			client.Set(ctx, "/p/a", "1", 0)
+
+			rec := &recorder{calls: map[string][]time.Time{}, failures: tt.failures}
+			newTestSyncer(t, client).Add("/p/", 3600, rec.fn)
+
+			if tt.delete {
+				waitFor(t, "the status to be reported", func() bool {
+					status, _ := LookupStatus(ctx, client, "node", "/p/a")
+					return status != nil && status.LastSuccess > 0
+				})
+				client.Delete(ctx, "/p/a")
+			}
+			waitFor(t, "the expected status", func() bool {
+				status, err := LookupStatus(ctx, client, "node", "/p/a")
+				return err == nil && tt.want(status)
+			})
+		})
+	}
+}
+
+func TestWatchResync(t *testing.T) {
+	ctx := context.Background()
+	client := &flakyClient{Client: memdb.New()}
+	defer client.Terminate(ctx)
+	client.Set(ctx, "/p/a", "1", 0)
+
+	rec := &recorder{calls: map[string][]time.Time{}, failures: 0}
+	syncer := newTestSyncer(t, client)
+	syncer.Add("/p/", 3600, rec.fn)
+	waitFor(t, "the initial sync", func() bool { return rec.count("/p/a") == 1 && client.watchCount() == 1 })
+
+	client.drop()
+	client.Set(ctx, "/p/b", "1", 0)
+
+	// the periodic routine only runs once an hour, the unchanged key is only applied again by the resync.
+	waitFor(t, "the resync after the reconnect", func() bool { return rec.count("/p/a") == 2 })
+	waitFor(t, "the key written while the watcher was down", func() bool { return rec.count("/p/b") >= 1 })
+	if got := client.watchCount(); got != 2 {
+		t.Errorf("expected the watcher to reconnect once, got %d watches", got)
+	}
+	waitFor(t, "the watcher to be healthy", func() bool { return syncer.Health() == nil })
+}
@@ -0,0 +1,141 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/workqueue"
+)
+
+// watch runs the incremental watcher of the routine until the routine is stopped.
+// If the watcher fails, it is reestablished with exponential backoff and resumed from the last seen revision,
+// so that no event (especially no deletion) is lost. After every reconnect a full resync of the routine is
+// triggered to catch up with states that may have been missed while the watcher was down.
+// If resuming repeatedly fails without delivering any event (e.g. because the revision is compacted),
+// the watcher is restarted at the current revision; deletions in between are lost in this case.
+func (s *Syncer) watch(prefix string, r *routine) {
+	var (
+		revision int64 = 0
+		attempts int64 = 0
+	)
+	for {
+		startRevision := int64(0)
+		if revision > 0 {
+			startRevision = revision + 1
+		}
+
+		// the watcher is considered healthy if it did not fail within the first retry interval.
+		startTime := time.Now()
+		runningLock, running := sync.Mutex{}, true
+		healthTimer := time.AfterFunc(s.retryBase, func() {
+			runningLock.Lock()
+			defer runningLock.Unlock()
+			if running {
+				s.setWatchState(prefix, nil)
+			}
+		})
+
+		delivered := false
+		err := s.client.WatchRange(r.ctx, prefix, startRevision, func(event db.Event, err error) {
+			if err != nil {
+				s.logger.Error(fmt.Sprintf("failed to load key '%s': %s", prefix, err.Error()))
+				return
+			}
+			delivered, revision = true, event.Revision
+			r.enqueue(event.Key, event.Value, event.Type == db.EVENT_DELETE, false)
+		})
+
+		healthTimer.Stop()
+		runningLock.Lock()
+		running = false
+		runningLock.Unlock()
+
+		if r.ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("watch closed unexpectedly")
+		}
+		if time.Since(startTime) > s.retryBase {
+			attempts = 0
+		}
+		attempts++
+		s.setWatchState(prefix, fmt.Errorf("watcher of '%s' failed: %w", prefix, err))
+		s.logger.Error(fmt.Sprintf(
+			"failed to watch '%s' state: %s; reconnecting state watcher...", prefix, err.Error(),
+		), slog.Int64("attempts", attempts))
+
+		if !delivered && startRevision > 0 && attempts > 1 {
+			s.logger.Warn(fmt.Sprintf(
+				"cannot resume watcher of '%s' at revision %d; restarting at current revision...", prefix, startRevision,
+			))
+			revision = 0
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-time.After(workqueue.Backoff(s.retryBase, s.retryMax, attempts)):
+		}
+
+		select {
+		case r.resync <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// setWatchState updates the watcher state of the routine (nil = healthy).
+// Routines that were removed in the meantime are not recreated.
+func (s *Syncer) setWatchState(prefix string, err error) {
+	s.watchStateLock.Lock()
+	defer s.watchStateLock.Unlock()
+	if _, ok := s.watchState[prefix]; ok {
+		s.watchState[prefix] = err
+	}
+}
+
+// Health returns an error if the incremental watcher of any routine is currently not established.
+// Failing watchers are reestablished automatically, in the meantime states are only applied periodically.
+func (s *Syncer) Health() error {
+	s.watchStateLock.Lock()
+	defer s.watchStateLock.Unlock()
+
+	prefixes := make([]string, 0, len(s.watchState))
+	for prefix := range s.watchState {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	errs := []error{}
+	for _, prefix := range prefixes {
+		if err := s.watchState[prefix]; err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
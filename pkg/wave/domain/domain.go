@@ -21,42 +21,29 @@ package domain
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"strings"
 
 	"cthul.io/cthul/pkg/adapter/domain"
 	domainstruct "cthul.io/cthul/pkg/api/wave/v1/domain"
 	"cthul.io/cthul/pkg/db"
-	"golang.org/x/sync/errgroup"
-	"google.golang.org/protobuf/proto"
+	"cthul.io/cthul/pkg/informer"
+	"cthul.io/cthul/pkg/keyspace"
+	"cthul.io/cthul/pkg/resource"
 )
 
-// NodeMismatchErr indicates that the action cannot be executed on this node.
-type NodeMismatchErr struct {
-	Node    string
-	Message string
-}
-
-func (n *NodeMismatchErr) Error() string {
-	return n.Message
-}
-
 // Controller provides an interface for wave domain related operations.
+// The generic resource operations (Create, Apply, Attach, Detach, Delete) are provided by the resource controller.
 type Controller struct {
-	node    string
-	runRoot string
-	client  db.Client
-	adapter domain.Adapter
+	*resource.Controller[*domainstruct.DomainConfig]
+	informer *informer.Informer
+	runRoot  string
+	adapter  domain.Adapter
 }
 
 type Option func(*Controller)
 
 func New(node string, client db.Client, adapter domain.Adapter, opts ...Option) *Controller {
 	controller := &Controller{
-		node:    node,
 		runRoot: "/run/cthul/wave/",
-		client:  client,
 		adapter: adapter,
 	}
 
@@ -64,9 +51,21 @@ func New(node string, client db.Client, adapter domain.Adapter, opts ...Option)
 		opt(controller)
 	}
 
+	controller.Controller = resource.New[*domainstruct.DomainConfig](node, "domain", keyspace.WaveDomain, client,
+		resource.WithInformer[*domainstruct.DomainConfig](controller.informer),
+	)
+
 	return controller
 }
 
+// WithInformer defines an informer that caches the domain state (see resource.NewInformer()).
+// Reads are served from the cache once it is synced, which avoids querying the database on every call.
+func WithInformer(informer *informer.Informer) Option {
+	return func(c *Controller) {
+		c.informer = informer
+	}
+}
+
 // WithRunRoot defines a custom root for runtime files (bsd sockets etc.).
 // The controller needs this information to understand where to find those files (usually created by operators).
 func WithRunRoot(path string) Option {
@@ -77,69 +76,24 @@ func WithRunRoot(path string) Option {
 
 // List returns a map containing domain ids and associated metadata from the database.
 func (c *Controller) List(ctx context.Context) (map[string]*domainstruct.Domain, error) {
-	domains := map[string]*domainstruct.Domain{}
-
-	reqnodes, err := c.client.GetRange(ctx, "/WAVE/DOMAIN/REQNODE/")
-	if err != nil {
-		return nil, fmt.Errorf("fetching domain reqnode: %w", err)
-	}
-	nodes, err := c.client.GetRange(ctx, "/WAVE/DOMAIN/NODE/")
-	if err != nil {
-		return nil, fmt.Errorf("fetching domain node: %w", err)
-	}
-	configs, err := c.client.GetRange(ctx, "/WAVE/DOMAIN/CONFIG/")
+	entries, err := c.Controller.List(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("fetching domain configs: %w", err)
+		return nil, err
 	}
 
-	for key, rawConfig := range configs {
-		var domainErr error
-		id := strings.TrimPrefix(key, "/WAVE/DOMAIN/CONFIG/")
-		reqnode := reqnodes[fmt.Sprint("/WAVE/DOMAIN/REQNODE/", id)]
-		node := nodes[fmt.Sprint("/WAVE/DOMAIN/NODE/", id)]
-
-		config := &domainstruct.DomainConfig{}
-		err = proto.Unmarshal([]byte(rawConfig), config)
-		if err != nil {
-			domainErr = errors.Join(domainErr, fmt.Errorf("parsing domain config: %w", err))
-		}
-
-    domain := &domainstruct.Domain{
-			Reqnode:         reqnode,
-			Node:            node,
-			Config:          config,
-		}
-    if domainErr != nil {
-      domain.Error = domainErr.Error()
-    }
-		domains[id] = domain
+	domains := map[string]*domainstruct.Domain{}
+	for id, entry := range entries {
+		domains[id] = newDomain(entry)
 	}
 	return domains, nil
 }
 
-// Apply upserts the domain configuration.
-func (c *Controller) Apply(ctx context.Context, id string, config *domainstruct.DomainConfig) error {
-	rawConfig, err := proto.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("cannot serialize config: %w", err)
-	}
-
-	_, err = c.client.Set(ctx, fmt.Sprintf("/WAVE/DOMAIN/CONFIG/%s", id), string(rawConfig), 0)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 // Stat returns the current statistics of the domain. The data is read directly from the vmm (e.g. qemu).
 func (c *Controller) Stat(ctx context.Context, id string) (*domainstruct.DomainStats, error) {
-	node, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/DOMAIN/NODE/%s", id))
+	err := c.CheckLocal(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	if node != c.node {
-		return nil, &NodeMismatchErr{Message: "domain must be on the same node as the controller", Node: c.node}
-	}
 	stats, err := c.adapter.GetStats(ctx, id)
 	if err != nil {
 		return nil, err
@@ -149,118 +103,45 @@ func (c *Controller) Stat(ctx context.Context, id string) (*domainstruct.DomainS
 
 // Lookup searches for the domain by id and returns its configuration.
 func (c *Controller) Lookup(ctx context.Context, id string) (*domainstruct.Domain, error) {
-	reqnode, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/DOMAIN/REQNODE/%s", id))
-	if err != nil {
-		return nil, fmt.Errorf("fetching domain reqnode: %w", err)
-	}
-	node, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/DOMAIN/NODE/%s", id))
-	if err != nil {
-		return nil, fmt.Errorf("fetching domain node: %w", err)
-	}
-	rawConfig, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/DOMAIN/CONFIG/%s", id))
-	if err != nil {
-		return nil, fmt.Errorf("fetching domain configs: %w", err)
-	}
-
-  if rawConfig == "" {
-    return nil, fmt.Errorf("domain not found")
-  }
-
-	config := &domainstruct.DomainConfig{}
-	err = proto.Unmarshal([]byte(rawConfig), config)
+	entry, err := c.Controller.Lookup(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("parsing domain config: %w", err)
+		return nil, err
 	}
-
-	return &domainstruct.Domain{
-		Reqnode: reqnode,
-		Node:    node,
-		Config:  config,
-		Error:   "",
-	}, nil
+	return newDomain(entry), nil
 }
 
-// Attach requests the domain to be relocated to the specified node and waits until it's ready (if wait flag is set).
-func (c *Controller) Attach(ctx context.Context, id, node string, wait bool) error {
-	if !wait {
-		_, err := c.client.Set(ctx, fmt.Sprintf("/WAVE/DOMAIN/REQNODE/%s", id), node, 0)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-
-	pollCtx, pollCtxCancel := context.WithCancel(ctx)
-	pollG, pollGCtx := errgroup.WithContext(pollCtx)
-
-	pollG.Go(func() error {
-		err := c.client.Watch(pollGCtx, fmt.Sprintf("/WAVE/DOMAIN/NODE/%s", id), func(_, activeNode string, err error) {
-			if err == nil && node == activeNode {
-				pollCtxCancel()
-			}
-		})
-		if err != nil {
-			return err
-		}
-		return nil
-	})
-
-	// initial check, required in case the node is already set to the requested node (watch will not trigger in this case)
-	pollG.Go(func() error {
-		activeNode, err := c.client.Get(pollGCtx, fmt.Sprintf("/WAVE/DOMAIN/NODE/%s", id))
-		if err != nil {
-			return err
-		}
-		if node == activeNode {
-			pollCtxCancel()
-		}
-		return nil
-	})
-
-	_, err := c.client.Set(ctx, fmt.Sprintf("/WAVE/DOMAIN/REQNODE/%s", id), node, 0)
-	if err != nil {
-		return err
-	}
-
-	err = pollG.Wait()
+// Status returns the synchronization status of the domain reported by the nodes applying it
+// (see resource.Controller.SyncStatus()).
+func (c *Controller) Status(ctx context.Context, id string) ([]*domainstruct.SyncStatus, error) {
+	entries, err := c.SyncStatus(ctx, id)
 	if err != nil {
-		return err
-	}
-
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("context exceeded: domain couldn't be attached in the provided context window")
-	default:
-		return nil
+		return nil, err
 	}
-}
 
-// Detach removes the domain from the current node. It doesn't wait until the node fully detached it.
-func (c *Controller) Detach(ctx context.Context, id string) error {
-	err := c.client.Delete(ctx, fmt.Sprintf("/WAVE/DOMAIN/NODE/%s", id))
-	if err != nil {
-		return err
-	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/WAVE/DOMAIN/REQNODE/%s", id))
-	if err != nil {
-		return nil
+	statuses := []*domainstruct.SyncStatus{}
+	for _, entry := range entries {
+		statuses = append(statuses, &domainstruct.SyncStatus{
+			Node:        entry.Node,
+			Key:         entry.Key,
+			Error:       entry.Error,
+			Attempts:    entry.Attempts,
+			LastError:   entry.LastError,
+			LastSuccess: entry.LastSuccess,
+		})
 	}
-	return nil
+	return statuses, nil
 }
 
-// Delete completely removes a domain and its associated metadata.
-func (c *Controller) Delete(ctx context.Context, id string) error {
-	err := c.client.Delete(ctx, fmt.Sprintf("/WAVE/DOMAIN/NODE/%s", id))
-	if err != nil {
-		return err
-	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/WAVE/DOMAIN/REQNODE/%s", id))
-	if err != nil {
-		return err
+// newDomain converts the generic resource to the domain api structure.
+func newDomain(entry *resource.Resource[*domainstruct.DomainConfig]) *domainstruct.Domain {
+	domain := &domainstruct.Domain{
+		Reqnode: entry.Reqnode,
+		Node:    entry.Node,
+		Config:  entry.Config,
+		Error:   "",
 	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/WAVE/DOMAIN/CONFIG/%s", id))
-	if err != nil {
-		return err
+	if entry.Error != nil {
+		domain.Error = entry.Error.Error()
 	}
-	return nil
+	return domain
 }
@@ -23,35 +23,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
 	"cthul.io/cthul/pkg/api/wave/v1/node"
 	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/informer"
+	"cthul.io/cthul/pkg/keyspace"
 	"google.golang.org/protobuf/proto"
 )
 
-// NodeMismatchErr indicates that the action cannot be executed on this node.
-type NodeMismatchErr struct {
-	Node    string
-	Message string
-}
-
-func (n *NodeMismatchErr) Error() string {
-	return n.Message
-}
-
 // Controller provides an interface for wave node related operations.
 type Controller struct {
 	node   string
 	client db.Client
+	// informer caches the node registrations, reads are served from it once it is synced (nil = no cache).
+	informer *informer.Informer
 }
 
 type Option func(*Controller)
 
 func New(node string, client db.Client, opts ...Option) *Controller {
 	controller := &Controller{
-		node:   node,
-		client: client,
+		node:     node,
+		client:   client,
+		informer: nil,
 	}
 
 	for _, opt := range opts {
@@ -61,18 +55,35 @@ func New(node string, client db.Client, opts ...Option) *Controller {
 	return controller
 }
 
+// WithInformer defines an informer that caches the node registrations (created on keyspace.WaveNode.Prefix()).
+// Reads are served from the cache once it is synced, which avoids querying the database on every call.
+func WithInformer(informer *informer.Informer) Option {
+	return func(c *Controller) {
+		c.informer = informer
+	}
+}
+
 // List returns a map containing node uuids and associated metadata from the database.
 func (n *Controller) List(ctx context.Context) (map[string]*node.Node, error) {
 	nodes := map[string]*node.Node{}
 
-	configs, err := n.client.GetRange(ctx, "/WAVE/NODE/CONFIG/")
-	if err != nil {
-		return nil, fmt.Errorf("fetching node config: %w", err)
+	var configs map[string]string
+	if n.informer != nil && n.informer.Synced() {
+		configs = n.informer.List()
+	} else {
+		var err error
+		configs, err = n.client.GetRange(ctx, keyspace.WaveNode.Config.Prefix())
+		if err != nil {
+			return nil, fmt.Errorf("fetching node config: %w", err)
+		}
 	}
 
 	for key, rawConfig := range configs {
 		var nodeErr error
-		id := strings.TrimPrefix(key, "/WAVE/NODE/CONFIG/")
+		id, ok := keyspace.WaveNode.Config.Parse(key)
+		if !ok {
+			continue
+		}
 
 		config := &node.NodeConfig{}
 		err := proto.Unmarshal([]byte(rawConfig), config)
@@ -93,9 +104,15 @@ func (n *Controller) List(ctx context.Context) (map[string]*node.Node, error) {
 
 // Lookup finds the specified node and returns its associated metadata from the database.
 func (n *Controller) Lookup(ctx context.Context, id string) (*node.Node, error) {
-	rawConfig, err := n.client.Get(ctx, fmt.Sprintf("/WAVE/NODE/CONFIG/%s", id))
-	if err != nil {
-		return nil, fmt.Errorf("fetching node config: %w", err)
+	var rawConfig string
+	if n.informer != nil && n.informer.Synced() {
+		rawConfig, _ = n.informer.Get(keyspace.WaveNode.Config.Key(id))
+	} else {
+		var err error
+		rawConfig, err = n.client.Get(ctx, keyspace.WaveNode.Config.Key(id))
+		if err != nil {
+			return nil, fmt.Errorf("fetching node config: %w", err)
+		}
 	}
 
   if rawConfig == "" {
@@ -103,7 +120,7 @@ func (n *Controller) Lookup(ctx context.Context, id string) (*node.Node, error)
   }
 
 	config := &node.NodeConfig{}
-	err = proto.Unmarshal([]byte(rawConfig), config)
+	err := proto.Unmarshal([]byte(rawConfig), config)
 	if err != nil {
     return nil, fmt.Errorf("failed to parse node config %w", err)
 	}
@@ -114,13 +131,14 @@ func (n *Controller) Lookup(ctx context.Context, id string) (*node.Node, error)
 }
 
 // Register registers / announces node information to the cluster by adding it to the database.
-// The registration expires after ttl and must be renewed in order to ensure the node is part of the cluster.
-func (n *Controller) Register(ctx context.Context, id string, node *node.Node, ttl int64) error {
+// The registration is attached to the provided lease, it is removed as soon as the lease expires or is revoked.
+// The lease must therefore be kept alive in order to ensure the node is part of the cluster.
+func (n *Controller) Register(ctx context.Context, id string, node *node.Node, lease int64) error {
   rawConfig, err := proto.Marshal(node.Config)
   if err!=nil {
     return fmt.Errorf("failed to serialize config: %w", err)
   }
-	_, err = n.client.Set(ctx, fmt.Sprintf("/WAVE/NODE/CONFIG/%s", id), string(rawConfig), ttl)
+	_, err = n.client.SetLease(ctx, keyspace.WaveNode.Config.Key(id), string(rawConfig), lease)
 	if err != nil {
 		return err
 	}
@@ -130,7 +148,7 @@ func (n *Controller) Register(ctx context.Context, id string, node *node.Node, t
 
 // Unregister removes an existing node registration entry.
 func (n *Controller) Unregister(ctx context.Context, id string) error {
-	err := n.client.Delete(ctx, fmt.Sprintf("/WAVE/NODE/CONFIG/%s", id))
+	err := n.client.Delete(ctx, keyspace.WaveNode.Config.Key(id))
 	if err != nil {
 		return err
 	}
@@ -21,53 +21,54 @@ package serial
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"cthul.io/cthul/pkg/api/wave/v1/serial"
 	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/informer"
+	"cthul.io/cthul/pkg/keyspace"
+	"cthul.io/cthul/pkg/resource"
 	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
-	"google.golang.org/protobuf/proto"
 )
 
-// NodeMismatchErr indicates that the action cannot be executed on this node.
-type NodeMismatchErr struct {
-  Node string
-  Message string
-}
-
-func (n *NodeMismatchErr) Error() string {
-  return n.Message
-}
-
 // Controller provides an interface for wave serial device operations.
+// The generic resource operations (Create, Apply, Attach, Detach, Delete) are provided by the resource controller.
 type Controller struct {
-  node string
+	*resource.Controller[*serial.SerialConfig]
+  informer *informer.Informer
   runRoot string
-	client db.Client
 }
 
 type Option func(*Controller)
 
 func New(node string, client db.Client, opts ...Option) *Controller {
 	controller := &Controller{
-    node: node,
     runRoot: "/run/cthul/wave/",
-		client: client,
 	}
 
 	for _, opt := range opts {
 		opt(controller)
 	}
 
+	controller.Controller = resource.New[*serial.SerialConfig](node, "device", keyspace.WaveSerial, client,
+		resource.WithInformer[*serial.SerialConfig](controller.informer),
+	)
+
 	return controller
 }
 
+// WithInformer defines an informer that caches the serial device state (see resource.NewInformer()).
+// Reads are served from the cache once it is synced, which avoids querying the database on every call.
+func WithInformer(informer *informer.Informer) Option {
+	return func(c *Controller) {
+		c.informer = informer
+	}
+}
+
 // WithRunRoot defines a custom root for runtime files (bsd sockets etc.).
 // The controller needs this information to understand where to find those files (usually created by operators).
 func WithRunRoot(path string) Option {
@@ -78,65 +79,70 @@ func WithRunRoot(path string) Option {
 
 // List returns a map containing serial device uuids and associated metadata from the database.
 func (c *Controller) List(ctx context.Context) (map[string]*serial.Serial, error) {
-	serials := map[string]*serial.Serial{}
-
-	reqnodes, err := c.client.GetRange(ctx, "/WAVE/SERIAL/REQNODE/")
+	devices, err := c.Controller.List(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("fetching serial device reqnode: %w", err)
+		return nil, err
 	}
-	nodes, err := c.client.GetRange(ctx, "/WAVE/SERIAL/NODE/")
-	if err != nil {
-		return nil, fmt.Errorf("fetching serial device node: %w", err)
+
+	serials := map[string]*serial.Serial{}
+	for id, device := range devices {
+		serials[id] = newSerial(device)
 	}
-	configs, err := c.client.GetRange(ctx, "/WAVE/SERIAL/CONFIG/")
+	return serials, nil
+}
+
+// Lookup searches for the device by id and returns its configuration.
+func (c *Controller) Lookup(ctx context.Context, id string) (*serial.Serial, error) {
+	device, err := c.Controller.Lookup(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("fetching serial device config: %w", err)
+		return nil, err
 	}
+	return newSerial(device), nil
+}
 
-	for key, rawConfig := range configs {
-		var serialErr error
-		id := strings.TrimPrefix(key, "/WAVE/SERIAL/CONFIG/")
-		reqnode := reqnodes[fmt.Sprint("/WAVE/SERIAL/REQNODE/", id)]
-		node := nodes[fmt.Sprint("/WAVE/SERIAL/NODE/", id)]
-
-		config := &serial.SerialConfig{}
-		err = proto.Unmarshal([]byte(rawConfig), config)
-		if err != nil {
-			serialErr = errors.Join(serialErr, fmt.Errorf("parsing device config: %w", err))
-		}
+// Status returns the synchronization status of the device reported by the nodes applying it
+// (see resource.Controller.SyncStatus()).
+func (c *Controller) Status(ctx context.Context, id string) ([]*serial.SyncStatus, error) {
+	entries, err := c.SyncStatus(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []*serial.SyncStatus{}
+	for _, entry := range entries {
+		statuses = append(statuses, &serial.SyncStatus{
+			Node:        entry.Node,
+			Key:         entry.Key,
+			Error:       entry.Error,
+			Attempts:    entry.Attempts,
+			LastError:   entry.LastError,
+			LastSuccess: entry.LastSuccess,
+		})
+	}
+	return statuses, nil
+}
 
-    serial := &serial.Serial{
-			Reqnode:         reqnode,
-			Node:            node,
-			Config:          config,
-		}
-    if serialErr != nil {
-      serial.Error = serialErr.Error()
-    }
-		serials[id] = serial
+// newSerial converts the generic resource to the serial api structure.
+func newSerial(device *resource.Resource[*serial.SerialConfig]) *serial.Serial {
+	serial := &serial.Serial{
+		Reqnode: device.Reqnode,
+		Node:    device.Node,
+		Config:  device.Config,
+		Error:   "",
 	}
-	return serials, nil
+	if device.Error != nil {
+		serial.Error = device.Error.Error()
+	}
+	return serial
 }
 
 // Connect creates a bidirectional communication bridge to the serial device socket.
 // Input and output is not manipulated, the format depends on the device type. Runs until the context is cancelled.
 func (c *Controller) Connect(ctx context.Context, id string, reader chan<-[]byte, writer <-chan []byte) error {
-  node, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/SERIAL/NODE/%s", id))
-  if err!=nil {
-    return err
-  }
-  if node != c.node {
-    return &NodeMismatchErr{Message: "device must be on the same node as the controller", Node: c.node}
-  }
-  rawConfig, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/SERIAL/CONFIG/%s", id))
-  if err!=nil {
-    return err
-  }
-  config := &serial.SerialConfig{}
-  err = proto.Unmarshal([]byte(rawConfig), config)
-  if err!=nil {
-    return fmt.Errorf("parsing device config: %w", err)
-  }
+	err := c.CheckLocal(ctx, id)
+	if err!=nil {
+		return err
+	}
 
 	// ensure no path escape is possible if every barrier breaks.
 	if _, err := uuid.Parse(id); err!=nil {
@@ -198,134 +204,3 @@ func (c *Controller) Connect(ctx context.Context, id string, reader chan<-[]byte
 
   return loopG.Wait()
 }
-
-// Lookup searches for the device by id and returns its configuration.
-func (c *Controller) Lookup(ctx context.Context, id string) (*serial.Serial, error) {
-	reqnode, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/SERIAL/REQNODE/%s", id))
-	if err != nil {
-		return nil, fmt.Errorf("fetching serial device reqnode: %w", err)
-	}
-	node, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/SERIAL/NODE/%s", id))
-	if err != nil {
-		return nil, fmt.Errorf("fetching serial device node: %w", err)
-	}
-	rawConfig, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/SERIAL/CONFIG/%s", id))
-	if err != nil {
-		return nil, fmt.Errorf("fetching serial device config: %w", err)
-	}
-
-	if rawConfig == "" {
-		return nil, fmt.Errorf("device not found")
-	}
-
-  config := &serial.SerialConfig{}
-  err = proto.Unmarshal([]byte(rawConfig), config)
-  if err!=nil {
-    return nil, fmt.Errorf("parsing device config: %w", err)
-  }
-
-	return &serial.Serial{
-		Reqnode: reqnode,
-		Node:    node,
-    Config: config,
-    Error: "",
-	}, nil
-}
-
-// Apply upserts the serial device configuration.
-func (c *Controller) Apply(ctx context.Context, id string, config *serial.SerialConfig) error {
-	rawConfig, err := proto.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("cannot serialize config: %w", err)
-	}
-
-	_, err = c.client.Set(ctx, fmt.Sprintf("/WAVE/SERIAL/CONFIG/%s", id), string(rawConfig), 0)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-// Attach requests the device to be relocated to the specified node and waits until it's ready (if wait flag is set).
-func (c *Controller) Attach(ctx context.Context, id, node string, wait bool) error {
-	if !wait {
-		_, err := c.client.Set(ctx, fmt.Sprintf("/WAVE/SERIAL/REQNODE/%s", id), node, 0)
-		if err != nil {
-			return err
-		}
-	}
-
-	pollCtx, pollCtxCancel := context.WithCancel(ctx)
-	pollG, pollGCtx := errgroup.WithContext(pollCtx)
-
-	pollG.Go(func() error {
-		err := c.client.Watch(pollGCtx, fmt.Sprintf("/WAVE/SERIAL/NODE/%s", id), func(_, activeNode string, err error) {
-			if err == nil && node == activeNode {
-				pollCtxCancel()
-			}
-		})
-		if err != nil {
-			return err
-		}
-		return nil
-	})
-
-	// initial check, required in case the node is already set to the requested node (watch will not trigger in this case)
-	pollG.Go(func() error {
-		activeNode, err := c.client.Get(pollGCtx, fmt.Sprintf("/WAVE/SERIAL/NODE/%s", id))
-		if err != nil {
-			return err
-		}
-		if node == activeNode {
-			pollCtxCancel()
-		}
-		return nil
-	})
-
-	_, err := c.client.Set(ctx, fmt.Sprintf("/WAVE/SERIAL/REQNODE/%s", id), node, 0)
-	if err != nil {
-		return err
-	}
-
-	err = pollG.Wait()
-	if err != nil {
-		return err
-	}
-
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("context exceeded: device couldn't be attached in the provided context window")
-	default:
-		return nil
-	}
-}
-
-// Detach removes the device from the current node. It doesn't wait until the node fully detached it.
-func (c *Controller) Detach(ctx context.Context, id string) error {
-	err := c.client.Delete(ctx, fmt.Sprintf("/WAVE/SERIAL/NODE/%s", id))
-	if err != nil {
-		return err
-	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/WAVE/SERIAL/REQNODE/%s", id))
-	if err != nil {
-		return nil
-	}
-	return nil
-}
-
-// Delete completely removes a serial device and its associated metadata.
-func (c *Controller) Delete(ctx context.Context, id string) error {
-	err := c.client.Delete(ctx, fmt.Sprintf("/WAVE/SERIAL/NODE/%s", id))
-	if err != nil {
-		return err
-	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/WAVE/SERIAL/REQNODE/%s", id))
-	if err != nil {
-		return err
-	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/WAVE/SERIAL/CONFIG/%s", id))
-	if err != nil {
-		return err
-	}
-	return nil
-}
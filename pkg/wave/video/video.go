@@ -21,53 +21,54 @@ package video
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"cthul.io/cthul/pkg/api/wave/v1/video"
 	"cthul.io/cthul/pkg/db"
+	"cthul.io/cthul/pkg/informer"
+	"cthul.io/cthul/pkg/keyspace"
+	"cthul.io/cthul/pkg/resource"
 	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
-	"google.golang.org/protobuf/proto"
 )
 
-// NodeMismatchErr indicates that the action cannot be executed on this node.
-type NodeMismatchErr struct {
-  Node string
-  Message string
-}
-
-func (n *NodeMismatchErr) Error() string {
-  return n.Message
-}
-
 // Controller provides an interface for wave video device operations.
+// The generic resource operations (Create, Apply, Attach, Detach, Delete) are provided by the resource controller.
 type Controller struct {
-  node string
+	*resource.Controller[*video.VideoConfig]
+  informer *informer.Informer
   runRoot string
-	client db.Client
 }
 
 type Option func(*Controller)
 
 func New(node string, client db.Client, opts ...Option) *Controller {
 	controller := &Controller{
-    node: node,
     runRoot: "/run/cthul/wave/",
-		client: client,
 	}
 
 	for _, opt := range opts {
 		opt(controller)
 	}
 
+	controller.Controller = resource.New[*video.VideoConfig](node, "device", keyspace.WaveVideo, client,
+		resource.WithInformer[*video.VideoConfig](controller.informer),
+	)
+
 	return controller
 }
 
+// WithInformer defines an informer that caches the video device state (see resource.NewInformer()).
+// Reads are served from the cache once it is synced, which avoids querying the database on every call.
+func WithInformer(informer *informer.Informer) Option {
+	return func(c *Controller) {
+		c.informer = informer
+	}
+}
+
 // WithRunRoot defines a custom root for runtime files (bsd sockets etc.).
 // The controller needs this information to understand where to find those files (usually created by operators).
 func WithRunRoot(path string) Option {
@@ -78,65 +79,70 @@ func WithRunRoot(path string) Option {
 
 // List returns a map containing video device uuids and associated metadata from the database.
 func (c *Controller) List(ctx context.Context) (map[string]*video.Video, error) {
-	videos := map[string]*video.Video{}
-
-	reqnodes, err := c.client.GetRange(ctx, "/WAVE/VIDEO/REQNODE/")
+	devices, err := c.Controller.List(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("fetching video device reqnode: %w", err)
+		return nil, err
 	}
-	nodes, err := c.client.GetRange(ctx, "/WAVE/VIDEO/NODE/")
-	if err != nil {
-		return nil, fmt.Errorf("fetching video device node: %w", err)
+
+	videos := map[string]*video.Video{}
+	for id, device := range devices {
+		videos[id] = newVideo(device)
 	}
-	configs, err := c.client.GetRange(ctx, "/WAVE/VIDEO/CONFIG/")
+	return videos, nil
+}
+
+// Lookup searches for the device by id and returns its configuration.
+func (c *Controller) Lookup(ctx context.Context, id string) (*video.Video, error) {
+	device, err := c.Controller.Lookup(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("fetching video device config: %w", err)
+		return nil, err
 	}
+	return newVideo(device), nil
+}
 
-	for key, rawConfig := range configs {
-		var videoErr error
-		id := strings.TrimPrefix(key, "/WAVE/VIDEO/CONFIG/")
-		reqnode := reqnodes[fmt.Sprint("/WAVE/VIDEO/REQNODE/", id)]
-		node := nodes[fmt.Sprint("/WAVE/VIDEO/NODE/", id)]
-
-		config := &video.VideoConfig{}
-		err = proto.Unmarshal([]byte(rawConfig), config)
-		if err != nil {
-			videoErr = errors.Join(videoErr, fmt.Errorf("parsing device config: %w", err))
-		}
+// Status returns the synchronization status of the device reported by the nodes applying it
+// (see resource.Controller.SyncStatus()).
+func (c *Controller) Status(ctx context.Context, id string) ([]*video.SyncStatus, error) {
+	entries, err := c.SyncStatus(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []*video.SyncStatus{}
+	for _, entry := range entries {
+		statuses = append(statuses, &video.SyncStatus{
+			Node:        entry.Node,
+			Key:         entry.Key,
+			Error:       entry.Error,
+			Attempts:    entry.Attempts,
+			LastError:   entry.LastError,
+			LastSuccess: entry.LastSuccess,
+		})
+	}
+	return statuses, nil
+}
 
-    video := &video.Video{
-			Reqnode:         reqnode,
-			Node:            node,
-			Config:          config,
-		}
-    if videoErr != nil {
-      video.Error = videoErr.Error()
-    }
-		videos[id] = video
+// newVideo converts the generic resource to the video api structure.
+func newVideo(device *resource.Resource[*video.VideoConfig]) *video.Video {
+	video := &video.Video{
+		Reqnode: device.Reqnode,
+		Node:    device.Node,
+		Config:  device.Config,
+		Error:   "",
 	}
-	return videos, nil
+	if device.Error != nil {
+		video.Error = device.Error.Error()
+	}
+	return video
 }
 
 // Connect creates a bidirectional communication bridge to the video device socket.
 // Input and output is not manipulated, the format depends on the device type. Runs until the context is cancelled.
 func (c *Controller) Connect(ctx context.Context, id string, reader chan<-[]byte, writer <-chan []byte) error {
-  node, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/VIDEO/NODE/%s", id))
-  if err!=nil {
-    return err
-  }
-  if node != c.node {
-    return &NodeMismatchErr{Message: "device must be on the same node as the controller", Node: c.node}
-  }
-  rawConfig, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/VIDEO/CONFIG/%s", id))
-  if err!=nil {
-    return err
-  }
-  config := &video.VideoConfig{}
-  err = proto.Unmarshal([]byte(rawConfig), config)
-  if err!=nil {
-    return fmt.Errorf("parsing device config: %w", err)
-  }
+	err := c.CheckLocal(ctx, id)
+	if err!=nil {
+		return err
+	}
 
 	// ensure no path escape is possible if every barrier breaks.
 	if _, err := uuid.Parse(id); err!=nil {
@@ -198,134 +204,3 @@ func (c *Controller) Connect(ctx context.Context, id string, reader chan<-[]byte
 
   return loopG.Wait()
 }
-
-// Lookup searches for the device by id and returns its configuration.
-func (c *Controller) Lookup(ctx context.Context, id string) (*video.Video, error) {
-	reqnode, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/VIDEO/REQNODE/%s", id))
-	if err != nil {
-		return nil, fmt.Errorf("fetching video device reqnode: %w", err)
-	}
-	node, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/VIDEO/NODE/%s", id))
-	if err != nil {
-		return nil, fmt.Errorf("fetching video device node: %w", err)
-	}
-	rawConfig, err := c.client.Get(ctx, fmt.Sprintf("/WAVE/VIDEO/CONFIG/%s", id))
-	if err != nil {
-		return nil, fmt.Errorf("fetching video device config: %w", err)
-	}
-
-	if rawConfig == "" {
-		return nil, fmt.Errorf("device not found")
-	}
-
-  config := &video.VideoConfig{}
-  err = proto.Unmarshal([]byte(rawConfig), config)
-  if err!=nil {
-    return nil, fmt.Errorf("parsing device config: %w", err)
-  }
-
-	return &video.Video{
-		Reqnode: reqnode,
-		Node:    node,
-    Config: config,
-    Error: "",
-	}, nil
-}
-
-// Apply upserts the video device configuration.
-func (c *Controller) Apply(ctx context.Context, id string, config *video.VideoConfig) error {
-	rawConfig, err := proto.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("cannot serialize config: %w", err)
-	}
-
-	_, err = c.client.Set(ctx, fmt.Sprintf("/WAVE/VIDEO/CONFIG/%s", id), string(rawConfig), 0)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-// Attach requests the device to be relocated to the specified node and waits until it's ready (if wait flag is set).
-func (c *Controller) Attach(ctx context.Context, id, node string, wait bool) error {
-	if !wait {
-		_, err := c.client.Set(ctx, fmt.Sprintf("/WAVE/VIDEO/REQNODE/%s", id), node, 0)
-		if err != nil {
-			return err
-		}
-	}
-
-	pollCtx, pollCtxCancel := context.WithCancel(ctx)
-	pollG, pollGCtx := errgroup.WithContext(pollCtx)
-
-	pollG.Go(func() error {
-		err := c.client.Watch(pollGCtx, fmt.Sprintf("/WAVE/VIDEO/NODE/%s", id), func(_, activeNode string, err error) {
-			if err == nil && node == activeNode {
-				pollCtxCancel()
-			}
-		})
-		if err != nil {
-			return err
-		}
-		return nil
-	})
-
-	// initial check, required in case the node is already set to the requested node (watch will not trigger in this case)
-	pollG.Go(func() error {
-		activeNode, err := c.client.Get(pollGCtx, fmt.Sprintf("/WAVE/VIDEO/NODE/%s", id))
-		if err != nil {
-			return err
-		}
-		if node == activeNode {
-			pollCtxCancel()
-		}
-		return nil
-	})
-
-	_, err := c.client.Set(ctx, fmt.Sprintf("/WAVE/VIDEO/REQNODE/%s", id), node, 0)
-	if err != nil {
-		return err
-	}
-
-	err = pollG.Wait()
-	if err != nil {
-		return err
-	}
-
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("context exceeded: device couldn't be attached in the provided context window")
-	default:
-		return nil
-	}
-}
-
-// Detach removes the device from the current node. It doesn't wait until the node fully detached it.
-func (c *Controller) Detach(ctx context.Context, id string) error {
-	err := c.client.Delete(ctx, fmt.Sprintf("/WAVE/VIDEO/NODE/%s", id))
-	if err != nil {
-		return err
-	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/WAVE/VIDEO/REQNODE/%s", id))
-	if err != nil {
-		return nil
-	}
-	return nil
-}
-
-// Delete completely removes a video device and its associated metadata.
-func (c *Controller) Delete(ctx context.Context, id string) error {
-	err := c.client.Delete(ctx, fmt.Sprintf("/WAVE/VIDEO/NODE/%s", id))
-	if err != nil {
-		return err
-	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/WAVE/VIDEO/REQNODE/%s", id))
-	if err != nil {
-		return err
-	}
-	err = c.client.Delete(ctx, fmt.Sprintf("/WAVE/VIDEO/CONFIG/%s", id))
-	if err != nil {
-		return err
-	}
-	return nil
-}
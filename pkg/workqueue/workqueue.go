@@ -0,0 +1,222 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// workqueue provides a keyed work queue that decouples event sources from the reconcile function.
+// Keys added multiple times while waiting are only processed once and a key is never processed by two workers
+// at the same time, if a key is added while processing it is queued again once the worker is done.
+package workqueue
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Queue is a keyed fifo queue with deduplication and per key rate limited requeues.
+type Queue struct {
+	lock sync.Mutex
+	cond *sync.Cond
+
+	// queue holds the keys in the order they are processed.
+	queue []string
+	// dirty holds all keys that must be processed (queued keys and keys added while processing).
+	dirty map[string]struct{}
+	// processing holds all keys that are currently processed by a worker.
+	processing map[string]struct{}
+	// failures holds the number of consecutive rate limited requeues per key.
+	failures map[string]int64
+	// timers holds the pending delayed adds, they are stopped on shutdown.
+	timers   map[*time.Timer]struct{}
+	shutdown bool
+
+	// backoffBase specifies the delay of the first rate limited requeue, it is doubled on every requeue.
+	backoffBase time.Duration
+	// backoffMax specifies the maximum delay of a rate limited requeue.
+	backoffMax time.Duration
+}
+
+type Option func(*Queue)
+
+func New(opts ...Option) *Queue {
+	queue := &Queue{
+		lock:        sync.Mutex{},
+		queue:       []string{},
+		dirty:       map[string]struct{}{},
+		processing:  map[string]struct{}{},
+		failures:    map[string]int64{},
+		timers:      map[*time.Timer]struct{}{},
+		shutdown:    false,
+		backoffBase: time.Second,
+		backoffMax:  time.Minute,
+	}
+	queue.cond = sync.NewCond(&queue.lock)
+
+	for _, opt := range opts {
+		opt(queue)
+	}
+
+	return queue
+}
+
+// WithBackoff defines a custom backoff for rate limited requeues. The first requeue is delayed by base,
+// every further requeue of the same key doubles the delay up to max.
+func WithBackoff(base, max time.Duration) Option {
+	return func(q *Queue) {
+		q.backoffBase = base
+		q.backoffMax = max
+	}
+}
+
+// Add marks the key for processing. If the key is already waiting, the call has no effect.
+// If the key is currently processed, it is queued again as soon as the worker calls Done().
+func (q *Queue) Add(key string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.shutdown {
+		return
+	}
+	if _, ok := q.dirty[key]; ok {
+		return
+	}
+	q.dirty[key] = struct{}{}
+	if _, ok := q.processing[key]; ok {
+		return
+	}
+	q.queue = append(q.queue, key)
+	q.cond.Signal()
+}
+
+// AddAfter adds the key after the specified delay.
+func (q *Queue) AddAfter(key string, delay time.Duration) {
+	if delay <= 0 {
+		q.Add(key)
+		return
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.shutdown {
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		q.lock.Lock()
+		delete(q.timers, timer)
+		q.lock.Unlock()
+		q.Add(key)
+	})
+	q.timers[timer] = struct{}{}
+}
+
+// AddRateLimited adds the key after an exponential backoff (including a jitter of +/- 20%) that grows with
+// every rate limited requeue of the key. The backoff is reset with Forget().
+func (q *Queue) AddRateLimited(key string) {
+	q.lock.Lock()
+	q.failures[key]++
+	delay := Backoff(q.backoffBase, q.backoffMax, q.failures[key])
+	q.lock.Unlock()
+
+	q.AddAfter(key, delay)
+}
+
+// Forget resets the backoff of the key, it is typically called after the key was processed successfully.
+func (q *Queue) Forget(key string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	delete(q.failures, key)
+}
+
+// NumRequeues returns the number of consecutive rate limited requeues of the key.
+func (q *Queue) NumRequeues(key string) int64 {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.failures[key]
+}
+
+// Get blocks until a key is available and marks it as processing. The worker must call Done() with the key
+// after processing it. Returns false if the queue was shut down.
+func (q *Queue) Get() (string, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for len(q.queue) == 0 && !q.shutdown {
+		q.cond.Wait()
+	}
+	if q.shutdown {
+		return "", false
+	}
+
+	key := q.queue[0]
+	q.queue = q.queue[1:]
+	delete(q.dirty, key)
+	q.processing[key] = struct{}{}
+	return key, true
+}
+
+// Done marks the key as processed. If the key was added while processing, it is queued again.
+func (q *Queue) Done(key string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	delete(q.processing, key)
+	if _, ok := q.dirty[key]; ok && !q.shutdown {
+		q.queue = append(q.queue, key)
+		q.cond.Signal()
+	}
+}
+
+// Len returns the number of keys waiting to be processed.
+func (q *Queue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.queue)
+}
+
+// Shutdown stops the queue, waiting and delayed keys are dropped and all blocked Get() calls return.
+func (q *Queue) Shutdown() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.shutdown = true
+	for timer := range q.timers {
+		timer.Stop()
+	}
+	q.timers = map[*time.Timer]struct{}{}
+	q.cond.Broadcast()
+}
+
+// Backoff returns the exponential delay for the attempt including a jitter of +/- 20%.
+// The first attempt is delayed by base, every further attempt doubles the delay up to max.
+// The helper is shared by all components that retry with backoff (queue requeues, watcher reconnects, etc.).
+func Backoff(base, max time.Duration, attempt int64) time.Duration {
+	delay := base
+	for i := int64(1); i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	if delay <= 0 {
+		return 0
+	}
+	jitter := time.Duration(rand.Int64N(int64(delay)/5*2+1)) - delay/5
+	return delay + jitter
+}
@@ -0,0 +1,182 @@
+/**
+ * Cthul System
+ *
+ * Copyright (C) 2025 Linus Ilian Moser <linus.moser@megakuul.ch>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package workqueue
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestQueue(t *testing.T) {
+	tests := []struct {
+		name string
+		// run executes the steps on the queue and returns the keys in the order they were processed.
+		run  func(q *Queue) []string
+		want []string
+	}{
+		{
+			name: "fifo order",
+			run: func(q *Queue) []string {
+				q.Add("a")
+				q.Add("b")
+				q.Add("c")
+				return drain(q)
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "waiting keys are deduplicated",
+			run: func(q *Queue) []string {
+				q.Add("a")
+				q.Add("b")
+				q.Add("a")
+				return drain(q)
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "key added while processing is requeued after done",
+			run: func(q *Queue) []string {
+				q.Add("a")
+				key, _ := q.Get()
+				q.Add("a")
+				q.Add("b")
+				if q.Len() != 1 {
+					return []string{"processing key was queued"}
+				}
+				q.Done(key)
+				return append([]string{key}, drain(q)...)
+			},
+			want: []string{"a", "b", "a"},
+		},
+		{
+			name: "shutdown drops waiting keys",
+			run: func(q *Queue) []string {
+				q.Add("a")
+				q.Shutdown()
+				q.Add("b")
+				return drain(q)
+			},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.run(New())
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// drain processes all waiting keys and returns them in processing order.
+func drain(q *Queue) []string {
+	keys := []string{}
+	for q.Len() > 0 {
+		key, ok := q.Get()
+		if !ok {
+			break
+		}
+		keys = append(keys, key)
+		q.Done(key)
+	}
+	return keys
+}
+
+func TestGetBlocksUntilShutdown(t *testing.T) {
+	q := New()
+	result := make(chan bool)
+	go func() {
+		_, ok := q.Get()
+		result <- ok
+	}()
+
+	select {
+	case <-result:
+		t.Fatal("expected Get to block on an empty queue")
+	case <-time.After(time.Millisecond * 50):
+	}
+	q.Shutdown()
+	select {
+	case ok := <-result:
+		if ok {
+			t.Error("expected Get to report the shutdown")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Get to return after shutdown")
+	}
+}
+
+func TestAddRateLimited(t *testing.T) {
+	q := New(WithBackoff(time.Millisecond*10, time.Millisecond*40))
+
+	start := time.Now()
+	q.AddRateLimited("a")
+	if q.Len() != 0 {
+		t.Fatal("expected rate limited key to be delayed")
+	}
+	key, _ := q.Get()
+	if elapsed := time.Since(start); elapsed < time.Millisecond*8 {
+		t.Errorf("expected key after at least 8ms, got %v", elapsed)
+	}
+	q.Done(key)
+
+	q.AddRateLimited("a")
+	q.AddRateLimited("a")
+	if q.NumRequeues("a") != 3 {
+		t.Errorf("expected 3 requeues, got %d", q.NumRequeues("a"))
+	}
+	q.Forget("a")
+	if q.NumRequeues("a") != 0 {
+		t.Errorf("expected forget to reset the requeues, got %d", q.NumRequeues("a"))
+	}
+	q.Shutdown()
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    time.Duration
+		max     time.Duration
+		attempt int64
+		want    time.Duration
+	}{
+		{name: "first attempt", base: time.Second, max: time.Minute, attempt: 1, want: time.Second},
+		{name: "attempt zero", base: time.Second, max: time.Minute, attempt: 0, want: time.Second},
+		{name: "doubled", base: time.Second, max: time.Minute, attempt: 3, want: time.Second * 4},
+		{name: "capped", base: time.Second, max: time.Second * 10, attempt: 10, want: time.Second * 10},
+		{name: "huge attempt", base: time.Second, max: time.Minute, attempt: 1 << 40, want: time.Minute},
+		{name: "zero base", base: 0, max: time.Minute, attempt: 5, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				got := Backoff(tt.base, tt.max, tt.attempt)
+				if got < tt.want-tt.want/5 || got > tt.want+tt.want/5 {
+					t.Fatalf("expected %v +/- 20%%, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}